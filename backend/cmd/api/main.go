@@ -9,15 +9,29 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/Alzarus/to-de-olho/internal/agenda"
+	"github.com/Alzarus/to-de-olho/internal/alerta"
 	"github.com/Alzarus/to-de-olho/internal/api"
+	"github.com/Alzarus/to-de-olho/internal/camara/deputado"
+	camaradespesa "github.com/Alzarus/to-de-olho/internal/camara/despesa"
+	camaravotacao "github.com/Alzarus/to-de-olho/internal/camara/votacao"
 	"github.com/Alzarus/to-de-olho/internal/ceaps"
 	"github.com/Alzarus/to-de-olho/internal/comissao"
+	"github.com/Alzarus/to-de-olho/internal/discurso"
 	"github.com/Alzarus/to-de-olho/internal/emenda"
+	"github.com/Alzarus/to-de-olho/internal/fornecedor"
+	"github.com/Alzarus/to-de-olho/internal/gabinete"
+	"github.com/Alzarus/to-de-olho/internal/licenca"
+	"github.com/Alzarus/to-de-olho/internal/municipio"
 	"github.com/Alzarus/to-de-olho/internal/proposicao"
 	"github.com/Alzarus/to-de-olho/internal/ranking"
+	"github.com/Alzarus/to-de-olho/internal/relatoria"
 	"github.com/Alzarus/to-de-olho/internal/scheduler"
 	"github.com/Alzarus/to-de-olho/internal/senador"
 	"github.com/Alzarus/to-de-olho/internal/votacao"
+	"github.com/Alzarus/to-de-olho/pkg/camara"
+	"github.com/Alzarus/to-de-olho/pkg/cnpj"
+	"github.com/Alzarus/to-de-olho/pkg/ibge"
 	"github.com/Alzarus/to-de-olho/pkg/senado"
 	"github.com/joho/godotenv"
 	"gorm.io/driver/postgres"
@@ -46,11 +60,39 @@ func main() {
 	if err := db.AutoMigrate(
 		&senador.Senador{},
 		&senador.Mandato{},
+		&senador.Exercicio{},
+		&senador.Filiacao{},
 		&ceaps.DespesaCEAPS{},
 		&votacao.Votacao{},
+		&votacao.Sessao{},
+		&votacao.Orientacao{},
 		&comissao.ComissaoMembro{},
+		&comissao.Reuniao{},
+		&comissao.Presenca{},
 		&proposicao.Proposicao{},
+		&proposicao.Tramitacao{},
+		&proposicao.Autoria{},
 		&emenda.Emenda{},
+		&emenda.Documento{},
+		&emenda.FavorecidoFinal{},
+		&emenda.TransferenciaEspecial{},
+		&emenda.AutorAlias{},
+		&municipio.UF{},
+		&municipio.Municipio{},
+		&municipio.Populacao{},
+		&deputado.Deputado{},
+		&camaravotacao.Votacao{},
+		&camaradespesa.DespesaCEAP{},
+		&discurso.Discurso{},
+		&agenda.Evento{},
+		&agenda.Item{},
+		&gabinete.Servidor{},
+		&gabinete.Remuneracao{},
+		&gabinete.Lotacao{},
+		&fornecedor.Fornecedor{},
+		&alerta.Alerta{},
+		&relatoria.Relatoria{},
+		&licenca.Licenca{},
 	); err != nil {
 		slog.Error("falha no auto-migrate", "error", err)
 		os.Exit(1)
@@ -76,22 +118,69 @@ func main() {
 	// Repositorios
 	senadorRepo := senador.NewRepository(db)
 	votacaoRepo := votacao.NewRepository(db)
+	if migradas, err := votacaoRepo.MigrarSessoesLegadas(); err != nil {
+		slog.Warn("falha ao migrar sessoes de votacao legadas", "error", err)
+	} else if migradas > 0 {
+		slog.Info("sessoes de votacao legadas migradas", "total", migradas)
+	}
 	ceapsRepo := ceaps.NewRepository(db)
+	if preenchidas, err := ceapsRepo.PreencherDocumentosLimpos(); err != nil {
+		slog.Warn("falha ao preencher documentos limpos das despesas", "error", err)
+	} else if preenchidas > 0 {
+		slog.Info("documentos limpos das despesas preenchidos", "total", preenchidas)
+	}
 	emendaRepo := emenda.NewRepository(db)
 	comissaoRepo := comissao.NewRepository(db)
 	proposicaoRepo := proposicao.NewRepository(db)
+	if migradas, err := proposicaoRepo.MigrarAutoriasLegadas(); err != nil {
+		slog.Warn("falha ao migrar autorias legadas", "error", err)
+	} else if migradas > 0 {
+		slog.Info("autorias legadas migradas", "total", migradas)
+	}
+	municipioRepo := municipio.NewRepository(db)
+	if carregados, err := municipioRepo.CarregarReferencia(); err != nil {
+		slog.Warn("falha ao carregar referencia de municipios", "error", err)
+	} else {
+		slog.Info("referencia de municipios carregada", "municipios", carregados)
+	}
+	deputadoRepo := deputado.NewRepository(db)
+	camaraVotacaoRepo := camaravotacao.NewRepository(db)
+	camaraDespesaRepo := camaradespesa.NewRepository(db)
+	discursoRepo := discurso.NewRepository(db)
+	agendaRepo := agenda.NewRepository(db)
+	gabineteRepo := gabinete.NewRepository(db)
+	fornecedorRepo := fornecedor.NewRepository(db)
+	alertaRepo := alerta.NewRepository(db)
+	relatoriaRepo := relatoria.NewRepository(db)
+	licencaRepo := licenca.NewRepository(db)
 
 	// Clients
 	legisClient := senado.NewLegisClient()
 	admClient := senado.NewAdmClient()
+	camaraClient := camara.NewClient()
+	cnpjClient := cnpj.NewClient()
 
 	// Sync Services (Modules)
 	senadorSync := senador.NewSyncService(senadorRepo, legisClient)
 	votacaoSync := votacao.NewSyncService(votacaoRepo, senadorRepo, legisClient)
 	ceapsSync := ceaps.NewSyncService(ceapsRepo, senadorRepo, admClient)
 	emendaSync := emenda.NewSyncService(emendaRepo, senadorRepo, transparenciaKey)
+	emendaSync.SetMunicipioRepository(municipioRepo)
+	municipioSync := municipio.NewSyncService(municipioRepo, ibge.NewClient())
 	comissaoSync := comissao.NewSyncService(comissaoRepo, senadorRepo, legisClient)
 	proposicaoSync := proposicao.NewSyncService(proposicaoRepo, senadorRepo, legisClient)
+	deputadoSync := deputado.NewSyncService(deputadoRepo, camaraClient)
+	camaraVotacaoSync := camaravotacao.NewSyncService(camaraVotacaoRepo, deputadoRepo, camaraClient)
+	camaraDespesaSync := camaradespesa.NewSyncService(camaraDespesaRepo, deputadoRepo, camaraClient)
+	discursoSync := discurso.NewSyncService(discursoRepo, senadorRepo, legisClient)
+	agendaSync := agenda.NewSyncService(agendaRepo, legisClient)
+	gabineteSync := gabinete.NewSyncService(gabineteRepo, senadorRepo, admClient)
+	fornecedorSync := fornecedor.NewSyncService(fornecedorRepo, cnpjClient)
+	relatoriaSync := relatoria.NewSyncService(relatoriaRepo, senadorRepo, legisClient)
+	licencaSync := licenca.NewSyncService(licencaRepo, senadorRepo, legisClient)
+
+	// Motor de alertas roda apos a consolidacao de fornecedores (ver scheduler)
+	alertaService := alerta.NewService(alertaRepo, ceapsRepo, senadorRepo, fornecedorRepo)
 
 	// Ranking Service (necessario para recalcular aps sync, suporta redis mas passamos nil)
 	rankingService := ranking.NewService(
@@ -101,6 +190,9 @@ func main() {
 		ceapsRepo,
 		comissaoRepo,
 	)
+	rankingService.SetDiscursoRepository(discursoRepo)
+	rankingService.SetRelatoriaRepository(relatoriaRepo)
+	rankingService.HabilitarAlinhamento()
 
 	// Iniciar Scheduler
 	sched := scheduler.NewScheduler(
@@ -113,6 +205,17 @@ func main() {
 		rankingService,
 		senadorRepo,
 		votacaoRepo,
+		deputadoSync,
+		camaraVotacaoSync,
+		camaraDespesaSync,
+		discursoSync,
+		agendaSync,
+		gabineteSync,
+		fornecedorSync,
+		relatoriaSync,
+		licencaSync,
+		municipioSync,
+		alertaService,
 	)
 
 	// Contexto para o scheduler (cancelado no shutdown)
@@ -121,6 +224,20 @@ func main() {
 
 	sched.Start(ctxSched)
 
+	// Sem a lista completa de municipios quase todas as localidades ficam sem codigo IBGE;
+	// completa a referencia com a API do IBGE sem esperar o proximo backfill
+	if completa, err := municipioSync.ReferenciaCompleta(); err == nil && !completa {
+		go func() {
+			if err := municipioSync.SyncFromIBGE(ctxSched); err != nil {
+				slog.Warn("falha ao completar referencia de municipios", "error", err)
+				return
+			}
+			if _, err := emendaSync.ResolverLocalidades(); err != nil {
+				slog.Warn("falha ao resolver localidades das emendas", "error", err)
+			}
+		}()
+	}
+
 	// Registrar endpoint de sync diario (Cloud Scheduler)
 	api.RegisterSchedulerRoutes(router, sched)
 	// -----------------------------------------------------------------------------
@@ -9,10 +9,12 @@ import (
 	"github.com/Alzarus/to-de-olho/internal/ceaps"
 	"github.com/Alzarus/to-de-olho/internal/comissao"
 	"github.com/Alzarus/to-de-olho/internal/emenda"
+	"github.com/Alzarus/to-de-olho/internal/municipio"
 	"github.com/Alzarus/to-de-olho/internal/proposicao"
 	"github.com/Alzarus/to-de-olho/internal/ranking"
 	"github.com/Alzarus/to-de-olho/internal/senador"
 	"github.com/Alzarus/to-de-olho/internal/votacao"
+	"github.com/Alzarus/to-de-olho/pkg/ibge"
 	"github.com/Alzarus/to-de-olho/pkg/senado"
 	"github.com/joho/godotenv"
 	"gorm.io/driver/postgres"
@@ -69,6 +71,9 @@ func main() {
 	votacaoSync := votacao.NewSyncService(votacaoRepo, senadorRepo, legisClient)
 	ceapsSync := ceaps.NewSyncService(ceapsRepo, senadorRepo, admClient)
 	emendaSync := emenda.NewSyncService(emendaRepo, senadorRepo, transparenciaKey)
+	municipioRepo := municipio.NewRepository(db)
+	emendaSync.SetMunicipioRepository(municipioRepo)
+	municipioSync := municipio.NewSyncService(municipioRepo, ibge.NewClient())
 	comissaoSync := comissao.NewSyncService(comissaoRepo, senadorRepo, legisClient)
 	proposicaoSync := proposicao.NewSyncService(proposicaoRepo, senadorRepo, legisClient)
 	
@@ -88,20 +93,20 @@ func main() {
 		slog.Error("falha sync senadores", "error", err)
 	}
 
-	// B. Votacoes List (All Years)
-	slog.Info(">>> 2. VOTACOES (LISTA GERAL)")
-	if err := votacaoSync.SyncFromAPI(ctx); err != nil {
-		slog.Error("falha sync votacoes list", "error", err)
+	// B. Municipios (IBGE), para resolver as localidades das emendas
+	slog.Info(">>> 2. MUNICIPIOS (IBGE)")
+	if err := municipioSync.SyncFromIBGE(ctx); err != nil {
+		slog.Error("falha sync municipios", "error", err)
 	}
 
 	// C. Loop 2023..2026
 	for ano := startAno; ano <= endAno; ano++ {
 		slog.Info(">>> PROCESSANDO ANO", "ano", ano)
 		
-		// Metadata
-		slog.Info("   > Metadata Votacoes")
-		if err := votacaoSync.SyncMetadata(ctx, ano); err != nil {
-			slog.Error("falha metadata", "ano", ano, "error", err)
+		// Votacoes (sessoes + votos)
+		slog.Info("   > Votacoes")
+		if err := votacaoSync.SyncFromAPI(ctx, ano); err != nil {
+			slog.Error("falha votacoes", "ano", ano, "error", err)
 		}
 
 		// CEAPS
@@ -115,6 +120,18 @@ func main() {
 		if err := emendaSync.SyncAll(ctx, ano); err != nil {
 			slog.Error("falha emendas", "ano", ano, "error", err)
 		}
+
+		// Transferencias especiais ("emendas PIX")
+		slog.Info("   > Transferencias especiais (Transferegov)")
+		if err := emendaSync.SyncTransferenciasEspeciais(ctx, ano); err != nil {
+			slog.Error("falha transferencias especiais", "ano", ano, "error", err)
+		}
+
+		// Reunioes de comissoes (presenca)
+		slog.Info("   > Reunioes de comissoes")
+		if err := comissaoSync.SyncReunioes(ctx, ano); err != nil {
+			slog.Error("falha reunioes de comissoes", "ano", ano, "error", err)
+		}
 	}
 
 	// D. Comissoes & Proposicoes
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"sort"
+
+	"github.com/Alzarus/to-de-olho/internal/municipio"
+	"github.com/Alzarus/to-de-olho/pkg/ibge"
+)
+
+// Regenera a tabela de municipios distribuida com o binario a partir da API de
+// localidades do IBGE (DTB), mantendo a marcacao de capitais do CSV atual.
+// Uso: gerar_municipios [internal/municipio/dados/municipios.csv]
+func main() {
+	caminho := "internal/municipio/dados/municipios.csv"
+	if len(os.Args) > 1 {
+		caminho = os.Args[1]
+	}
+
+	_, atuais, err := municipio.ReferenciaEmbutida()
+	if err != nil {
+		log.Fatal("Falha ao ler referencia atual:", err)
+	}
+	capitais := make(map[string]bool)
+	for _, m := range atuais {
+		if m.Capital {
+			capitais[m.CodigoIBGE] = true
+		}
+	}
+
+	// O SyncService so usa o client para listar os municipios; nao grava no banco
+	sync := municipio.NewSyncService(nil, ibge.NewClient())
+	municipios, err := sync.MunicipiosIBGE(context.Background())
+	if err != nil {
+		log.Fatal("Falha ao consultar o IBGE:", err)
+	}
+	if len(municipios) < municipio.MinimoMunicipiosReferencia {
+		log.Fatalf("Lista do IBGE incompleta: %d municipios", len(municipios))
+	}
+	for i := range municipios {
+		municipios[i].Capital = capitais[municipios[i].CodigoIBGE]
+	}
+	sort.Slice(municipios, func(i, j int) bool {
+		return municipios[i].CodigoIBGE < municipios[j].CodigoIBGE
+	})
+
+	arquivo, err := os.Create(caminho)
+	if err != nil {
+		log.Fatal("Falha ao criar CSV:", err)
+	}
+	defer arquivo.Close()
+	if err := municipio.EscreverMunicipiosCSV(arquivo, municipios); err != nil {
+		log.Fatal("Falha ao gravar CSV:", err)
+	}
+	log.Printf("%d municipios gravados em %s\n", len(municipios), caminho)
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/Alzarus/to-de-olho/internal/emenda"
+	"github.com/Alzarus/to-de-olho/internal/municipio"
 	"github.com/Alzarus/to-de-olho/internal/senador"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -26,9 +27,16 @@ func main() {
 		log.Fatal("Informe o caminho do CSV via EMENDAS_CSV_PATH ou argumento CLI")
 	}
 
+	// Aliases de autores usados na identificacao dos senadores
+	if err := db.AutoMigrate(&emenda.AutorAlias{}); err != nil {
+		log.Fatal("Falha ao migrar aliases de autores:", err)
+	}
+
 	senadorRepo := senador.NewRepository(db)
 	emendaRepo := emenda.NewRepository(db)
 	service := emenda.NewService(emendaRepo, senadorRepo)
+	// Resolve as localidades em codigos IBGE ao final da importacao
+	service.SetMunicipioRepository(municipio.NewRepository(db))
 
 	log.Println("Iniciando importacao CSV de emendas...")
 	if err := service.ImportarCSV(caminhoCSV); err != nil {
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/Alzarus/to-de-olho/internal/municipio"
+	"github.com/Alzarus/to-de-olho/pkg/ibge"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Importa as estimativas de populacao do IBGE (planilha exportada em CSV separado por ";")
+// Uso: seed_populacao <arquivo.csv> <ano>
+func main() {
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		dsn = "host=localhost user=postgres password=postgres dbname=todeolho port=5432 sslmode=disable"
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		log.Fatal("Falha ao conectar ao banco:", err)
+	}
+
+	if len(os.Args) < 3 {
+		log.Fatal("Uso: seed_populacao <arquivo.csv> <ano>")
+	}
+	caminhoCSV := os.Args[1]
+	ano, err := strconv.Atoi(os.Args[2])
+	if err != nil || ano < 1990 {
+		log.Fatal("Ano invalido:", os.Args[2])
+	}
+
+	if err := db.AutoMigrate(&municipio.Populacao{}); err != nil {
+		log.Fatal("Falha no auto-migrate:", err)
+	}
+
+	municipioRepo := municipio.NewRepository(db)
+	sync := municipio.NewSyncService(municipioRepo, ibge.NewClient())
+
+	log.Println("Iniciando importacao de populacao...")
+	total, err := sync.ImportarPopulacaoCSV(caminhoCSV, ano)
+	if err != nil {
+		log.Fatal("Falha ao importar populacao:", err)
+	}
+	log.Printf("Importacao concluida: %d municipios (%d)\n", total, ano)
+}
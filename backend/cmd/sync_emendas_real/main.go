@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/Alzarus/to-de-olho/internal/emenda"
+	"github.com/Alzarus/to-de-olho/internal/municipio"
 	"github.com/Alzarus/to-de-olho/internal/senador"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -33,9 +34,16 @@ func main() {
 		log.Fatal("Falha ao conectar ao banco:", err)
 	}
 
+	// Aliases de autores usados na identificacao dos senadores
+	if err := db.AutoMigrate(&emenda.AutorAlias{}); err != nil {
+		log.Fatal("Falha ao migrar aliases de autores:", err)
+	}
+
 	senadorRepo := senador.NewRepository(db)
 	emendaRepo := emenda.NewRepository(db)
 	syncService := emenda.NewSyncService(emendaRepo, senadorRepo, apiKey)
+	// Resolve as localidades em codigos IBGE ao final da importacao
+	syncService.SetMunicipioRepository(municipio.NewRepository(db))
 
 	anos := parseAnos(os.Getenv("EMENDAS_ANOS"))
 	if len(anos) == 0 {
@@ -0,0 +1,113 @@
+package agenda
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaxDiasPeriodo limita o intervalo consultado em uma unica requisicao
+const MaxDiasPeriodo = 92
+
+// Handler gerencia endpoints REST da agenda
+type Handler struct {
+	repo *Repository
+}
+
+// NewHandler cria um novo handler
+func NewHandler(repo *Repository) *Handler {
+	return &Handler{repo: repo}
+}
+
+// List godoc
+// @Summary Lista a agenda do plenario e das comissoes
+// @Tags agenda
+// @Produce json
+// @Param inicio query string false "Data inicial YYYY-MM-DD (default hoje)"
+// @Param fim query string false "Data final YYYY-MM-DD (default inicio + 7 dias)"
+// @Param tipo query string false "plenario ou comissao"
+// @Param comissao query string false "Codigo da comissao"
+// @Param materia query string false "Codigo da materia"
+// @Param senador_id query int false "Apenas eventos com materias de autoria do senador"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/agenda [get]
+func (h *Handler) List(c *gin.Context) {
+	inicio := time.Now().UTC().Truncate(24 * time.Hour)
+	if v := c.Query("inicio"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "inicio invalido (use YYYY-MM-DD)"})
+			return
+		}
+		inicio = t
+	}
+
+	fim := inicio.AddDate(0, 0, 7)
+	if v := c.Query("fim"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "fim invalido (use YYYY-MM-DD)"})
+			return
+		}
+		fim = t
+	}
+
+	if fim.Before(inicio) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "fim deve ser posterior ao inicio"})
+		return
+	}
+	if fim.Sub(inicio) > MaxDiasPeriodo*24*time.Hour {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "periodo maximo de 92 dias"})
+		return
+	}
+
+	tipo := c.Query("tipo")
+	if tipo != "" && tipo != TipoPlenario && tipo != TipoComissao {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "tipo invalido (plenario ou comissao)"})
+		return
+	}
+
+	senadorID, _ := strconv.Atoi(c.Query("senador_id"))
+
+	filtro := Filtro{
+		Inicio:         inicio,
+		Fim:            fim,
+		Tipo:           tipo,
+		CodigoComissao: c.Query("comissao"),
+		CodigoMateria:  c.Query("materia"),
+		SenadorID:      senadorID,
+	}
+
+	eventos, err := h.repo.FindByFiltro(filtro)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar agenda"})
+		return
+	}
+
+	// Marcar os itens de autoria do senador para destaque no frontend
+	if senadorID > 0 {
+		var codigos []string
+		for _, e := range eventos {
+			for _, item := range e.Itens {
+				codigos = append(codigos, item.CodigoMateria)
+			}
+		}
+		autorias, err := h.repo.FindMateriasDoSenador(senadorID, codigos)
+		if err == nil {
+			for i := range eventos {
+				for j := range eventos[i].Itens {
+					eventos[i].Itens[j].DeAutoria = autorias[eventos[i].Itens[j].CodigoMateria]
+				}
+			}
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"inicio":  inicio.Format("2006-01-02"),
+		"fim":     fim.Format("2006-01-02"),
+		"total":   len(eventos),
+		"eventos": eventos,
+	})
+}
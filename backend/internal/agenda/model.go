@@ -0,0 +1,67 @@
+package agenda
+
+import "time"
+
+// Tipos de evento da agenda
+const (
+	TipoPlenario = "plenario"
+	TipoComissao = "comissao"
+)
+
+// Evento representa uma sessao do plenario ou reuniao de comissao agendada
+type Evento struct {
+	ID             int        `gorm:"primaryKey" json:"id"`
+	CodigoEvento   string     `gorm:"uniqueIndex;not null" json:"codigo_evento"` // PLEN-{codigo} ou COM-{codigo}
+	Tipo           string     `gorm:"index;not null" json:"tipo"`                // plenario, comissao
+	Casa           string     `json:"casa"`                                      // SF, CN
+	Data           *time.Time `gorm:"index" json:"data,omitempty"`
+	Hora           string     `json:"hora,omitempty"`
+	Descricao      string     `json:"descricao"` // Tipo da sessao/reuniao
+	Situacao       string     `json:"situacao,omitempty"`
+	Local          string     `json:"local,omitempty"`
+	CodigoComissao string     `gorm:"index" json:"codigo_comissao,omitempty"` // Mesmo codigo de comissao_membros
+	SiglaComissao  string     `json:"sigla_comissao,omitempty"`
+	NomeComissao   string     `json:"nome_comissao,omitempty"`
+
+	Itens []Item `gorm:"foreignKey:EventoID;constraint:OnDelete:CASCADE" json:"itens"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName define o nome da tabela
+func (Evento) TableName() string {
+	return "agenda_eventos"
+}
+
+// Item representa uma materia na pauta de um evento
+type Item struct {
+	ID            int    `gorm:"primaryKey" json:"id"`
+	EventoID      int    `gorm:"index;not null" json:"evento_id"`
+	Sequencia     int    `json:"sequencia"`
+	CodigoMateria string `gorm:"index" json:"codigo_materia"` // Mesmo codigo de proposicoes.codigo_materia
+	SiglaMateria  string `json:"sigla_materia"`
+	NumeroMateria string `json:"numero_materia"`
+	AnoMateria    int    `json:"ano_materia"`
+	Ementa        string `json:"ementa,omitempty"`
+	Relator       string `json:"relator,omitempty"`
+	Parte         string `json:"parte,omitempty"` // Bloco da pauta nas reunioes de comissao
+
+	// Preenchido apenas em consultas filtradas por senador
+	DeAutoria bool `gorm:"-" json:"de_autoria,omitempty"`
+}
+
+// TableName define o nome da tabela
+func (Item) TableName() string {
+	return "agenda_itens"
+}
+
+// Filtro define os filtros de consulta da agenda
+type Filtro struct {
+	Inicio         time.Time
+	Fim            time.Time
+	Tipo           string
+	CodigoComissao string
+	CodigoMateria  string
+	SenadorID      int // Apenas eventos com materias de autoria do senador
+}
@@ -0,0 +1,107 @@
+package agenda
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository encapsula operacoes de banco de dados para a agenda
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository cria um novo repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// FindByFiltro retorna os eventos do periodo com seus itens de pauta
+func (r *Repository) FindByFiltro(f Filtro) ([]Evento, error) {
+	var eventos []Evento
+
+	err := filtrarEventos(r.db, f).
+		Preload("Itens", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sequencia ASC")
+		}).
+		Order("data ASC, hora ASC").
+		Find(&eventos).Error
+
+	return eventos, err
+}
+
+// filtrarEventos monta a consulta de eventos do filtro. O filtro por senador usa
+// proposicao_autorias para incluir as materias em coautoria, nao so as do primeiro autor.
+func filtrarEventos(db *gorm.DB, f Filtro) *gorm.DB {
+	query := db.Model(&Evento{}).
+		Where("data >= ? AND data <= ?", f.Inicio, f.Fim)
+
+	if f.Tipo != "" {
+		query = query.Where("tipo = ?", f.Tipo)
+	}
+	if f.CodigoComissao != "" {
+		query = query.Where("codigo_comissao = ?", f.CodigoComissao)
+	}
+	if f.CodigoMateria != "" {
+		query = query.Where("id IN (?)", db.Model(&Item{}).Select("evento_id").Where("codigo_materia = ?", f.CodigoMateria))
+	}
+	if f.SenadorID > 0 {
+		materiasAutor := db.Table("proposicao_autorias").Select("codigo_materia").Where("senador_id = ?", f.SenadorID)
+		query = query.Where("id IN (?)", db.Model(&Item{}).Select("evento_id").Where("codigo_materia IN (?)", materiasAutor))
+	}
+	return query
+}
+
+// FindMateriasDoSenador retorna, entre os codigos informados, as materias de autoria do senador
+func (r *Repository) FindMateriasDoSenador(senadorID int, codigos []string) (map[string]bool, error) {
+	autorias := make(map[string]bool)
+	if len(codigos) == 0 {
+		return autorias, nil
+	}
+
+	var encontrados []string
+	err := r.db.Table("proposicao_autorias").
+		Where("senador_id = ? AND codigo_materia IN ?", senadorID, codigos).
+		Pluck("codigo_materia", &encontrados).Error
+	for _, c := range encontrados {
+		autorias[c] = true
+	}
+	return autorias, err
+}
+
+// UpsertEvento insere ou atualiza um evento e substitui sua pauta
+func (r *Repository) UpsertEvento(evento *Evento) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		itens := evento.Itens
+		evento.Itens = nil
+
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "codigo_evento"}},
+			DoUpdates: clause.AssignmentColumns([]string{"tipo", "casa", "data", "hora", "descricao", "situacao", "local", "codigo_comissao", "sigla_comissao", "nome_comissao", "updated_at"}),
+		}).Create(evento).Error; err != nil {
+			return err
+		}
+
+		// O ID nao e retornado no caminho de update do ON CONFLICT em todos os drivers
+		if err := tx.Model(&Evento{}).Select("id").Where("codigo_evento = ?", evento.CodigoEvento).Scan(&evento.ID).Error; err != nil {
+			return err
+		}
+
+		// A pauta muda ate o dia do evento: substituir em vez de mesclar
+		if err := tx.Where("evento_id = ?", evento.ID).Delete(&Item{}).Error; err != nil {
+			return err
+		}
+
+		for i := range itens {
+			itens[i].ID = 0
+			itens[i].EventoID = evento.ID
+		}
+		if len(itens) > 0 {
+			if err := tx.CreateInBatches(itens, 100).Error; err != nil {
+				return err
+			}
+		}
+
+		evento.Itens = itens
+		return nil
+	})
+}
@@ -0,0 +1,61 @@
+package agenda
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// TestFiltrarEventos confere o SQL gerado (DryRun, sem banco): o filtro por senador deve
+// partir de proposicao_autorias, que tem uma linha por autor e coautor da materia
+func TestFiltrarEventos(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inicio := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	casos := []struct {
+		nome      string
+		filtro    Filtro
+		contem    []string
+		naoContem []string
+	}{
+		{
+			nome:      "senador inclui coautorias",
+			filtro:    Filtro{SenadorID: 7},
+			contem:    []string{`SELECT codigo_materia FROM "proposicao_autorias" WHERE senador_id = 7`},
+			naoContem: []string{`"proposicoes"`},
+		},
+		{
+			nome:   "materia",
+			filtro: Filtro{CodigoMateria: "165432"},
+			contem: []string{`codigo_materia = '165432'`},
+		},
+		{
+			nome:      "apenas periodo",
+			filtro:    Filtro{},
+			naoContem: []string{"agenda_itens", "proposicao_autorias"},
+		},
+	}
+
+	for _, c := range casos {
+		c.filtro.Inicio, c.filtro.Fim = inicio, inicio.AddDate(0, 0, 7)
+		sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+			return filtrarEventos(tx, c.filtro).Find(&[]Evento{})
+		})
+		for _, trecho := range c.contem {
+			if !strings.Contains(sql, trecho) {
+				t.Errorf("%s: SQL sem %q: %s", c.nome, trecho, sql)
+			}
+		}
+		for _, trecho := range c.naoContem {
+			if strings.Contains(sql, trecho) {
+				t.Errorf("%s: SQL nao deveria conter %q: %s", c.nome, trecho, sql)
+			}
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package agenda
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/Alzarus/to-de-olho/internal/utils"
+	senadoapi "github.com/Alzarus/to-de-olho/pkg/senado"
+)
+
+// SyncService gerencia sincronizacao da agenda do plenario e das comissoes
+type SyncService struct {
+	repo   *Repository
+	client *senadoapi.LegisClient
+}
+
+// NewSyncService cria um novo servico de sincronizacao
+func NewSyncService(repo *Repository, client *senadoapi.LegisClient) *SyncService {
+	return &SyncService{
+		repo:   repo,
+		client: client,
+	}
+}
+
+// SyncProximosDias sincroniza a agenda de ontem ate N dias a frente
+// Ontem entra para atualizar a situacao de eventos recem realizados
+func (s *SyncService) SyncProximosDias(ctx context.Context, dias int) error {
+	hoje := time.Now().UTC().Truncate(24 * time.Hour)
+	return s.SyncPeriodo(ctx, hoje.AddDate(0, 0, -1), hoje.AddDate(0, 0, dias))
+}
+
+// SyncPeriodo sincroniza a agenda entre duas datas (inclusive)
+func (s *SyncService) SyncPeriodo(ctx context.Context, inicio, fim time.Time) error {
+	slog.Info("iniciando sync de agenda", "inicio", inicio.Format("2006-01-02"), "fim", fim.Format("2006-01-02"))
+
+	var totalPlenario, totalComissoes int
+
+	// A agenda do plenario e consultada por mes
+	for _, mes := range mesesNoPeriodo(inicio, fim) {
+		sessoes, err := s.client.ListarAgendaPlenario(ctx, mes)
+		if err != nil {
+			slog.Warn("falha ao buscar agenda do plenario", "mes", mes.Format("2006-01"), "error", err)
+			continue
+		}
+
+		for _, sessao := range sessoes {
+			evento := convertSessao(sessao)
+			if evento.Data == nil || evento.Data.Before(inicio) || evento.Data.After(fim) {
+				continue
+			}
+			if err := s.repo.UpsertEvento(&evento); err != nil {
+				slog.Warn("falha ao salvar sessao da agenda", "codigo", sessao.CodigoSessao, "error", err)
+				continue
+			}
+			totalPlenario++
+		}
+	}
+
+	// A agenda das comissoes aceita intervalo; consultar em janelas mensais para limitar o payload
+	for _, mes := range mesesNoPeriodo(inicio, fim) {
+		janelaInicio, janelaFim := limitarAoMes(mes, inicio, fim)
+
+		reunioes, err := s.client.ListarAgendaComissoes(ctx, janelaInicio, janelaFim)
+		if err != nil {
+			slog.Warn("falha ao buscar agenda de comissoes", "mes", mes.Format("2006-01"), "error", err)
+			continue
+		}
+
+		for _, reuniao := range reunioes {
+			evento := convertReuniao(reuniao)
+			if evento.Data == nil {
+				continue
+			}
+			if err := s.repo.UpsertEvento(&evento); err != nil {
+				slog.Warn("falha ao salvar reuniao da agenda", "codigo", reuniao.Codigo, "error", err)
+				continue
+			}
+			totalComissoes++
+		}
+	}
+
+	slog.Info("sync de agenda concluido", "sessoes_plenario", totalPlenario, "reunioes_comissoes", totalComissoes)
+	return nil
+}
+
+// convertSessao converte uma sessao da agenda do plenario para o modelo interno
+func convertSessao(s senadoapi.SessaoAgendaAPI) Evento {
+	descricao := s.TipoSessao
+	if s.Evento.DescricaoTipoEvento != "" {
+		descricao = s.Evento.DescricaoTipoEvento
+	}
+
+	evento := Evento{
+		CodigoEvento: "PLEN-" + s.CodigoSessao,
+		Tipo:         TipoPlenario,
+		Casa:         s.SiglaCasa,
+		Data:         utils.ParseDataISO(s.DataSessao),
+		Hora:         s.HoraInicioSessao,
+		Descricao:    descricao,
+		Situacao:     s.SituacaoSessao,
+		Local:        "Plenario",
+	}
+
+	for _, m := range s.Materias.Materia {
+		if m.CodigoMateria == "" {
+			continue
+		}
+		seq, _ := strconv.Atoi(m.SequenciaOrdem)
+		ano, _ := strconv.Atoi(m.AnoMateria)
+		evento.Itens = append(evento.Itens, Item{
+			Sequencia:     seq,
+			CodigoMateria: m.CodigoMateria,
+			SiglaMateria:  m.SiglaMateria,
+			NumeroMateria: m.NumeroMateria,
+			AnoMateria:    ano,
+			Ementa:        m.Ementa,
+			Relator:       m.NomeRelator,
+		})
+	}
+
+	return evento
+}
+
+// convertReuniao converte uma reuniao de comissao para o modelo interno
+func convertReuniao(r senadoapi.ReuniaoAgendaAPI) Evento {
+	descricao := r.Tipo
+	if r.Titulo != "" {
+		descricao = r.Titulo
+	}
+
+	evento := Evento{
+		CodigoEvento:   "COM-" + r.Codigo,
+		Tipo:           TipoComissao,
+		Casa:           r.Comissao.SiglaCasa,
+		Data:           utils.ParseDataISO(r.Data),
+		Hora:           r.Hora,
+		Descricao:      descricao,
+		Situacao:       r.Situacao,
+		Local:          r.Local,
+		CodigoComissao: r.Comissao.Codigo,
+		SiglaComissao:  r.Comissao.Sigla,
+		NomeComissao:   r.Comissao.Nome,
+	}
+
+	seq := 0
+	for _, parte := range r.Partes.Parte {
+		for _, item := range parte.Itens.Item {
+			if item.Materia.Codigo == "" {
+				continue
+			}
+			seq++
+			if n, err := strconv.Atoi(item.Sequencial); err == nil {
+				seq = n
+			}
+			ano, _ := strconv.Atoi(item.Materia.Ano)
+			evento.Itens = append(evento.Itens, Item{
+				Sequencia:     seq,
+				CodigoMateria: item.Materia.Codigo,
+				SiglaMateria:  item.Materia.Sigla,
+				NumeroMateria: item.Materia.Numero,
+				AnoMateria:    ano,
+				Ementa:        item.Materia.Ementa,
+				Relator:       item.Relator,
+				Parte:         parte.Descricao,
+			})
+		}
+	}
+
+	return evento
+}
+
+// mesesNoPeriodo retorna o primeiro dia de cada mes coberto pelo intervalo
+func mesesNoPeriodo(inicio, fim time.Time) []time.Time {
+	var meses []time.Time
+	mes := time.Date(inicio.Year(), inicio.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for !mes.After(fim) {
+		meses = append(meses, mes)
+		mes = mes.AddDate(0, 1, 0)
+	}
+	return meses
+}
+
+// limitarAoMes recorta o intervalo [inicio, fim] ao mes informado
+func limitarAoMes(mes, inicio, fim time.Time) (time.Time, time.Time) {
+	janelaInicio := mes
+	janelaFim := mes.AddDate(0, 1, -1)
+	if inicio.After(janelaInicio) {
+		janelaInicio = inicio
+	}
+	if fim.Before(janelaFim) {
+		janelaFim = fim
+	}
+	return janelaInicio, janelaFim
+}
@@ -0,0 +1,59 @@
+package agenda
+
+import (
+	"testing"
+	"time"
+
+	senadoapi "github.com/Alzarus/to-de-olho/pkg/senado"
+)
+
+func TestMesesNoPeriodo(t *testing.T) {
+	inicio := time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)
+	fim := time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	meses := mesesNoPeriodo(inicio, fim)
+	if len(meses) != 3 {
+		t.Fatalf("esperado 3 meses, obtido %d", len(meses))
+	}
+	if meses[0].Month() != time.January || meses[2].Month() != time.March {
+		t.Errorf("meses inesperados: %v", meses)
+	}
+
+	janelaInicio, janelaFim := limitarAoMes(meses[0], inicio, fim)
+	if !janelaInicio.Equal(inicio) || janelaFim.Day() != 31 {
+		t.Errorf("janela de janeiro inesperada: %v - %v", janelaInicio, janelaFim)
+	}
+	janelaInicio, janelaFim = limitarAoMes(meses[2], inicio, fim)
+	if janelaInicio.Day() != 1 || !janelaFim.Equal(fim) {
+		t.Errorf("janela de marco inesperada: %v - %v", janelaInicio, janelaFim)
+	}
+}
+
+func TestConvertReuniao(t *testing.T) {
+	var r senadoapi.ReuniaoAgendaAPI
+	r.Codigo = "12345"
+	r.Data = "2025-04-09T10:00:00"
+	r.Tipo = "Deliberativa"
+	r.Comissao.Codigo = "34"
+	r.Comissao.Sigla = "CCJ"
+
+	var parte senadoapi.ParteReuniaoAPI
+	parte.Descricao = "Deliberativa"
+	item := senadoapi.ItemPautaAPI{Sequencial: "2"}
+	item.Materia.Codigo = "160000"
+	item.Materia.Ano = "2023"
+	semMateria := senadoapi.ItemPautaAPI{Sequencial: "3"}
+	parte.Itens.Item = senadoapi.Lista[senadoapi.ItemPautaAPI]{item, semMateria}
+	r.Partes.Parte = senadoapi.Lista[senadoapi.ParteReuniaoAPI]{parte}
+
+	evento := convertReuniao(r)
+	if evento.CodigoEvento != "COM-12345" || evento.Tipo != TipoComissao || evento.CodigoComissao != "34" {
+		t.Errorf("evento inesperado: %+v", evento)
+	}
+	if evento.Data == nil || evento.Data.Day() != 9 {
+		t.Errorf("data inesperada: %v", evento.Data)
+	}
+	if len(evento.Itens) != 1 || evento.Itens[0].CodigoMateria != "160000" || evento.Itens[0].Sequencia != 2 || evento.Itens[0].AnoMateria != 2023 {
+		t.Errorf("itens inesperados: %+v", evento.Itens)
+	}
+}
@@ -0,0 +1,53 @@
+package alerta
+
+import "time"
+
+// feriadosNacionais retorna os feriados nacionais (fixos e moveis) de um ano.
+// Carnaval e Corpus Christi sao pontos facultativos, mas tratados como feriado
+// por nao haver expediente no Senado.
+func feriadosNacionais(ano int) map[string]bool {
+	pascoa := domingoDePascoa(ano)
+	datas := []time.Time{
+		time.Date(ano, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(ano, time.April, 21, 0, 0, 0, 0, time.UTC),
+		time.Date(ano, time.May, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(ano, time.September, 7, 0, 0, 0, 0, time.UTC),
+		time.Date(ano, time.October, 12, 0, 0, 0, 0, time.UTC),
+		time.Date(ano, time.November, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(ano, time.November, 15, 0, 0, 0, 0, time.UTC),
+		time.Date(ano, time.December, 25, 0, 0, 0, 0, time.UTC),
+		pascoa.AddDate(0, 0, -48), // Segunda de Carnaval
+		pascoa.AddDate(0, 0, -47), // Terca de Carnaval
+		pascoa.AddDate(0, 0, -2),  // Sexta-feira Santa
+		pascoa.AddDate(0, 0, 60),  // Corpus Christi
+	}
+	// Consciencia Negra virou feriado nacional em 2024 (Lei 14.759/2023)
+	if ano >= 2024 {
+		datas = append(datas, time.Date(ano, time.November, 20, 0, 0, 0, 0, time.UTC))
+	}
+
+	feriados := make(map[string]bool, len(datas))
+	for _, d := range datas {
+		feriados[d.Format("2006-01-02")] = true
+	}
+	return feriados
+}
+
+// domingoDePascoa calcula a data da Pascoa (algoritmo de Meeus/Jones/Butcher)
+func domingoDePascoa(ano int) time.Time {
+	a := ano % 19
+	b := ano / 100
+	c := ano % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	mes := (h + l - 7*m + 114) / 31
+	dia := ((h + l - 7*m + 114) % 31) + 1
+	return time.Date(ano, time.Month(mes), dia, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,122 @@
+package alerta
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler gerencia endpoints REST de alertas
+type Handler struct {
+	repo *Repository
+}
+
+// NewHandler cria um novo handler
+func NewHandler(repo *Repository) *Handler {
+	return &Handler{repo: repo}
+}
+
+// ListBySenador godoc
+// @Summary Lista alertas das despesas CEAPS de um senador
+// @Tags alertas
+// @Produce json
+// @Param id path int true "ID do senador"
+// @Param ano query int false "Ano de referencia"
+// @Param regra query string false "ID da regra"
+// @Param limit query int false "Limite (default 20)"
+// @Param page query int false "Pagina (default 1)"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/senadores/{id}/despesas/alertas [get]
+func (h *Handler) ListBySenador(c *gin.Context) {
+	senadorID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalido"})
+		return
+	}
+
+	filtro := parseFiltro(c)
+	filtro.SenadorID = senadorID
+
+	alertas, total, err := h.repo.Find(filtro)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar alertas"})
+		return
+	}
+
+	porRegra, err := h.repo.CountPorRegra(senadorID, filtro.Ano)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao agregar alertas"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"senador_id":  senadorID,
+		"total":       total,
+		"limit":       filtro.Limit,
+		"page":        filtro.Offset/filtro.Limit + 1,
+		"total_pages": (int(total) + filtro.Limit - 1) / filtro.Limit,
+		"por_regra":   porRegra,
+		"alertas":     alertas,
+	})
+}
+
+// ListFeed godoc
+// @Summary Feed global de alertas das despesas CEAPS
+// @Tags alertas
+// @Produce json
+// @Param ano query int false "Ano de referencia"
+// @Param regra query string false "ID da regra"
+// @Param severidade query string false "baixa, media ou alta"
+// @Param limit query int false "Limite (default 20)"
+// @Param page query int false "Pagina (default 1)"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/alertas [get]
+func (h *Handler) ListFeed(c *gin.Context) {
+	filtro := parseFiltro(c)
+
+	alertas, total, err := h.repo.Find(filtro)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar alertas"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"total":       total,
+		"limit":       filtro.Limit,
+		"page":        filtro.Offset/filtro.Limit + 1,
+		"total_pages": (int(total) + filtro.Limit - 1) / filtro.Limit,
+		"alertas":     alertas,
+	})
+}
+
+// ListRegras godoc
+// @Summary Lista as regras do motor de alertas
+// @Tags alertas
+// @Produce json
+// @Success 200 {array} RegraInfo
+// @Router /api/v1/alertas/regras [get]
+func (h *Handler) ListRegras(c *gin.Context) {
+	c.JSON(http.StatusOK, Regras)
+}
+
+// parseFiltro le filtros comuns de ?ano, ?regra, ?severidade, ?limit e ?page
+func parseFiltro(c *gin.Context) Filtro {
+	limit := 20
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+	page := 1
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	ano, _ := strconv.Atoi(c.Query("ano"))
+
+	return Filtro{
+		Ano:        ano,
+		Regra:      c.Query("regra"),
+		Severidade: c.Query("severidade"),
+		Limit:      limit,
+		Offset:     (page - 1) * limit,
+	}
+}
@@ -0,0 +1,102 @@
+package alerta
+
+import "time"
+
+// Identificadores das regras do motor de alertas (RF20)
+const (
+	RegraDocumentoDuplicado = "DOC_DUPLICADO"
+	RegraProximoLimite      = "PROXIMO_LIMITE"
+	RegraFimDeSemana        = "FIM_DE_SEMANA_FERIADO"
+	RegraCNPJRecente        = "CNPJ_RECENTE"
+	RegraOutlierTipo        = "OUTLIER_TIPO"
+)
+
+// Severidades dos alertas
+const (
+	SeveridadeBaixa = "baixa"
+	SeveridadeMedia = "media"
+	SeveridadeAlta  = "alta"
+)
+
+// Alerta representa um indicio de irregularidade em despesas CEAPS.
+// Alertas nao sao acusacoes: indicam lancamentos que merecem verificacao.
+type Alerta struct {
+	ID          int        `gorm:"primaryKey" json:"id"`
+	Chave       string     `gorm:"uniqueIndex;not null" json:"-"` // Regra + referencia, evita duplicidade
+	Regra       string     `gorm:"index;not null" json:"regra"`
+	Severidade  string     `json:"severidade"`
+	SenadorID   int        `gorm:"index:idx_alerta_senador_ano,priority:1;not null" json:"senador_id"`
+	DespesaID   *int       `gorm:"index" json:"despesa_id,omitempty"` // Nulo em alertas mensais
+	Ano         int        `gorm:"index:idx_alerta_senador_ano,priority:2;not null" json:"ano"`
+	Mes         int        `json:"mes"`
+	Data        *time.Time `json:"data,omitempty"`
+	TipoDespesa string     `json:"tipo_despesa,omitempty"`
+	Fornecedor  string     `json:"fornecedor,omitempty"`
+	CNPJCPF     string     `gorm:"column:cnpj_cpf" json:"cnpj_cpf,omitempty"`
+	Valor       float64    `json:"valor"`
+	Descricao   string     `json:"descricao"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName define o nome da tabela
+func (Alerta) TableName() string {
+	return "alertas_ceaps"
+}
+
+// AlertaFeed representa um alerta com dados do senador para o feed global
+type AlertaFeed struct {
+	Alerta
+	NomeSenador string `json:"nome_senador"`
+	Partido     string `json:"partido"`
+	UF          string `json:"uf"`
+}
+
+// AlertasPorRegra representa contagem de alertas por regra
+type AlertasPorRegra struct {
+	Regra string  `json:"regra"`
+	Total int     `json:"total"`
+	Valor float64 `json:"valor"`
+}
+
+// RegraInfo descreve uma regra do motor de alertas
+type RegraInfo struct {
+	ID         string `json:"id"`
+	Nome       string `json:"nome"`
+	Severidade string `json:"severidade"`
+	Descricao  string `json:"descricao"`
+}
+
+// Regras lista as regras aplicadas, na ordem de execucao
+var Regras = []RegraInfo{
+	{RegraDocumentoDuplicado, "Documento duplicado", SeveridadeAlta,
+		"Mesmo numero de documento do mesmo fornecedor reembolsado mais de uma vez"},
+	{RegraProximoLimite, "Gasto mensal no limite da cota", SeveridadeMedia,
+		"Total do mes entre 97% e 100% do teto da CEAPS da UF do senador"},
+	{RegraFimDeSemana, "Nota em fim de semana ou feriado", SeveridadeBaixa,
+		"Documento emitido em sabado, domingo ou feriado nacional (exceto locomocao e passagens)"},
+	{RegraCNPJRecente, "Fornecedor recem-aberto", SeveridadeAlta,
+		"CNPJ aberto menos de 180 dias antes da despesa (ou depois dela)"},
+	{RegraOutlierTipo, "Valor atipico para o tipo de despesa", SeveridadeMedia,
+		"Valor muito acima da mediana do tipo de despesa no ano (desvio absoluto mediano > 3,5)"},
+}
+
+// severidadeDaRegra retorna a severidade padrao de uma regra
+func severidadeDaRegra(regra string) string {
+	for _, r := range Regras {
+		if r.ID == regra {
+			return r.Severidade
+		}
+	}
+	return SeveridadeBaixa
+}
+
+// Filtro define os filtros de consulta de alertas
+type Filtro struct {
+	SenadorID  int
+	Ano        int
+	Regra      string
+	Severidade string
+	Limit      int
+	Offset     int
+}
@@ -0,0 +1,240 @@
+package alerta
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Alzarus/to-de-olho/internal/ceaps"
+	"github.com/Alzarus/to-de-olho/internal/utils"
+)
+
+const (
+	// FaixaLimiteMensal define o inicio da faixa "no limite" da cota (97% do teto)
+	FaixaLimiteMensal = 0.97
+
+	// DiasCNPJRecente define quando um CNPJ e considerado recem-aberto na data da despesa
+	DiasCNPJRecente = 180
+
+	// LimiarOutlier e o desvio robusto (MAD normalizado) a partir do qual um valor e atipico
+	LimiarOutlier = 3.5
+
+	// AmostraMinimaOutlier evita marcar outliers em tipos com poucos lancamentos
+	AmostraMinimaOutlier = 30
+)
+
+// novoAlertaDespesa cria um alerta referente a uma despesa especifica
+func novoAlertaDespesa(regra string, d ceaps.DespesaCEAPS, descricao string) Alerta {
+	id := d.ID
+	return Alerta{
+		Chave:       fmt.Sprintf("%s:%d", regra, d.ID),
+		Regra:       regra,
+		Severidade:  severidadeDaRegra(regra),
+		SenadorID:   d.SenadorID,
+		DespesaID:   &id,
+		Ano:         d.Ano,
+		Mes:         d.Mes,
+		Data:        d.DataEmissao,
+		TipoDespesa: d.TipoDespesa,
+		Fornecedor:  d.Fornecedor,
+		CNPJCPF:     d.CNPJCPF,
+		Valor:       d.Valor,
+		Descricao:   descricao,
+	}
+}
+
+// regraDocumentoDuplicado marca lancamentos com o mesmo numero de documento do mesmo fornecedor
+func regraDocumentoDuplicado(despesas []ceaps.DespesaCEAPS) []Alerta {
+	grupos := make(map[string][]ceaps.DespesaCEAPS)
+	for _, d := range despesas {
+		doc := normalizarNumeroDocumento(d.Documento)
+		cnpj := utils.ApenasDigitos(d.CNPJCPF)
+		if doc == "" || cnpj == "" {
+			continue
+		}
+		chave := cnpj + "|" + doc
+		grupos[chave] = append(grupos[chave], d)
+	}
+
+	var alertas []Alerta
+	for _, grupo := range grupos {
+		if len(grupo) < 2 {
+			continue
+		}
+		for _, d := range grupo {
+			descricao := fmt.Sprintf("Documento %s de %s aparece em %d lancamentos", d.Documento, d.Fornecedor, len(grupo))
+			alertas = append(alertas, novoAlertaDespesa(RegraDocumentoDuplicado, d, descricao))
+		}
+	}
+	return alertas
+}
+
+// normalizarNumeroDocumento remove zeros a esquerda e descarta numeros genericos (S/N, 0)
+func normalizarNumeroDocumento(documento string) string {
+	doc := strings.ToUpper(strings.TrimSpace(documento))
+	doc = strings.TrimLeft(doc, "0")
+	switch doc {
+	case "", "S/N", "SN", "S N", "-":
+		return ""
+	}
+	return doc
+}
+
+// regraProximoLimite marca meses em que o total gasto ficou logo abaixo do teto da UF
+func regraProximoLimite(despesas []ceaps.DespesaCEAPS, tetoPorSenador map[int]float64) []Alerta {
+	type chaveMes struct {
+		senadorID, ano, mes int
+	}
+	totais := make(map[chaveMes]float64)
+	for _, d := range despesas {
+		totais[chaveMes{d.SenadorID, d.Ano, d.Mes}] += d.Valor
+	}
+
+	var alertas []Alerta
+	for k, total := range totais {
+		teto, ok := tetoPorSenador[k.senadorID]
+		if !ok || teto <= 0 {
+			continue
+		}
+		percentual := total / teto
+		if percentual < FaixaLimiteMensal || percentual > 1 {
+			continue
+		}
+		alertas = append(alertas, Alerta{
+			Chave:      fmt.Sprintf("%s:%d:%d-%02d", RegraProximoLimite, k.senadorID, k.ano, k.mes),
+			Regra:      RegraProximoLimite,
+			Severidade: severidadeDaRegra(RegraProximoLimite),
+			SenadorID:  k.senadorID,
+			Ano:        k.ano,
+			Mes:        k.mes,
+			Valor:      total,
+			Descricao:  fmt.Sprintf("Gasto de %02d/%d atingiu %.1f%% do teto mensal (R$ %.2f)", k.mes, k.ano, percentual*100, teto),
+		})
+	}
+	return alertas
+}
+
+// regraFimDeSemana marca notas emitidas em fins de semana ou feriados nacionais.
+// Locomocao e passagens sao ignoradas: viagens no fim de semana sao rotina do mandato.
+func regraFimDeSemana(despesas []ceaps.DespesaCEAPS) []Alerta {
+	feriadosPorAno := make(map[int]map[string]bool)
+
+	var alertas []Alerta
+	for _, d := range despesas {
+		if d.DataEmissao == nil || tipoDeViagem(d.TipoDespesa) {
+			continue
+		}
+		data := *d.DataEmissao
+
+		feriados, ok := feriadosPorAno[data.Year()]
+		if !ok {
+			feriados = feriadosNacionais(data.Year())
+			feriadosPorAno[data.Year()] = feriados
+		}
+
+		var motivo string
+		switch {
+		case feriados[data.Format("2006-01-02")]:
+			motivo = "feriado nacional"
+		case data.Weekday() == time.Saturday:
+			motivo = "sabado"
+		case data.Weekday() == time.Sunday:
+			motivo = "domingo"
+		default:
+			continue
+		}
+
+		descricao := fmt.Sprintf("Documento emitido em %s (%s)", data.Format("02/01/2006"), motivo)
+		alertas = append(alertas, novoAlertaDespesa(RegraFimDeSemana, d, descricao))
+	}
+	return alertas
+}
+
+// tipoDeViagem identifica categorias da CEAPS ligadas a deslocamento
+func tipoDeViagem(tipo string) bool {
+	t := strings.ToLower(tipo)
+	return strings.Contains(t, "locomo") || strings.Contains(t, "passage")
+}
+
+// regraCNPJRecente marca despesas com fornecedores abertos pouco antes (ou depois) da nota
+func regraCNPJRecente(despesas []ceaps.DespesaCEAPS, datasAbertura map[string]time.Time) []Alerta {
+	var alertas []Alerta
+	for _, d := range despesas {
+		if d.DataEmissao == nil {
+			continue
+		}
+		abertura, ok := datasAbertura[utils.ApenasDigitos(d.CNPJCPF)]
+		if !ok {
+			continue
+		}
+
+		dias := int(d.DataEmissao.Sub(abertura).Hours() / 24)
+		if dias >= DiasCNPJRecente {
+			continue
+		}
+
+		descricao := fmt.Sprintf("CNPJ aberto em %s, %d dias antes da despesa", abertura.Format("02/01/2006"), dias)
+		if dias < 0 {
+			descricao = fmt.Sprintf("Despesa emitida %d dias antes da abertura do CNPJ (%s)", -dias, abertura.Format("02/01/2006"))
+		}
+		alertas = append(alertas, novoAlertaDespesa(RegraCNPJRecente, d, descricao))
+	}
+	return alertas
+}
+
+// regraOutlierTipo marca valores muito acima do padrao do tipo de despesa.
+// Usa mediana e desvio absoluto mediano (MAD), robustos aos proprios outliers.
+func regraOutlierTipo(despesas []ceaps.DespesaCEAPS) []Alerta {
+	porTipo := make(map[string][]ceaps.DespesaCEAPS)
+	for _, d := range despesas {
+		porTipo[d.TipoDespesa] = append(porTipo[d.TipoDespesa], d)
+	}
+
+	var alertas []Alerta
+	for tipo, grupo := range porTipo {
+		if len(grupo) < AmostraMinimaOutlier {
+			continue
+		}
+
+		valores := make([]float64, len(grupo))
+		for i, d := range grupo {
+			valores[i] = d.Valor
+		}
+		med := mediana(valores)
+
+		desvios := make([]float64, len(valores))
+		for i, v := range valores {
+			desvios[i] = math.Abs(v - med)
+		}
+		mad := mediana(desvios) * 1.4826 // Escala para equivaler ao desvio padrao na normal
+		if mad == 0 {
+			continue
+		}
+
+		for _, d := range grupo {
+			escore := (d.Valor - med) / mad
+			if escore <= LimiarOutlier {
+				continue
+			}
+			descricao := fmt.Sprintf("Valor R$ %.2f muito acima da mediana de %s (R$ %.2f)", d.Valor, tipo, med)
+			alertas = append(alertas, novoAlertaDespesa(RegraOutlierTipo, d, descricao))
+		}
+	}
+	return alertas
+}
+
+// mediana calcula a mediana sem alterar o slice original
+func mediana(valores []float64) float64 {
+	if len(valores) == 0 {
+		return 0
+	}
+	ordenados := append([]float64(nil), valores...)
+	sort.Float64s(ordenados)
+	meio := len(ordenados) / 2
+	if len(ordenados)%2 == 0 {
+		return (ordenados[meio-1] + ordenados[meio]) / 2
+	}
+	return ordenados[meio]
+}
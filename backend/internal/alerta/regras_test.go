@@ -0,0 +1,131 @@
+package alerta
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Alzarus/to-de-olho/internal/ceaps"
+)
+
+func data(ano int, mes time.Month, dia int) *time.Time {
+	t := time.Date(ano, mes, dia, 0, 0, 0, 0, time.UTC)
+	return &t
+}
+
+func TestDomingoDePascoa(t *testing.T) {
+	esperados := map[int]string{
+		2023: "2023-04-09",
+		2024: "2024-03-31",
+		2025: "2025-04-20",
+		2026: "2026-04-05",
+	}
+	for ano, esperado := range esperados {
+		if got := domingoDePascoa(ano).Format("2006-01-02"); got != esperado {
+			t.Errorf("domingoDePascoa(%d) = %s; esperado %s", ano, got, esperado)
+		}
+	}
+
+	feriados := feriadosNacionais(2024)
+	for _, dia := range []string{"2024-02-12", "2024-02-13", "2024-03-29", "2024-05-30", "2024-11-20"} {
+		if !feriados[dia] {
+			t.Errorf("%s deveria ser feriado", dia)
+		}
+	}
+	if feriadosNacionais(2023)["2023-11-20"] {
+		t.Error("20/11 nao era feriado nacional em 2023")
+	}
+}
+
+func TestRegraDocumentoDuplicado(t *testing.T) {
+	despesas := []ceaps.DespesaCEAPS{
+		{ID: 1, SenadorID: 1, CNPJCPF: "12.345.678/0001-90", Documento: "000123", Valor: 100},
+		{ID: 2, SenadorID: 2, CNPJCPF: "12345678000190", Documento: "123", Valor: 100},
+		{ID: 3, SenadorID: 1, CNPJCPF: "12345678000190", Documento: "S/N", Valor: 100},
+		{ID: 4, SenadorID: 1, CNPJCPF: "12345678000190", Documento: "s/n", Valor: 100},
+		{ID: 5, SenadorID: 1, CNPJCPF: "98765432000100", Documento: "123", Valor: 100},
+	}
+
+	alertas := regraDocumentoDuplicado(despesas)
+	if len(alertas) != 2 {
+		t.Fatalf("esperado 2 alertas, obtido %d", len(alertas))
+	}
+	for _, a := range alertas {
+		if *a.DespesaID != 1 && *a.DespesaID != 2 {
+			t.Errorf("despesa inesperada no alerta: %d", *a.DespesaID)
+		}
+		if a.Regra != RegraDocumentoDuplicado || a.Severidade != SeveridadeAlta {
+			t.Errorf("regra/severidade inesperadas: %s/%s", a.Regra, a.Severidade)
+		}
+	}
+}
+
+func TestRegraProximoLimite(t *testing.T) {
+	despesas := []ceaps.DespesaCEAPS{
+		{SenadorID: 1, Ano: 2024, Mes: 3, Valor: 30000},
+		{SenadorID: 1, Ano: 2024, Mes: 3, Valor: 9000},  // 39.000 de 40.000 = 97,5%
+		{SenadorID: 1, Ano: 2024, Mes: 4, Valor: 41000}, // acima do teto
+		{SenadorID: 2, Ano: 2024, Mes: 3, Valor: 20000},
+	}
+	teto := map[int]float64{1: 40000, 2: 40000}
+
+	alertas := regraProximoLimite(despesas, teto)
+	if len(alertas) != 1 {
+		t.Fatalf("esperado 1 alerta, obtido %d", len(alertas))
+	}
+	if alertas[0].SenadorID != 1 || alertas[0].Mes != 3 || alertas[0].DespesaID != nil {
+		t.Errorf("alerta inesperado: %+v", alertas[0])
+	}
+}
+
+func TestRegraFimDeSemana(t *testing.T) {
+	despesas := []ceaps.DespesaCEAPS{
+		{ID: 1, DataEmissao: data(2024, time.March, 9), TipoDespesa: "Aquisição de material de consumo"},        // sabado
+		{ID: 2, DataEmissao: data(2024, time.March, 11), TipoDespesa: "Aquisição de material de consumo"},       // segunda
+		{ID: 3, DataEmissao: data(2024, time.March, 10), TipoDespesa: "Locomoção, hospedagem, alimentação"},     // domingo, viagem
+		{ID: 4, DataEmissao: data(2024, time.December, 25), TipoDespesa: "Divulgação da atividade parlamentar"}, // natal
+		{ID: 5, TipoDespesa: "Aquisição de material de consumo"},
+	}
+
+	alertas := regraFimDeSemana(despesas)
+	if len(alertas) != 2 {
+		t.Fatalf("esperado 2 alertas, obtido %d", len(alertas))
+	}
+	if *alertas[0].DespesaID != 1 || *alertas[1].DespesaID != 4 {
+		t.Errorf("despesas inesperadas: %d, %d", *alertas[0].DespesaID, *alertas[1].DespesaID)
+	}
+}
+
+func TestRegraCNPJRecente(t *testing.T) {
+	aberturas := map[string]time.Time{
+		"12345678000190": time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+	despesas := []ceaps.DespesaCEAPS{
+		{ID: 1, CNPJCPF: "12.345.678/0001-90", DataEmissao: data(2024, time.February, 1)},
+		{ID: 2, CNPJCPF: "12345678000190", DataEmissao: data(2024, time.December, 1)},
+		{ID: 3, CNPJCPF: "12345678000190", DataEmissao: data(2023, time.December, 1)},
+		{ID: 4, CNPJCPF: "98765432000100", DataEmissao: data(2024, time.February, 1)},
+	}
+
+	alertas := regraCNPJRecente(despesas, aberturas)
+	if len(alertas) != 2 {
+		t.Fatalf("esperado 2 alertas, obtido %d", len(alertas))
+	}
+	if *alertas[0].DespesaID != 1 || *alertas[1].DespesaID != 3 {
+		t.Errorf("despesas inesperadas: %d, %d", *alertas[0].DespesaID, *alertas[1].DespesaID)
+	}
+}
+
+func TestRegraOutlierTipo(t *testing.T) {
+	var despesas []ceaps.DespesaCEAPS
+	for i := 0; i < AmostraMinimaOutlier; i++ {
+		despesas = append(despesas, ceaps.DespesaCEAPS{ID: i + 1, TipoDespesa: "Combustivel", Valor: 200 + float64(i%5)*10})
+	}
+	despesas = append(despesas, ceaps.DespesaCEAPS{ID: 100, TipoDespesa: "Combustivel", Valor: 5000})
+	// Tipo com poucos lancamentos nao gera alerta
+	despesas = append(despesas, ceaps.DespesaCEAPS{ID: 200, TipoDespesa: "Consultoria", Valor: 90000})
+
+	alertas := regraOutlierTipo(despesas)
+	if len(alertas) != 1 || *alertas[0].DespesaID != 100 {
+		t.Fatalf("esperado alerta apenas para a despesa 100, obtido %+v", alertas)
+	}
+}
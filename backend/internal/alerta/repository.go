@@ -0,0 +1,80 @@
+package alerta
+
+import (
+	"gorm.io/gorm"
+)
+
+// Repository encapsula operacoes de banco de dados para Alerta
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository cria um novo repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// ReplaceByAno substitui os alertas de um ano pelo resultado da ultima analise
+func (r *Repository) ReplaceByAno(ano int, alertas []Alerta) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("ano = ?", ano).Delete(&Alerta{}).Error; err != nil {
+			return err
+		}
+		if len(alertas) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(alertas, 200).Error
+	})
+}
+
+// Find retorna alertas com filtros e paginacao, incluindo dados do senador
+func (r *Repository) Find(f Filtro) ([]AlertaFeed, int64, error) {
+	var alertas []AlertaFeed
+	var total int64
+
+	query := r.db.Table("alertas_ceaps a").
+		Joins("JOIN senadores s ON s.id = a.senador_id")
+
+	if f.SenadorID > 0 {
+		query = query.Where("a.senador_id = ?", f.SenadorID)
+	}
+	if f.Ano > 0 {
+		query = query.Where("a.ano = ?", f.Ano)
+	}
+	if f.Regra != "" {
+		query = query.Where("a.regra = ?", f.Regra)
+	}
+	if f.Severidade != "" {
+		query = query.Where("a.severidade = ?", f.Severidade)
+	}
+
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Select("a.*, s.nome as nome_senador, s.partido, s.uf").
+		Order("a.ano DESC, a.mes DESC, a.valor DESC").
+		Limit(f.Limit).
+		Offset(f.Offset).
+		Scan(&alertas).Error
+
+	return alertas, total, err
+}
+
+// CountPorRegra retorna a contagem e o valor envolvido por regra (senadorID 0 = todos)
+func (r *Repository) CountPorRegra(senadorID int, ano int) ([]AlertasPorRegra, error) {
+	var result []AlertasPorRegra
+
+	query := r.db.Model(&Alerta{}).
+		Select("regra, COUNT(*) as total, COALESCE(SUM(valor), 0) as valor")
+	if senadorID > 0 {
+		query = query.Where("senador_id = ?", senadorID)
+	}
+	if ano > 0 {
+		query = query.Where("ano = ?", ano)
+	}
+
+	err := query.Group("regra").Order("total DESC").Scan(&result).Error
+	return result, err
+}
@@ -0,0 +1,71 @@
+package alerta
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Alzarus/to-de-olho/internal/ceaps"
+	"github.com/Alzarus/to-de-olho/internal/fornecedor"
+	"github.com/Alzarus/to-de-olho/internal/senador"
+)
+
+// Service executa as regras do motor de alertas sobre as despesas CEAPS
+// Roda depois da consolidacao de fornecedores e da consulta de CNPJs (ver scheduler)
+type Service struct {
+	repo           *Repository
+	ceapsRepo      *ceaps.Repository
+	senadorRepo    *senador.Repository
+	fornecedorRepo *fornecedor.Repository
+}
+
+// NewService cria um novo servico de alertas
+func NewService(repo *Repository, ceapsRepo *ceaps.Repository, senadorRepo *senador.Repository, fornecedorRepo *fornecedor.Repository) *Service {
+	return &Service{
+		repo:           repo,
+		ceapsRepo:      ceapsRepo,
+		senadorRepo:    senadorRepo,
+		fornecedorRepo: fornecedorRepo,
+	}
+}
+
+// AnalisarAno aplica todas as regras as despesas de um ano e persiste os alertas
+func (s *Service) AnalisarAno(ctx context.Context, ano int) error {
+	slog.Info("iniciando analise de alertas CEAPS", "ano", ano)
+
+	despesas, err := s.ceapsRepo.FindByAno(ano)
+	if err != nil {
+		return err
+	}
+
+	// Teto mensal da UF de cada senador (inclui fora de exercicio, que tambem tem despesas)
+	senadores, err := s.senadorRepo.FindAll(true)
+	if err != nil {
+		return err
+	}
+	tetoPorSenador := make(map[int]float64, len(senadores))
+	for _, sen := range senadores {
+		if teto, ok := ceaps.TetoMensalPorUF[sen.UF]; ok {
+			tetoPorSenador[sen.ID] = teto
+		}
+	}
+
+	// Sem dados cadastrais a regra de CNPJ recente apenas nao dispara
+	datasAbertura, err := s.fornecedorRepo.FindDatasAbertura()
+	if err != nil {
+		slog.Warn("falha ao carregar datas de abertura de CNPJ", "error", err)
+	}
+
+	var alertas []Alerta
+	alertas = append(alertas, regraDocumentoDuplicado(despesas)...)
+	alertas = append(alertas, regraProximoLimite(despesas, tetoPorSenador)...)
+	alertas = append(alertas, regraFimDeSemana(despesas)...)
+	alertas = append(alertas, regraCNPJRecente(despesas, datasAbertura)...)
+	alertas = append(alertas, regraOutlierTipo(despesas)...)
+
+	if err := s.repo.ReplaceByAno(ano, alertas); err != nil {
+		return err
+	}
+
+	slog.Info("analise de alertas CEAPS concluida", "ano", ano, "despesas", len(despesas), "alertas", len(alertas))
+	return nil
+}
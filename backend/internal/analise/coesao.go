@@ -0,0 +1,108 @@
+package analise
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// MinVotosCoesao e o minimo de votos Sim/Nao da bancada para que a sessao entre no
+// calculo de coesao (com um unico voto o indice seria sempre 100)
+const MinVotosCoesao = 2
+
+// VotosPartidoSessao agrega os votos Sim/Nao de um partido em uma sessao
+type VotosPartidoSessao struct {
+	SessaoID string
+	Data     time.Time
+	Materia  string
+	Partido  string
+	Sim      int
+	Nao      int
+}
+
+// CoesaoSessao e o indice de Rice de um partido em uma sessao
+type CoesaoSessao struct {
+	SessaoID string    `json:"sessao_id"`
+	Data     time.Time `json:"data"`
+	Materia  string    `json:"materia,omitempty"`
+	Sim      int       `json:"sim"`
+	Nao      int       `json:"nao"`
+	Coesao   float64   `json:"coesao"` // 0-100
+}
+
+// CoesaoMensal e a media do indice de Rice de um partido nas sessoes do mes
+type CoesaoMensal struct {
+	Mes     string  `json:"mes"` // YYYY-MM
+	Sessoes int     `json:"sessoes"`
+	Coesao  float64 `json:"coesao"` // 0-100
+}
+
+// IndiceRice calcula o indice de coesao de Rice: |Sim - Nao| / (Sim + Nao), em 0-100.
+// 100 indica bancada unanime; 0, bancada dividida ao meio.
+func IndiceRice(sim, nao int) float64 {
+	if sim+nao == 0 {
+		return 0
+	}
+	return math.Abs(float64(sim-nao)) / float64(sim+nao) * 100
+}
+
+// CalcularCoesao converte os votos agregados em indices por sessao, em ordem cronologica,
+// descartando sessoes com menos de MinVotosCoesao votos da bancada
+func CalcularCoesao(votos []VotosPartidoSessao) []CoesaoSessao {
+	sessoes := make([]CoesaoSessao, 0, len(votos))
+	for _, v := range votos {
+		if v.Sim+v.Nao < MinVotosCoesao {
+			continue
+		}
+		sessoes = append(sessoes, CoesaoSessao{
+			SessaoID: v.SessaoID,
+			Data:     v.Data,
+			Materia:  v.Materia,
+			Sim:      v.Sim,
+			Nao:      v.Nao,
+			Coesao:   IndiceRice(v.Sim, v.Nao),
+		})
+	}
+	sort.SliceStable(sessoes, func(i, j int) bool {
+		return sessoes[i].Data.Before(sessoes[j].Data)
+	})
+	return sessoes
+}
+
+// SerieMensal agrupa os indices por mes, com a media simples das sessoes de cada mes
+func SerieMensal(sessoes []CoesaoSessao) []CoesaoMensal {
+	porMes := make(map[string]*CoesaoMensal)
+	var meses []string
+	for _, s := range sessoes {
+		mes := s.Data.Format("2006-01")
+		m, ok := porMes[mes]
+		if !ok {
+			m = &CoesaoMensal{Mes: mes}
+			porMes[mes] = m
+			meses = append(meses, mes)
+		}
+		m.Sessoes++
+		m.Coesao += s.Coesao
+	}
+	sort.Strings(meses)
+
+	serie := make([]CoesaoMensal, 0, len(meses))
+	for _, mes := range meses {
+		m := porMes[mes]
+		m.Coesao /= float64(m.Sessoes)
+		serie = append(serie, *m)
+	}
+	return serie
+}
+
+// MediaCoesao retorna a media do indice nas sessoes informadas
+func MediaCoesao(sessoes []CoesaoSessao) float64 {
+	if len(sessoes) == 0 {
+		return 0
+	}
+	var soma float64
+	for _, s := range sessoes {
+		soma += s.Coesao
+	}
+	return soma / float64(len(sessoes))
+}
@@ -0,0 +1,84 @@
+package analise
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestIndiceRice(t *testing.T) {
+	casos := []struct {
+		sim, nao int
+		esperado float64
+	}{
+		{10, 0, 100},
+		{0, 7, 100},
+		{5, 5, 0},
+		{6, 2, 50},
+		{0, 0, 0},
+	}
+	for _, c := range casos {
+		if got := IndiceRice(c.sim, c.nao); math.Abs(got-c.esperado) > 1e-9 {
+			t.Errorf("IndiceRice(%d, %d) = %v, esperado %v", c.sim, c.nao, got, c.esperado)
+		}
+	}
+}
+
+func TestCalcularCoesaoESerieMensal(t *testing.T) {
+	data := func(mes time.Month, dia int) time.Time {
+		return time.Date(2025, mes, dia, 0, 0, 0, 0, time.UTC)
+	}
+	votos := []VotosPartidoSessao{
+		{SessaoID: "3_2025", Data: data(time.April, 2), Sim: 4, Nao: 4},
+		{SessaoID: "1_2025", Data: data(time.March, 10), Sim: 8, Nao: 0},
+		{SessaoID: "2_2025", Data: data(time.March, 20), Sim: 6, Nao: 2},
+		// Um unico voto nao mede coesao
+		{SessaoID: "4_2025", Data: data(time.April, 9), Sim: 1},
+	}
+
+	sessoes := CalcularCoesao(votos)
+	if len(sessoes) != 3 {
+		t.Fatalf("esperado 3 sessoes, obtido %d", len(sessoes))
+	}
+	if sessoes[0].SessaoID != "1_2025" || sessoes[2].SessaoID != "3_2025" {
+		t.Errorf("sessoes fora de ordem cronologica: %+v", sessoes)
+	}
+
+	serie := SerieMensal(sessoes)
+	if len(serie) != 2 {
+		t.Fatalf("esperado 2 meses, obtido %d", len(serie))
+	}
+	if serie[0].Mes != "2025-03" || serie[0].Sessoes != 2 || serie[0].Coesao != 75 {
+		t.Errorf("marco: %+v", serie[0])
+	}
+	if serie[1].Mes != "2025-04" || serie[1].Sessoes != 1 || serie[1].Coesao != 0 {
+		t.Errorf("abril: %+v", serie[1])
+	}
+
+	if media := MediaCoesao(sessoes); media != 50 {
+		t.Errorf("media = %v, esperado 50", media)
+	}
+	if MediaCoesao(nil) != 0 {
+		t.Error("media sem sessoes deve ser 0")
+	}
+}
+
+func TestSiglaPartidoValida(t *testing.T) {
+	casos := map[string]bool{
+		"PT":            true,
+		"UNIÃO":         true,
+		"União":         true,
+		"PCdoB":         true,
+		"SOLIDARIEDADE": true,
+		"PODE2":         true,
+		"P":             false,
+		"PC do B":       false,
+		"PT;DROP":       false,
+		"":              false,
+	}
+	for sigla, esperado := range casos {
+		if got := siglaPartidoValida.MatchString(sigla); got != esperado {
+			t.Errorf("siglaPartidoValida(%q) = %v, esperado %v", sigla, got, esperado)
+		}
+	}
+}
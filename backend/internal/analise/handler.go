@@ -0,0 +1,311 @@
+package analise
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Alzarus/to-de-olho/internal/senador"
+	"github.com/Alzarus/to-de-olho/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// siglaPartidoValida aceita letras, inclusive acentuadas (UNIÃO), e digitos
+var siglaPartidoValida = regexp.MustCompile(`^[\p{L}0-9]{2,15}$`)
+
+// Handler gerencia endpoints de analise de votacoes
+type Handler struct {
+	repo        *Repository
+	senadorRepo *senador.Repository
+}
+
+// NewHandler cria um novo handler de analises
+func NewHandler(repo *Repository, senadorRepo *senador.Repository) *Handler {
+	return &Handler{repo: repo, senadorRepo: senadorRepo}
+}
+
+// PontoSenador e a posicao de um senador no mapa de similaridade
+type PontoSenador struct {
+	SenadorID int     `json:"senador_id"`
+	Nome      string  `json:"nome"`
+	Partido   string  `json:"partido"`
+	UF        string  `json:"uf"`
+	FotoURL   string  `json:"foto_url,omitempty"`
+	X         float64 `json:"x"`
+	Y         float64 `json:"y"`
+	Grupo     int     `json:"grupo"`
+}
+
+// SimilarSenador e um senador na lista de similares, com os dados de exibicao
+type SimilarSenador struct {
+	Similaridade
+	Nome    string `json:"nome"`
+	Partido string `json:"partido"`
+	UF      string `json:"uf"`
+}
+
+// GetSimilaridade godoc
+// @Summary Matriz de concordancia entre senadores e mapa 2D (MDS classico) com agrupamento
+// @Description Concordancia = fracao das votacoes nominais em comum (Sim/Nao) em que dois senadores votaram igual.
+// @Tags analises
+// @Produce json
+// @Param ano query int false "Ano das votacoes"
+// @Param inicio query string false "Data inicial YYYY-MM-DD (default inicio da legislatura)"
+// @Param fim query string false "Data final YYYY-MM-DD (default hoje)"
+// @Param grupos query int false "Numero de grupos do k-means (default 3, max 10)"
+// @Param min_comuns query int false "Minimo de votos em comum por par (default 10)"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/analises/similaridade [get]
+func (h *Handler) GetSimilaridade(c *gin.Context) {
+	inicio, fim, err := parsePeriodo(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	k := 3
+	if v, err := strconv.Atoi(c.Query("grupos")); err == nil && v >= 1 && v <= 10 {
+		k = v
+	}
+
+	matriz, err := h.carregarMatriz(c, inicio, fim)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao carregar votacoes"})
+		return
+	}
+
+	senadores, err := h.senadoresPorID()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar senadores"})
+		return
+	}
+
+	coords := MDS(matriz, 2)
+	grupos := Agrupar(coords, k)
+
+	pontos := make([]PontoSenador, 0, len(matriz.IDs))
+	for i, id := range matriz.IDs {
+		sen := senadores[id]
+		pontos = append(pontos, PontoSenador{
+			SenadorID: id,
+			Nome:      sen.Nome,
+			Partido:   sen.Partido,
+			UF:        sen.UF,
+			FotoURL:   sen.FotoURL,
+			X:         utils.Arredondar(coords[i][0], 4),
+			Y:         utils.Arredondar(coords[i][1], 4),
+			Grupo:     grupos[i],
+		})
+	}
+
+	// Concordancia em 0-100; null quando o par nao tem votos suficientes em comum
+	concordancia := make([][]*float64, len(matriz.IDs))
+	for i := range matriz.Concordancia {
+		concordancia[i] = make([]*float64, len(matriz.IDs))
+		for j, valor := range matriz.Concordancia[i] {
+			if valor >= 0 {
+				v := utils.Arredondar(valor*100, 2)
+				concordancia[i][j] = &v
+			}
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"inicio":          inicio.Format("2006-01-02"),
+		"fim":             fim.AddDate(0, 0, -1).Format("2006-01-02"),
+		"total_sessoes":   matriz.Sessoes,
+		"total_senadores": len(matriz.IDs),
+		"grupos":          k,
+		"senadores":       pontos,
+		"matriz": gin.H{
+			"ids":          matriz.IDs,
+			"concordancia": concordancia,
+			"votos_comuns": matriz.Comuns,
+		},
+	})
+}
+
+// GetSimilares godoc
+// @Summary Senadores que mais e que menos votam como o senador informado
+// @Tags analises
+// @Produce json
+// @Param id path int true "ID do senador"
+// @Param ano query int false "Ano das votacoes"
+// @Param inicio query string false "Data inicial YYYY-MM-DD (default inicio da legislatura)"
+// @Param fim query string false "Data final YYYY-MM-DD (default hoje)"
+// @Param limit query int false "Quantidade em cada lista (default 10, max 81)"
+// @Param min_comuns query int false "Minimo de votos em comum por par (default 10)"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/senadores/{id}/similares [get]
+func (h *Handler) GetSimilares(c *gin.Context) {
+	senadorID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalido"})
+		return
+	}
+
+	inicio, fim, err := parsePeriodo(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	limit := 10
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 81 {
+		limit = l
+	}
+
+	matriz, err := h.carregarMatriz(c, inicio, fim)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao carregar votacoes"})
+		return
+	}
+	if _, ok := matriz.Indice(senadorID); !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "senador sem votacoes no periodo"})
+		return
+	}
+
+	senadores, err := h.senadoresPorID()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar senadores"})
+		return
+	}
+
+	similares := matriz.Similares(senadorID)
+	lista := make([]SimilarSenador, 0, len(similares))
+	for _, s := range similares {
+		sen := senadores[s.SenadorID]
+		s.Concordancia = utils.Arredondar(s.Concordancia, 2)
+		lista = append(lista, SimilarSenador{Similaridade: s, Nome: sen.Nome, Partido: sen.Partido, UF: sen.UF})
+	}
+
+	mais := lista[:min(limit, len(lista))]
+	menos := make([]SimilarSenador, 0, limit)
+	for i := len(lista) - 1; i >= 0 && len(menos) < limit; i-- {
+		menos = append(menos, lista[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"senador_id":      senadorID,
+		"inicio":          inicio.Format("2006-01-02"),
+		"fim":             fim.AddDate(0, 0, -1).Format("2006-01-02"),
+		"total_sessoes":   matriz.Sessoes,
+		"mais_similares":  mais,
+		"menos_similares": menos,
+	})
+}
+
+// GetCoesaoPartido godoc
+// @Summary Coesao de um partido (indice de Rice) por sessao e serie mensal
+// @Description Rice = |Sim - Nao| / (Sim + Nao) entre os senadores filiados ao partido na data da votacao, em 0-100.
+// @Tags analises
+// @Produce json
+// @Param sigla path string true "Sigla do partido"
+// @Param ano query int false "Ano das votacoes"
+// @Param inicio query string false "Data inicial YYYY-MM-DD (default inicio da legislatura)"
+// @Param fim query string false "Data final YYYY-MM-DD (default hoje)"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/partidos/{sigla}/coesao [get]
+func (h *Handler) GetCoesaoPartido(c *gin.Context) {
+	sigla := c.Param("sigla")
+	if !siglaPartidoValida.MatchString(sigla) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "sigla invalida"})
+		return
+	}
+
+	inicio, fim, err := parsePeriodo(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	votos, err := h.repo.FindVotosPartido(sigla, inicio, fim)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao carregar votacoes"})
+		return
+	}
+
+	sessoes := CalcularCoesao(votos)
+	serie := SerieMensal(sessoes)
+	for i := range sessoes {
+		sessoes[i].Coesao = utils.Arredondar(sessoes[i].Coesao, 2)
+	}
+	for i := range serie {
+		serie[i].Coesao = utils.Arredondar(serie[i].Coesao, 2)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"partido":       strings.ToUpper(sigla),
+		"inicio":        inicio.Format("2006-01-02"),
+		"fim":           fim.AddDate(0, 0, -1).Format("2006-01-02"),
+		"coesao_media":  utils.Arredondar(MediaCoesao(sessoes), 2),
+		"total_sessoes": len(sessoes),
+		"serie_mensal":  serie,
+		"sessoes":       sessoes,
+	})
+}
+
+// carregarMatriz busca os votos do periodo e monta a matriz de concordancia
+func (h *Handler) carregarMatriz(c *gin.Context, inicio, fim time.Time) (*Matriz, error) {
+	minComuns := MinVotosComunsPadrao
+	if v, err := strconv.Atoi(c.Query("min_comuns")); err == nil && v >= 1 {
+		minComuns = v
+	}
+	votos, err := h.repo.FindVotosNominais(inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	return CalcularMatriz(votos, minComuns), nil
+}
+
+// senadoresPorID indexa todos os senadores (inclusive inativos) pelo ID
+func (h *Handler) senadoresPorID() (map[int]senador.Senador, error) {
+	senadores, err := h.senadorRepo.FindAll(true)
+	if err != nil {
+		return nil, err
+	}
+	porID := make(map[int]senador.Senador, len(senadores))
+	for _, s := range senadores {
+		porID[s.ID] = s
+	}
+	return porID, nil
+}
+
+// parsePeriodo le ?ano= ou ?inicio=&fim= (YYYY-MM-DD). Sem parametros, usa a legislatura atual.
+// O intervalo retornado e [inicio, fim), com fim exclusivo.
+func parsePeriodo(c *gin.Context) (time.Time, time.Time, error) {
+	if v := c.Query("ano"); v != "" {
+		ano, err := strconv.Atoi(v)
+		if err != nil || ano < 1988 || ano > 2100 {
+			return time.Time{}, time.Time{}, errors.New("ano invalido")
+		}
+		return time.Date(ano, time.January, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(ano+1, time.January, 1, 0, 0, 0, 0, time.UTC), nil
+	}
+
+	inicio := time.Date(utils.GetInicioLegislaturaAtual(), time.February, 1, 0, 0, 0, 0, time.UTC)
+	if v := c.Query("inicio"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("inicio invalido (use YYYY-MM-DD)")
+		}
+		inicio = t
+	}
+
+	fim := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+	if v := c.Query("fim"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("fim invalido (use YYYY-MM-DD)")
+		}
+		fim = t.AddDate(0, 0, 1) // inclui o dia informado
+	}
+
+	if !fim.After(inicio) {
+		return time.Time{}, time.Time{}, errors.New("fim deve ser posterior ao inicio")
+	}
+	return inicio, fim, nil
+}
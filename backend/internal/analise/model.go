@@ -0,0 +1,379 @@
+package analise
+
+import (
+	"math"
+	"sort"
+)
+
+// MinVotosComunsPadrao e o minimo de votacoes em comum para que a concordancia
+// entre dois senadores seja considerada (evita 100% com um unico voto)
+const MinVotosComunsPadrao = 10
+
+// VotoNominal e o voto Sim/Nao de um senador em uma sessao
+type VotoNominal struct {
+	SenadorID int
+	SessaoID  string
+	Voto      string
+}
+
+// Matriz guarda a concordancia par a par entre senadores
+type Matriz struct {
+	IDs          []int       // IDs dos senadores, em ordem crescente
+	Concordancia [][]float64 // 0-1; -1 quando o par tem menos votos em comum que o minimo
+	Comuns       [][]int     // Votacoes em que ambos votaram Sim ou Nao
+	Sessoes      int         // Sessoes consideradas
+
+	indice map[int]int
+}
+
+// Similaridade descreve a concordancia de um senador com outro
+type Similaridade struct {
+	SenadorID    int     `json:"senador_id"`
+	Concordancia float64 `json:"concordancia"` // 0-100
+	VotosComuns  int     `json:"votos_comuns"`
+}
+
+// CalcularMatriz monta a matriz de concordancia a partir dos votos nominais:
+// para cada par, a fracao das sessoes em comum em que votaram igual.
+func CalcularMatriz(votos []VotoNominal, minComuns int) *Matriz {
+	porSessao := make(map[string]map[int]string)
+	ids := make(map[int]bool)
+	for _, v := range votos {
+		sessao, ok := porSessao[v.SessaoID]
+		if !ok {
+			sessao = make(map[int]string)
+			porSessao[v.SessaoID] = sessao
+		}
+		sessao[v.SenadorID] = v.Voto
+		ids[v.SenadorID] = true
+	}
+
+	m := &Matriz{Sessoes: len(porSessao), indice: make(map[int]int, len(ids))}
+	for id := range ids {
+		m.IDs = append(m.IDs, id)
+	}
+	sort.Ints(m.IDs)
+	for i, id := range m.IDs {
+		m.indice[id] = i
+	}
+
+	n := len(m.IDs)
+	iguais := novaMatrizInt(n)
+	m.Comuns = novaMatrizInt(n)
+
+	type votoIdx struct {
+		idx  int
+		voto string
+	}
+	for _, sessao := range porSessao {
+		presentes := make([]votoIdx, 0, len(sessao))
+		for id, voto := range sessao {
+			presentes = append(presentes, votoIdx{m.indice[id], voto})
+		}
+		for a := 0; a < len(presentes); a++ {
+			for b := a + 1; b < len(presentes); b++ {
+				i, j := presentes[a].idx, presentes[b].idx
+				m.Comuns[i][j]++
+				m.Comuns[j][i]++
+				if presentes[a].voto == presentes[b].voto {
+					iguais[i][j]++
+					iguais[j][i]++
+				}
+			}
+		}
+	}
+
+	m.Concordancia = make([][]float64, n)
+	for i := range m.Concordancia {
+		m.Concordancia[i] = make([]float64, n)
+		for j := range m.Concordancia[i] {
+			switch {
+			case i == j:
+				m.Concordancia[i][j] = 1
+			case m.Comuns[i][j] < minComuns || m.Comuns[i][j] == 0:
+				m.Concordancia[i][j] = -1
+			default:
+				m.Concordancia[i][j] = float64(iguais[i][j]) / float64(m.Comuns[i][j])
+			}
+		}
+	}
+
+	return m
+}
+
+// Indice retorna a posicao do senador na matriz
+func (m *Matriz) Indice(senadorID int) (int, bool) {
+	i, ok := m.indice[senadorID]
+	return i, ok
+}
+
+// Similares retorna os demais senadores com concordancia definida, do mais ao menos similar
+func (m *Matriz) Similares(senadorID int) []Similaridade {
+	i, ok := m.indice[senadorID]
+	if !ok {
+		return nil
+	}
+	var similares []Similaridade
+	for j, outro := range m.IDs {
+		if j == i || m.Concordancia[i][j] < 0 {
+			continue
+		}
+		similares = append(similares, Similaridade{
+			SenadorID:    outro,
+			Concordancia: m.Concordancia[i][j] * 100,
+			VotosComuns:  m.Comuns[i][j],
+		})
+	}
+	sort.SliceStable(similares, func(a, b int) bool {
+		return similares[a].Concordancia > similares[b].Concordancia
+	})
+	return similares
+}
+
+// MDS projeta os senadores em dims dimensoes por escalonamento multidimensional classico,
+// usando 1 - concordancia como distancia. Pares sem concordancia definida recebem a
+// distancia media. Autovetores obtidos por iteracao de potencia com deflacao.
+func MDS(m *Matriz, dims int) [][]float64 {
+	n := len(m.IDs)
+	coords := make([][]float64, n)
+	for i := range coords {
+		coords[i] = make([]float64, dims)
+	}
+	if n < 2 {
+		return coords
+	}
+
+	// Distancias ao quadrado, completando lacunas com a media
+	var soma float64
+	var conhecidas int
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if m.Concordancia[i][j] >= 0 {
+				soma += 1 - m.Concordancia[i][j]
+				conhecidas++
+			}
+		}
+	}
+	media := 0.5
+	if conhecidas > 0 {
+		media = soma / float64(conhecidas)
+	}
+	d2 := make([][]float64, n)
+	for i := range d2 {
+		d2[i] = make([]float64, n)
+		for j := range d2[i] {
+			if i == j {
+				continue
+			}
+			d := media
+			if m.Concordancia[i][j] >= 0 {
+				d = 1 - m.Concordancia[i][j]
+			}
+			d2[i][j] = d * d
+		}
+	}
+
+	// Dupla centralizacao: B = -1/2 * J * D2 * J
+	linhas := make([]float64, n)
+	var total float64
+	for i := range d2 {
+		for j := range d2[i] {
+			linhas[i] += d2[i][j]
+		}
+		total += linhas[i]
+		linhas[i] /= float64(n)
+	}
+	total /= float64(n * n)
+	b := make([][]float64, n)
+	for i := range b {
+		b[i] = make([]float64, n)
+		for j := range b[i] {
+			b[i][j] = -0.5 * (d2[i][j] - linhas[i] - linhas[j] + total)
+		}
+	}
+
+	for k := 0; k < dims; k++ {
+		autovalor, autovetor := maiorAutovetor(b)
+		if autovalor <= 1e-12 {
+			break
+		}
+		escala := math.Sqrt(autovalor)
+		for i := range coords {
+			coords[i][k] = autovetor[i] * escala
+		}
+		// Deflacao: remove o componente encontrado
+		for i := range b {
+			for j := range b[i] {
+				b[i][j] -= autovalor * autovetor[i] * autovetor[j]
+			}
+		}
+	}
+
+	return coords
+}
+
+// maiorAutovetor retorna o autovalor dominante de uma matriz simetrica e seu autovetor
+// unitario, com sinal fixado para que o maior componente em modulo seja positivo
+func maiorAutovetor(a [][]float64) (float64, []float64) {
+	n := len(a)
+	v := make([]float64, n)
+	for i := range v {
+		// Vetor inicial deterministico e nao ortogonal aos autovetores de interesse
+		v[i] = math.Sin(float64(i) + 1)
+	}
+	normalizar(v)
+
+	w := make([]float64, n)
+	var autovalor float64
+	for iter := 0; iter < 1000; iter++ {
+		for i := range w {
+			w[i] = 0
+			for j := range v {
+				w[i] += a[i][j] * v[j]
+			}
+		}
+		novo := produto(v, w)
+		if normalizar(w) == 0 {
+			return 0, v
+		}
+		v, w = w, v
+		if math.Abs(novo-autovalor) < 1e-12 {
+			autovalor = novo
+			break
+		}
+		autovalor = novo
+	}
+
+	maior := 0
+	for i := range v {
+		if math.Abs(v[i]) > math.Abs(v[maior]) {
+			maior = i
+		}
+	}
+	if v[maior] < 0 {
+		for i := range v {
+			v[i] = -v[i]
+		}
+	}
+	return autovalor, v
+}
+
+// Agrupar divide os pontos em k grupos por k-means. A inicializacao e deterministica:
+// o primeiro centro e o ponto mais distante do centroide e os seguintes, os mais
+// distantes dos centros ja escolhidos.
+func Agrupar(pontos [][]float64, k int) []int {
+	n := len(pontos)
+	grupos := make([]int, n)
+	if n == 0 || k <= 1 {
+		return grupos
+	}
+	if k > n {
+		k = n
+	}
+	dims := len(pontos[0])
+
+	centroide := make([]float64, dims)
+	for _, p := range pontos {
+		for d := range p {
+			centroide[d] += p[d] / float64(n)
+		}
+	}
+	centros := [][]float64{append([]float64(nil), pontos[maisDistante(pontos, [][]float64{centroide})]...)}
+	for len(centros) < k {
+		centros = append(centros, append([]float64(nil), pontos[maisDistante(pontos, centros)]...))
+	}
+
+	for iter := 0; iter < 100; iter++ {
+		mudou := iter == 0
+		for i, p := range pontos {
+			melhor := 0
+			for c := range centros {
+				if distancia2(p, centros[c]) < distancia2(p, centros[melhor]) {
+					melhor = c
+				}
+			}
+			if grupos[i] != melhor {
+				grupos[i] = melhor
+				mudou = true
+			}
+		}
+		if !mudou {
+			break
+		}
+		for c := range centros {
+			soma := make([]float64, dims)
+			var membros int
+			for i, p := range pontos {
+				if grupos[i] != c {
+					continue
+				}
+				membros++
+				for d := range p {
+					soma[d] += p[d]
+				}
+			}
+			if membros == 0 {
+				continue
+			}
+			for d := range soma {
+				centros[c][d] = soma[d] / float64(membros)
+			}
+		}
+	}
+	return grupos
+}
+
+// maisDistante retorna o ponto cuja menor distancia aos centros e maxima
+func maisDistante(pontos, centros [][]float64) int {
+	escolhido := 0
+	maior := -1.0
+	for i, p := range pontos {
+		menor := math.Inf(1)
+		for _, c := range centros {
+			if d := distancia2(p, c); d < menor {
+				menor = d
+			}
+		}
+		if menor > maior {
+			maior = menor
+			escolhido = i
+		}
+	}
+	return escolhido
+}
+
+func distancia2(a, b []float64) float64 {
+	var s float64
+	for i := range a {
+		s += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return s
+}
+
+func produto(a, b []float64) float64 {
+	var s float64
+	for i := range a {
+		s += a[i] * b[i]
+	}
+	return s
+}
+
+// normalizar torna o vetor unitario e retorna a norma original
+func normalizar(v []float64) float64 {
+	norma := math.Sqrt(produto(v, v))
+	if norma == 0 {
+		return 0
+	}
+	for i := range v {
+		v[i] /= norma
+	}
+	return norma
+}
+
+func novaMatrizInt(n int) [][]int {
+	m := make([][]int, n)
+	for i := range m {
+		m[i] = make([]int, n)
+	}
+	return m
+}
@@ -0,0 +1,118 @@
+package analise
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCalcularMatriz(t *testing.T) {
+	// Sessoes pares: Sim para quem acompanha o bloco, Nao para quem se opoe; impares o inverso
+	senadores := []struct {
+		id      int
+		contra  bool
+		sessoes int
+	}{
+		{1, false, 12},
+		{2, false, 12},
+		{3, true, 12},
+		{4, false, 1}, // Poucas sessoes: abaixo do minimo de votos em comum
+	}
+	var votos []VotoNominal
+	for _, s := range senadores {
+		for i := 0; i < s.sessoes; i++ {
+			voto := "Sim"
+			if (i%2 == 1) != s.contra {
+				voto = "Nao"
+			}
+			votos = append(votos, VotoNominal{SenadorID: s.id, SessaoID: fmt.Sprintf("%d_2025", i), Voto: voto})
+		}
+	}
+
+	m := CalcularMatriz(votos, MinVotosComunsPadrao)
+	if m.Sessoes != 12 || len(m.IDs) != 4 {
+		t.Fatalf("sessoes=%d ids=%v", m.Sessoes, m.IDs)
+	}
+
+	pares := []struct {
+		a, b         int
+		concordancia float64 // -1 = indefinida
+		comuns       int
+	}{
+		{1, 2, 1, 12},
+		{1, 3, 0, 12},
+		{2, 3, 0, 12},
+		{1, 4, -1, 1},
+	}
+	for _, p := range pares {
+		ia, _ := m.Indice(p.a)
+		ib, _ := m.Indice(p.b)
+		if m.Concordancia[ia][ib] != p.concordancia || m.Comuns[ia][ib] != p.comuns {
+			t.Errorf("par %dx%d: concordancia %.2f comuns %d; esperado %.2f e %d",
+				p.a, p.b, m.Concordancia[ia][ib], m.Comuns[ia][ib], p.concordancia, p.comuns)
+		}
+	}
+
+	similares := m.Similares(1)
+	if len(similares) != 2 || similares[0].SenadorID != 2 || similares[0].Concordancia != 100 || similares[1].SenadorID != 3 {
+		t.Errorf("similares inesperados: %+v", similares)
+	}
+}
+
+func TestMDSEAgruparSeparamBlocos(t *testing.T) {
+	// bloco: grupo esperado. Bloco A vota Sim nas sessoes pares, bloco B o inverso e o
+	// senador 8 acompanha o bloco A em 3/4 das sessoes
+	senadores := []struct {
+		id    int
+		bloco string
+		sim   func(sessao int) bool
+	}{
+		{1, "A", func(s int) bool { return s%2 == 0 }},
+		{2, "A", func(s int) bool { return s%2 == 0 }},
+		{3, "A", func(s int) bool { return s%2 == 0 }},
+		{4, "A", func(s int) bool { return s%2 == 0 }},
+		{5, "B", func(s int) bool { return s%2 == 1 }},
+		{6, "B", func(s int) bool { return s%2 == 1 }},
+		{7, "B", func(s int) bool { return s%2 == 1 }},
+		{8, "A", func(s int) bool { return (s%2 == 0) != (s%4 == 3) }},
+	}
+	var votos []VotoNominal
+	for _, sen := range senadores {
+		for s := 0; s < 20; s++ {
+			voto := "Nao"
+			if sen.sim(s) {
+				voto = "Sim"
+			}
+			votos = append(votos, VotoNominal{SenadorID: sen.id, SessaoID: fmt.Sprintf("%d_2025", s), Voto: voto})
+		}
+	}
+
+	m := CalcularMatriz(votos, MinVotosComunsPadrao)
+	coords := MDS(m, 2)
+	grupos := Agrupar(coords, 2)
+
+	i1, _ := m.Indice(1)
+	i5, _ := m.Indice(5)
+	if coords[i1][0]*coords[i5][0] >= 0 {
+		t.Errorf("blocos deveriam ficar em lados opostos do primeiro eixo: %.3f, %.3f", coords[i1][0], coords[i5][0])
+	}
+	if grupos[i1] == grupos[i5] {
+		t.Fatalf("blocos A e B no mesmo grupo: %v", grupos)
+	}
+	grupoDoBloco := map[string]int{"A": grupos[i1], "B": grupos[i5]}
+	for _, sen := range senadores {
+		i, _ := m.Indice(sen.id)
+		if grupos[i] != grupoDoBloco[sen.bloco] {
+			t.Errorf("senador %d fora do grupo do bloco %s", sen.id, sen.bloco)
+		}
+	}
+}
+
+func TestAgruparCasosLimite(t *testing.T) {
+	if g := Agrupar(nil, 3); len(g) != 0 {
+		t.Errorf("sem pontos: %v", g)
+	}
+	g := Agrupar([][]float64{{0, 0}, {1, 1}}, 5)
+	if g[0] == g[1] {
+		t.Errorf("k maior que n deveria separar cada ponto: %v", g)
+	}
+}
@@ -0,0 +1,49 @@
+package analise
+
+import (
+	"time"
+
+	"github.com/Alzarus/to-de-olho/internal/senador"
+	"gorm.io/gorm"
+)
+
+// Repository le os dados de votacao usados nas analises
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository cria um novo repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// FindVotosNominais retorna os votos Sim/Nao registrados no periodo [inicio, fim)
+func (r *Repository) FindVotosNominais(inicio, fim time.Time) ([]VotoNominal, error) {
+	var votos []VotoNominal
+	err := r.db.Table("votacoes").
+		Select("senador_id, sessao_id, voto").
+		Where("voto IN ('Sim', 'Nao') AND data >= ? AND data < ?", inicio, fim).
+		Scan(&votos).Error
+	return votos, err
+}
+
+// FindVotosPartido agrega por sessao os votos Sim/Nao dos senadores filiados ao partido
+// na data de cada votacao, no periodo [inicio, fim)
+func (r *Repository) FindVotosPartido(partido string, inicio, fim time.Time) ([]VotosPartidoSessao, error) {
+	votos := r.db.Table("votacoes").
+		Select("votacoes.sessao_id, votacoes.data, votacoes.voto, s.materia, "+
+			senador.PartidoNaDataSQL("votacoes.senador_id", "votacoes.data")+" as partido").
+		Joins("JOIN senadores ON senadores.id = votacoes.senador_id").
+		Joins("LEFT JOIN votacao_sessoes s ON s.sessao_id = votacoes.sessao_id").
+		Where("votacoes.voto IN ('Sim', 'Nao') AND votacoes.data >= ? AND votacoes.data < ?", inicio, fim)
+
+	var agregados []VotosPartidoSessao
+	err := r.db.Table("(?) as v", votos).
+		Select(`v.sessao_id, MIN(v.data) as data, MIN(v.materia) as materia, v.partido,
+			COUNT(*) FILTER (WHERE v.voto = 'Sim') as sim,
+			COUNT(*) FILTER (WHERE v.voto = 'Nao') as nao`).
+		Where("UPPER(v.partido) = UPPER(?)", partido).
+		Group("v.sessao_id, v.partido").
+		Scan(&agregados).Error
+	return agregados, err
+}
@@ -2,19 +2,37 @@ package api
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
+	"github.com/Alzarus/to-de-olho/internal/agenda"
+	"github.com/Alzarus/to-de-olho/internal/alerta"
+	"github.com/Alzarus/to-de-olho/internal/analise"
+	"github.com/Alzarus/to-de-olho/internal/camara/deputado"
+	camaradespesa "github.com/Alzarus/to-de-olho/internal/camara/despesa"
+	camaravotacao "github.com/Alzarus/to-de-olho/internal/camara/votacao"
 	"github.com/Alzarus/to-de-olho/internal/ceaps"
 	"github.com/Alzarus/to-de-olho/internal/comissao"
+	"github.com/Alzarus/to-de-olho/internal/discurso"
 	"github.com/Alzarus/to-de-olho/internal/emenda"
+	"github.com/Alzarus/to-de-olho/internal/fornecedor"
+	"github.com/Alzarus/to-de-olho/internal/gabinete"
+	"github.com/Alzarus/to-de-olho/internal/licenca"
+	"github.com/Alzarus/to-de-olho/internal/materia"
+	"github.com/Alzarus/to-de-olho/internal/municipio"
 	"github.com/Alzarus/to-de-olho/internal/proposicao"
 	"github.com/Alzarus/to-de-olho/internal/ranking"
+	"github.com/Alzarus/to-de-olho/internal/relatoria"
 	"github.com/Alzarus/to-de-olho/internal/senador"
 	"github.com/Alzarus/to-de-olho/internal/votacao"
+	"github.com/Alzarus/to-de-olho/pkg/camara"
+	"github.com/Alzarus/to-de-olho/pkg/cnpj"
+	"github.com/Alzarus/to-de-olho/pkg/ibge"
 	"github.com/Alzarus/to-de-olho/pkg/senado"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -33,6 +51,8 @@ func SetupRouter(db *gorm.DB, transparenciaAPIKey string) *gin.Engine {
 	// Clients das APIs externas
 	legisClient := senado.NewLegisClient()
 	admClient := senado.NewAdmClient()
+	camaraClient := camara.NewClient()
+	cnpjClient := cnpj.NewClient()
 
 	// API v1
 	v1 := router.Group("/api/v1")
@@ -51,6 +71,7 @@ func SetupRouter(db *gorm.DB, transparenciaAPIKey string) *gin.Engine {
 		votacaoRepo := votacao.NewRepository(db)
 		votacaoHandler := votacao.NewHandler(votacaoRepo)
 		votacaoSync := votacao.NewSyncService(votacaoRepo, senadorRepo, legisClient)
+		analiseHandler := analise.NewHandler(analise.NewRepository(db), senadorRepo)
 
 		// Comissoes
 		comissaoRepo := comissao.NewRepository(db)
@@ -69,8 +90,64 @@ func SetupRouter(db *gorm.DB, transparenciaAPIKey string) *gin.Engine {
 		emendaHandler := emenda.NewHandler(emendaService)
 		emendaSync := emenda.NewSyncService(emendaRepo, senadorRepo, transparenciaAPIKey)
 
+		// Municipios (referencia IBGE para as localidades das emendas)
+		municipioRepo := municipio.NewRepository(db)
+		municipioSync := municipio.NewSyncService(municipioRepo, ibge.NewClient())
+		emendaService.SetMunicipioRepository(municipioRepo)
+		emendaSync.SetMunicipioRepository(municipioRepo)
+
+		// Camara dos Deputados
+		deputadoRepo := deputado.NewRepository(db)
+		deputadoHandler := deputado.NewHandler(deputadoRepo)
+		deputadoSync := deputado.NewSyncService(deputadoRepo, camaraClient)
+		camaraVotacaoRepo := camaravotacao.NewRepository(db)
+		camaraVotacaoHandler := camaravotacao.NewHandler(camaraVotacaoRepo)
+		camaraVotacaoSync := camaravotacao.NewSyncService(camaraVotacaoRepo, deputadoRepo, camaraClient)
+		camaraDespesaRepo := camaradespesa.NewRepository(db)
+		camaraDespesaHandler := camaradespesa.NewHandler(camaraDespesaRepo)
+		camaraDespesaSync := camaradespesa.NewSyncService(camaraDespesaRepo, deputadoRepo, camaraClient)
+
+		// Discursos
+		discursoRepo := discurso.NewRepository(db)
+		discursoHandler := discurso.NewHandler(discursoRepo)
+		discursoSync := discurso.NewSyncService(discursoRepo, senadorRepo, legisClient)
+
+		// Licencas e afastamentos
+		licencaRepo := licenca.NewRepository(db)
+		licencaHandler := licenca.NewHandler(licencaRepo)
+		licencaSync := licenca.NewSyncService(licencaRepo, senadorRepo, legisClient)
+
+		// Relatorias
+		relatoriaRepo := relatoria.NewRepository(db)
+		relatoriaHandler := relatoria.NewHandler(relatoriaRepo)
+		relatoriaSync := relatoria.NewSyncService(relatoriaRepo, senadorRepo, legisClient)
+
+		// Fornecedores CEAPS (RF06-RF07)
+		fornecedorRepo := fornecedor.NewRepository(db)
+		fornecedorHandler := fornecedor.NewHandler(fornecedorRepo)
+		fornecedorSync := fornecedor.NewSyncService(fornecedorRepo, cnpjClient)
+
+		// Alertas CEAPS (RF20) - executados pelo scheduler apos a consolidacao de
+		// fornecedores, ou sob demanda em /sync/alertas/:ano
+		alertaRepo := alerta.NewRepository(db)
+		alertaService := alerta.NewService(alertaRepo, ceapsRepo, senadorRepo, fornecedorRepo)
+		alertaHandler := alerta.NewHandler(alertaRepo)
+
+		// Gabinete (RF17-RF18)
+		gabineteRepo := gabinete.NewRepository(db)
+		gabineteHandler := gabinete.NewHandler(gabineteRepo)
+		gabineteSync := gabinete.NewSyncService(gabineteRepo, senadorRepo, admClient)
+
+		// Agenda (plenario e comissoes)
+		agendaRepo := agenda.NewRepository(db)
+		agendaHandler := agenda.NewHandler(agendaRepo)
+		agendaSync := agenda.NewSyncService(agendaRepo, legisClient)
+
 		// Ranking
 		rankingService := ranking.NewService(senadorRepo, proposicaoRepo, votacaoRepo, ceapsRepo, comissaoRepo)
+		rankingService.SetDiscursoRepository(discursoRepo)
+		rankingService.SetRelatoriaRepository(relatoriaRepo)
+		rankingService.HabilitarAlinhamento()
 		rankingHandler := ranking.NewHandler(rankingService)
 
 		senadores := v1.Group("/senadores")
@@ -78,25 +155,98 @@ func SetupRouter(db *gorm.DB, transparenciaAPIKey string) *gin.Engine {
 			senadores.GET("", senadorHandler.ListAll)
 			senadores.GET("/:id", senadorHandler.GetByID)
 			senadores.GET("/codigo/:codigo", senadorHandler.GetByCodigo)
+			senadores.GET("/:id/mandatos", senadorHandler.GetMandatos)
+			senadores.GET("/:id/filiacoes", senadorHandler.GetFiliacoes)
 			senadores.GET("/:id/despesas", ceapsHandler.ListBySenador)
 			senadores.GET("/:id/despesas/agregado", ceapsHandler.AggregateBySenador)
+			senadores.GET("/:id/despesas/alertas", alertaHandler.ListBySenador)
 			senadores.GET("/:id/votacoes", votacaoHandler.ListBySenador)
 			senadores.GET("/:id/votacoes/stats", votacaoHandler.GetStats)
 			senadores.GET("/:id/votacoes/tipos", votacaoHandler.GetVotosPorTipo)
+			senadores.GET("/:id/votacoes/alinhamento", votacaoHandler.GetAlinhamento)
+			senadores.GET("/:id/similares", analiseHandler.GetSimilares)
+			senadores.GET("/:id/licencas", licencaHandler.ListBySenador)
 			// Comissoes
 			senadores.GET("/:id/comissoes", comissaoHandler.ListBySenador)
 			senadores.GET("/:id/comissoes/ativas", comissaoHandler.GetAtivas)
 			senadores.GET("/:id/comissoes/stats", comissaoHandler.GetStats)
 			senadores.GET("/:id/comissoes/casas", comissaoHandler.GetPorCasa)
+			senadores.GET("/:id/comissoes/presenca", comissaoHandler.GetPresenca)
 			// Proposicoes
 			senadores.GET("/:id/proposicoes", proposicaoHandler.ListBySenador)
 			senadores.GET("/:id/proposicoes/stats", proposicaoHandler.GetStats)
 			senadores.GET("/:id/proposicoes/tipos", proposicaoHandler.GetPorTipo)
+			// Discursos
+			senadores.GET("/:id/discursos", discursoHandler.ListBySenador)
+			senadores.GET("/:id/discursos/stats", discursoHandler.GetStats)
+			// Relatorias
+			senadores.GET("/:id/relatorias", relatoriaHandler.ListBySenador)
+			senadores.GET("/:id/relatorias/stats", relatoriaHandler.GetStats)
+			// Gabinete
+			senadores.GET("/:id/gabinete", gabineteHandler.GetBySenador)
 			// Score individual
 
 			senadores.GET("/:id/score", rankingHandler.GetScoreSenador)
 			// Emendas
 			senadores.GET("/:id/emendas", emendaHandler.GetBySenador)
+			senadores.GET("/:id/emendas/pix", emendaHandler.GetPixBySenador)
+			senadores.GET("/:id/emendas/distribuicao", emendaHandler.GetDistribuicao)
+		}
+
+		// Proposicoes (por materia)
+		proposicoes := v1.Group("/proposicoes")
+		{
+			proposicoes.GET("/:codigo/tramitacao", proposicaoHandler.GetTramitacao)
+		}
+
+		// Analises de votacoes (similaridade entre senadores)
+		analises := v1.Group("/analises")
+		{
+			analises.GET("/similaridade", analiseHandler.GetSimilaridade)
+		}
+
+		// Materias: visao centrada no projeto (proposicoes + votacoes + tramitacao)
+		materiaHandler := materia.NewHandler(proposicaoRepo, votacaoRepo)
+		materias := v1.Group("/materias")
+		{
+			materias.GET("/:sigla/:numero/:ano", materiaHandler.GetMateria)
+		}
+
+		// Comissoes
+		comissoes := v1.Group("/comissoes")
+		{
+			comissoes.GET("", comissaoHandler.ListComissoes)
+			comissoes.GET("/:codigo", comissaoHandler.GetByCodigo)
+		}
+
+		// Emendas: execucao de uma emenda ate o recebedor final
+		emendas := v1.Group("/emendas")
+		{
+			emendas.GET("/pix/senadores", emendaHandler.ListPixSenadores)
+			emendas.GET("/pix/municipios", emendaHandler.ListPixMunicipios)
+			emendas.GET("/pix/municipios/:cnpj", emendaHandler.GetPixMunicipio)
+			emendas.GET("/:numero", emendaHandler.GetByNumero)
+		}
+
+		// Municipios: onde o dinheiro das emendas chega
+		municipios := v1.Group("/municipios")
+		{
+			municipios.GET("/:ibge/emendas", emendaHandler.GetByMunicipio)
+		}
+
+		// Administracao: revisao dos autores de emendas nao identificados
+		admin := v1.Group("/admin", adminAuth())
+		{
+			admin.GET("/emendas/autores", emendaHandler.ListAutores)
+			admin.PUT("/emendas/autores", emendaHandler.SalvarAutor)
+			admin.DELETE("/emendas/autores/:id", emendaHandler.RemoverAutor)
+		}
+
+		// Partidos
+		partidos := v1.Group("/partidos")
+		{
+			partidos.GET("/trocas", senadorHandler.ListTrocas)
+			partidos.GET("/:sigla/coesao", analiseHandler.GetCoesaoPartido)
 		}
 
 		// Votacoes (Geral)
@@ -106,6 +256,31 @@ func SetupRouter(db *gorm.DB, transparenciaAPIKey string) *gin.Engine {
 			votacoes.GET("/:id", votacaoHandler.GetByID)
 		}
 
+		// Fornecedores
+		fornecedores := v1.Group("/fornecedores")
+		{
+			fornecedores.GET("", fornecedorHandler.ListRanking)
+			fornecedores.GET("/:documento", fornecedorHandler.GetByDocumento)
+		}
+
+		// Alertas
+		v1.GET("/alertas", alertaHandler.ListFeed)
+		v1.GET("/alertas/regras", alertaHandler.ListRegras)
+
+		// Agenda
+		v1.GET("/agenda", agendaHandler.List)
+
+		// Deputados (Camara)
+		deputados := v1.Group("/deputados")
+		{
+			deputados.GET("", deputadoHandler.ListAll)
+			deputados.GET("/:id", deputadoHandler.GetByID)
+			deputados.GET("/:id/despesas", camaraDespesaHandler.ListByDeputado)
+			deputados.GET("/:id/despesas/agregado", camaraDespesaHandler.AggregateByDeputado)
+			deputados.GET("/:id/votacoes", camaraVotacaoHandler.ListByDeputado)
+			deputados.GET("/:id/votacoes/stats", camaraVotacaoHandler.GetStats)
+		}
+
 		// Sync (trigger manual para desenvolvimento)
 		v1.POST("/sync/senadores", func(c *gin.Context) {
 			if err := senadorSync.SyncFromAPI(c.Request.Context()); err != nil {
@@ -136,13 +311,62 @@ func SetupRouter(db *gorm.DB, transparenciaAPIKey string) *gin.Engine {
 			})
 		})
 
-		v1.POST("/sync/votacoes", func(c *gin.Context) {
-			if err := votacaoSync.SyncFromAPI(c.Request.Context()); err != nil {
+		v1.POST("/sync/fornecedores", func(c *gin.Context) {
+			if err := fornecedorSync.RebuildFromCEAPS(c.Request.Context()); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			count, _ := fornecedorRepo.Count()
+			c.JSON(http.StatusOK, gin.H{
+				"message": "consolidacao de fornecedores concluida",
+				"total":   count,
+			})
+		})
+
+		v1.POST("/sync/fornecedores/cnpj", func(c *gin.Context) {
+			limite := 100
+			if l, err := strconv.Atoi(c.Query("limite")); err == nil && l > 0 {
+				limite = l
+			}
+			if err := fornecedorSync.EnriquecerCNPJs(c.Request.Context(), limite); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{
+				"message": "consulta cadastral de CNPJs concluida",
+				"limite":  limite,
+			})
+		})
+
+		v1.POST("/sync/alertas/:ano", func(c *gin.Context) {
+			ano, err := strconv.Atoi(c.Param("ano"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "ano invalido"})
+				return
+			}
+			if err := alertaService.AnalisarAno(c.Request.Context(), ano); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{
+				"message": "analise de alertas concluida",
+				"ano":     ano,
+			})
+		})
+
+		v1.POST("/sync/votacoes/:ano", func(c *gin.Context) {
+			var ano int
+			if _, err := fmt.Sscanf(c.Param("ano"), "%d", &ano); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "ano invalido"})
+				return
+			}
+			if err := votacaoSync.SyncFromAPI(c.Request.Context(), ano); err != nil {
 				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 				return
 			}
 			c.JSON(http.StatusOK, gin.H{
 				"message": "sync de votacoes concluido",
+				"ano":     ano,
 			})
 		})
 
@@ -156,6 +380,22 @@ func SetupRouter(db *gorm.DB, transparenciaAPIKey string) *gin.Engine {
 			})
 		})
 
+		v1.POST("/sync/comissoes/reunioes/:ano", func(c *gin.Context) {
+			var ano int
+			if _, err := fmt.Sscanf(c.Param("ano"), "%d", &ano); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "ano invalido"})
+				return
+			}
+			if err := comissaoSync.SyncReunioes(c.Request.Context(), ano); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{
+				"message": "sync de reunioes de comissoes concluido",
+				"ano":     ano,
+			})
+		})
+
 		v1.POST("/sync/proposicoes", func(c *gin.Context) {
 			if err := proposicaoSync.SyncFromAPI(c.Request.Context()); err != nil {
 				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -166,6 +406,130 @@ func SetupRouter(db *gorm.DB, transparenciaAPIKey string) *gin.Engine {
 			})
 		})
 
+		v1.POST("/sync/tramitacoes", func(c *gin.Context) {
+			limite := 200
+			if l, err := strconv.Atoi(c.Query("limite")); err == nil && l > 0 {
+				limite = l
+			}
+			if err := proposicaoSync.SyncTramitacoes(c.Request.Context(), limite); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{
+				"message": "sync de tramitacoes concluido",
+				"limite":  limite,
+			})
+		})
+
+		v1.POST("/sync/discursos/:ano", func(c *gin.Context) {
+			var ano int
+			if _, err := fmt.Sscanf(c.Param("ano"), "%d", &ano); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "ano invalido"})
+				return
+			}
+			if err := discursoSync.SyncFromAPI(c.Request.Context(), ano); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{
+				"message": "sync de discursos concluido",
+				"ano":     ano,
+			})
+		})
+
+		v1.POST("/sync/licencas", func(c *gin.Context) {
+			if err := licencaSync.SyncFromAPI(c.Request.Context()); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			count, _ := licencaRepo.Count()
+			c.JSON(http.StatusOK, gin.H{
+				"message":  "sync de licencas concluido",
+				"licencas": count,
+			})
+		})
+
+		v1.POST("/sync/relatorias", func(c *gin.Context) {
+			if err := relatoriaSync.SyncFromAPI(c.Request.Context()); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{
+				"message": "sync de relatorias concluido",
+			})
+		})
+
+		v1.POST("/sync/gabinete/:ano/:mes", func(c *gin.Context) {
+			ano, errAno := strconv.Atoi(c.Param("ano"))
+			mes, errMes := strconv.Atoi(c.Param("mes"))
+			if errAno != nil || errMes != nil || mes < 1 || mes > 12 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "ano ou mes invalido"})
+				return
+			}
+			if err := gabineteSync.SyncFromAPI(c.Request.Context()); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			if err := gabineteSync.SyncRemuneracoes(c.Request.Context(), ano, mes); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			count, _ := gabineteRepo.Count()
+			c.JSON(http.StatusOK, gin.H{
+				"message":    "sync de gabinetes concluido",
+				"servidores": count,
+				"ano":        ano,
+				"mes":        mes,
+			})
+		})
+
+		v1.POST("/sync/agenda", func(c *gin.Context) {
+			dias := 14
+			if d, err := strconv.Atoi(c.Query("dias")); err == nil && d > 0 && d <= agenda.MaxDiasPeriodo {
+				dias = d
+			}
+			if err := agendaSync.SyncProximosDias(c.Request.Context(), dias); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{
+				"message": "sync de agenda concluido",
+				"dias":    dias,
+			})
+		})
+
+		v1.POST("/sync/deputados", func(c *gin.Context) {
+			if err := deputadoSync.SyncFromAPI(c.Request.Context()); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			count, _ := deputadoRepo.Count()
+			c.JSON(http.StatusOK, gin.H{
+				"message": "sync de deputados concluido",
+				"total":   count,
+			})
+		})
+
+		v1.POST("/sync/camara/:ano", func(c *gin.Context) {
+			var ano int
+			if _, err := fmt.Sscanf(c.Param("ano"), "%d", &ano); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "ano invalido"})
+				return
+			}
+			if err := camaraVotacaoSync.SyncFromAPI(c.Request.Context(), ano); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			if err := camaraDespesaSync.SyncFromAPI(c.Request.Context(), ano); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{
+				"message": "sync da camara concluido",
+				"ano":     ano,
+			})
+		})
+
 
         
 		v1.POST("/sync/emendas/:ano", func(c *gin.Context) {
@@ -186,6 +550,53 @@ func SetupRouter(db *gorm.DB, transparenciaAPIKey string) *gin.Engine {
 			})
 		})
 
+		v1.POST("/sync/emendas/documentos", func(c *gin.Context) {
+			limite := 200
+			if v, err := strconv.Atoi(c.Query("limite")); err == nil && v > 0 {
+				limite = v
+			}
+			if err := emendaSync.SyncDocumentos(c.Request.Context(), limite); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{
+				"message": "sync de documentos de emendas concluido",
+				"limite":  limite,
+			})
+		})
+
+		v1.POST("/sync/emendas/pix/:ano", func(c *gin.Context) {
+			ano, err := strconv.Atoi(c.Param("ano"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "ano invalido"})
+				return
+			}
+			if err := emendaSync.SyncTransferenciasEspeciais(c.Request.Context(), ano); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{
+				"message": "sync de transferencias especiais concluido",
+				"ano":     ano,
+			})
+		})
+
+		v1.POST("/sync/municipios", func(c *gin.Context) {
+			if err := municipioSync.SyncFromIBGE(c.Request.Context()); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			resolvidas, err := emendaSync.ResolverLocalidades()
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{
+				"message":            "sync de municipios concluido",
+				"emendas_resolvidas": resolvidas,
+			})
+		})
+
 		// Metadata
 		v1.GET("/metadata/last-sync", func(c *gin.Context) {
 			
@@ -276,6 +687,20 @@ func RegisterSchedulerRoutes(router *gin.Engine, runner SyncRunner) {
 	})
 }
 
+// adminAuth protege as rotas de administracao com o mesmo segredo dos endpoints
+// de sync (header X-Sync-Secret). Sem SYNC_SECRET definido, as rotas ficam fechadas.
+func adminAuth() gin.HandlerFunc {
+	secret := []byte(os.Getenv("SYNC_SECRET"))
+	return func(c *gin.Context) {
+		header := []byte(c.GetHeader("X-Sync-Secret"))
+		if len(secret) == 0 || subtle.ConstantTimeCompare(header, secret) != 1 {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "acesso negado"})
+			return
+		}
+		c.Next()
+	}
+}
+
 func corsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
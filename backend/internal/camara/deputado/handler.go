@@ -0,0 +1,65 @@
+package deputado
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler gerencia endpoints REST de deputados
+type Handler struct {
+	repo *Repository
+}
+
+// NewHandler cria um novo handler
+func NewHandler(repo *Repository) *Handler {
+	return &Handler{repo: repo}
+}
+
+// ListAll godoc
+// @Summary Lista deputados federais
+// @Tags deputados
+// @Produce json
+// @Param inativos query string false "Incluir deputados fora de exercicio"
+// @Param uf query string false "Filtrar por UF"
+// @Param partido query string false "Filtrar por partido"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/deputados [get]
+func (h *Handler) ListAll(c *gin.Context) {
+	includeInactive := c.Query("inativos") == "true"
+	deputados, err := h.repo.FindAll(includeInactive, c.Query("uf"), c.Query("partido"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar deputados"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"total":     len(deputados),
+		"deputados": deputados,
+	})
+}
+
+// GetByID godoc
+// @Summary Busca deputado por ID
+// @Tags deputados
+// @Produce json
+// @Param id path int true "ID do deputado"
+// @Success 200 {object} Deputado
+// @Failure 404 {object} map[string]string
+// @Router /api/v1/deputados/{id} [get]
+func (h *Handler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalido"})
+		return
+	}
+
+	deputado, err := h.repo.FindByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "deputado nao encontrado"})
+		return
+	}
+
+	c.JSON(http.StatusOK, deputado)
+}
@@ -0,0 +1,23 @@
+package deputado
+
+import "time"
+
+// Deputado representa um deputado federal
+type Deputado struct {
+	ID             int       `gorm:"primaryKey" json:"id"`
+	CodigoDeputado int       `gorm:"uniqueIndex;not null" json:"codigo_deputado"`
+	Nome           string    `gorm:"not null" json:"nome"`
+	Partido        string    `json:"partido"`
+	UF             string    `gorm:"size:2" json:"uf"`
+	FotoURL        string    `json:"foto_url,omitempty"`
+	Email          string    `json:"email,omitempty"`
+	Legislatura    int       `json:"legislatura"`
+	EmExercicio    bool      `gorm:"default:true" json:"em_exercicio"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// TableName define o nome da tabela para Deputado
+func (Deputado) TableName() string {
+	return "deputados"
+}
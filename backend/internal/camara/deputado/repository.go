@@ -0,0 +1,78 @@
+package deputado
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository encapsula operacoes de banco de dados para Deputado
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository cria um novo repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// FindAll retorna deputados (pode incluir inativos), com filtros opcionais de UF e partido
+func (r *Repository) FindAll(includeInactive bool, uf, partido string) ([]Deputado, error) {
+	var deputados []Deputado
+	query := r.db.Order("nome ASC")
+	if !includeInactive {
+		query = query.Where("em_exercicio = ?", true)
+	}
+	if uf != "" {
+		query = query.Where("uf = ?", uf)
+	}
+	if partido != "" {
+		query = query.Where("partido = ?", partido)
+	}
+	result := query.Find(&deputados)
+	return deputados, result.Error
+}
+
+// FindByID busca deputado por ID interno
+func (r *Repository) FindByID(id int) (*Deputado, error) {
+	var deputado Deputado
+	result := r.db.First(&deputado, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &deputado, nil
+}
+
+// FindByCodigo busca deputado pelo ID da API da Camara
+func (r *Repository) FindByCodigo(codigo int) (*Deputado, error) {
+	var deputado Deputado
+	result := r.db.Where("codigo_deputado = ?", codigo).First(&deputado)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &deputado, nil
+}
+
+// UpsertBatch insere ou atualiza multiplos deputados
+func (r *Repository) UpsertBatch(deputados []Deputado) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "codigo_deputado"}},
+		DoUpdates: clause.AssignmentColumns([]string{"nome", "partido", "uf", "foto_url", "email", "legislatura", "em_exercicio", "updated_at"}),
+	}).CreateInBatches(deputados, 100).Error
+}
+
+// SetInactive marca deputados nao listados como fora de exercicio
+func (r *Repository) SetInactive(activeCodes []int) error {
+	if len(activeCodes) == 0 {
+		return nil
+	}
+	return r.db.Model(&Deputado{}).
+		Where("codigo_deputado NOT IN ?", activeCodes).
+		Update("em_exercicio", false).Error
+}
+
+// Count retorna o total de deputados em exercicio
+func (r *Repository) Count() (int64, error) {
+	var count int64
+	result := r.db.Model(&Deputado{}).Where("em_exercicio = ?", true).Count(&count)
+	return count, result.Error
+}
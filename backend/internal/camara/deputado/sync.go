@@ -0,0 +1,70 @@
+package deputado
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Alzarus/to-de-olho/pkg/camara"
+)
+
+// SyncService gerencia sincronizacao de deputados com a API da Camara
+type SyncService struct {
+	repo   *Repository
+	client *camara.Client
+}
+
+// NewSyncService cria um novo servico de sincronizacao
+func NewSyncService(repo *Repository, client *camara.Client) *SyncService {
+	return &SyncService{
+		repo:   repo,
+		client: client,
+	}
+}
+
+// SyncFromAPI busca deputados em exercicio da API e atualiza o banco
+func (s *SyncService) SyncFromAPI(ctx context.Context) error {
+	slog.Info("iniciando sync de deputados")
+
+	deputadosAPI, err := s.client.ListarDeputadosAtuais(ctx)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("deputados recebidos da API", "total", len(deputadosAPI))
+
+	deputados := make([]Deputado, 0, len(deputadosAPI))
+	activeCodes := make([]int, 0, len(deputadosAPI))
+	for _, d := range deputadosAPI {
+		deputados = append(deputados, s.convertToDeputado(d))
+		activeCodes = append(activeCodes, d.ID)
+	}
+
+	if len(deputados) == 0 {
+		return nil
+	}
+
+	if err := s.repo.UpsertBatch(deputados); err != nil {
+		return err
+	}
+
+	if err := s.repo.SetInactive(activeCodes); err != nil {
+		slog.Error("falha ao inativar deputados antigos", "error", err)
+	}
+
+	slog.Info("sync de deputados concluido", "salvos", len(deputados))
+	return nil
+}
+
+// convertToDeputado converte dados da API para modelo interno
+func (s *SyncService) convertToDeputado(d camara.DeputadoAPI) Deputado {
+	return Deputado{
+		CodigoDeputado: d.ID,
+		Nome:           d.Nome,
+		Partido:        d.SiglaPartido,
+		UF:             d.SiglaUF,
+		FotoURL:        d.URLFoto,
+		Email:          d.Email,
+		Legislatura:    d.IDLegislatura,
+		EmExercicio:    true,
+	}
+}
@@ -0,0 +1,109 @@
+package despesa
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler gerencia endpoints REST de despesas CEAP da Camara
+type Handler struct {
+	repo *Repository
+}
+
+// NewHandler cria um novo handler
+func NewHandler(repo *Repository) *Handler {
+	return &Handler{repo: repo}
+}
+
+// ListByDeputado godoc
+// @Summary Lista despesas CEAP de um deputado
+// @Tags deputados
+// @Produce json
+// @Param id path int true "ID do deputado"
+// @Param ano query int false "Ano de referencia"
+// @Param tipo query string false "Tipo de despesa"
+// @Param limit query int false "Limite (default 20)"
+// @Param page query int false "Pagina (default 1)"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/deputados/{id}/despesas [get]
+func (h *Handler) ListByDeputado(c *gin.Context) {
+	deputadoID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalido"})
+		return
+	}
+
+	var ano *int
+	if anoStr := c.Query("ano"); anoStr != "" {
+		if anoVal, err := strconv.Atoi(anoStr); err == nil && anoVal > 0 {
+			ano = &anoVal
+		}
+	}
+
+	limit := 20
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+	page := 1
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	offset := (page - 1) * limit
+
+	despesas, total, err := h.repo.FindByDeputadoID(deputadoID, ano, limit, offset, c.Query("tipo"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar despesas"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"deputado_id": deputadoID,
+		"total":       total,
+		"limit":       limit,
+		"page":        page,
+		"total_pages": (int(total) + limit - 1) / limit,
+		"despesas":    despesas,
+	})
+}
+
+// AggregateByDeputado godoc
+// @Summary Retorna gastos CEAP agregados por tipo de despesa
+// @Tags deputados
+// @Produce json
+// @Param id path int true "ID do deputado"
+// @Param ano query int false "Ano de referencia"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/deputados/{id}/despesas/agregado [get]
+func (h *Handler) AggregateByDeputado(c *gin.Context) {
+	deputadoID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalido"})
+		return
+	}
+
+	var ano *int
+	if anoStr := c.Query("ano"); anoStr != "" {
+		if anoVal, err := strconv.Atoi(anoStr); err == nil {
+			ano = &anoVal
+		}
+	}
+
+	agregados, err := h.repo.AggregateByTipo(deputadoID, ano)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao agregar despesas"})
+		return
+	}
+
+	var totalGeral float64
+	for _, a := range agregados {
+		totalGeral += a.Total
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"deputado_id": deputadoID,
+		"total_geral": totalGeral,
+		"por_tipo":    agregados,
+	})
+}
@@ -0,0 +1,75 @@
+package despesa
+
+import (
+	"math"
+	"time"
+)
+
+// DespesaCEAP representa um lancamento da Cota para o Exercicio da Atividade Parlamentar
+// de um deputado federal (equivalente ao ceaps.DespesaCEAPS do Senado)
+type DespesaCEAP struct {
+	ID         int `gorm:"primaryKey" json:"id"`
+	DeputadoID int `gorm:"uniqueIndex:idx_despesa_camara_unica,priority:1;index:idx_despesa_camara_deputado_ano;not null" json:"deputado_id"`
+	Ano        int `gorm:"uniqueIndex:idx_despesa_camara_unica,priority:2;index:idx_despesa_camara_deputado_ano;not null" json:"ano"`
+	Mes        int `gorm:"uniqueIndex:idx_despesa_camara_unica,priority:3" json:"mes"`
+
+	// Dados do lancamento
+	TipoDespesa   string     `json:"tipo_despesa"`
+	TipoDocumento string     `json:"tipo_documento,omitempty"`
+	Fornecedor    string     `json:"fornecedor"`
+	CNPJCPF       string     `gorm:"column:cnpj_cpf;uniqueIndex:idx_despesa_camara_unica,priority:4" json:"cnpj_cpf"`
+	NumDocumento  string     `gorm:"uniqueIndex:idx_despesa_camara_unica,priority:5" json:"num_documento,omitempty"`
+	DataEmissao   *time.Time `json:"data_emissao,omitempty"`
+	Valor         float64    `json:"valor"` // Valor liquido reembolsado
+	ValorGlosa    float64    `json:"valor_glosa,omitempty"`
+	URLDocumento  string     `json:"url_documento,omitempty"`
+
+	// Chave natural para idempotencia (upsert)
+	ValorCentavos int64 `gorm:"uniqueIndex:idx_despesa_camara_unica,priority:6" json:"-"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName define o nome da tabela
+func (DespesaCEAP) TableName() string {
+	return "despesas_ceap_camara"
+}
+
+// calcularCentavos preenche o valor em centavos usado na chave de idempotencia
+func (d *DespesaCEAP) calcularCentavos() {
+	d.ValorCentavos = int64(math.Round(d.Valor * 100))
+}
+
+// chaveDespesa espelha as colunas de idx_despesa_camara_unica
+type chaveDespesa struct {
+	deputadoID, ano, mes  int
+	cnpjCPF, numDocumento string
+	valorCentavos         int64
+}
+
+// deduplicarDespesas remove lancamentos com a mesma chave natural dentro de um lote.
+// O Postgres rejeita o INSERT ... ON CONFLICT inteiro quando duas linhas do mesmo
+// comando colidem; prevalece o ultimo lancamento recebido, na posicao do primeiro.
+// Espera ValorCentavos ja calculado.
+func deduplicarDespesas(despesas []DespesaCEAP) []DespesaCEAP {
+	posicao := make(map[chaveDespesa]int, len(despesas))
+	unicas := make([]DespesaCEAP, 0, len(despesas))
+	for _, d := range despesas {
+		chave := chaveDespesa{d.DeputadoID, d.Ano, d.Mes, d.CNPJCPF, d.NumDocumento, d.ValorCentavos}
+		if i, ok := posicao[chave]; ok {
+			unicas[i] = d
+			continue
+		}
+		posicao[chave] = len(unicas)
+		unicas = append(unicas, d)
+	}
+	return unicas
+}
+
+// AggregatedDespesa representa gastos agregados por categoria
+type AggregatedDespesa struct {
+	TipoDespesa string  `json:"tipo_despesa"`
+	Total       float64 `json:"total"`
+	Quantidade  int     `json:"quantidade"`
+}
@@ -0,0 +1,24 @@
+package despesa
+
+import "testing"
+
+// TestDeduplicarDespesas verifica que lancamentos repetidos na mesma pagina viram um so
+func TestDeduplicarDespesas(t *testing.T) {
+	despesas := []DespesaCEAP{
+		{DeputadoID: 1, Ano: 2024, Mes: 3, CNPJCPF: "123", NumDocumento: "NF1", Valor: 10, Fornecedor: "A"},
+		{DeputadoID: 1, Ano: 2024, Mes: 3, CNPJCPF: "123", NumDocumento: "NF2", Valor: 10},
+		{DeputadoID: 1, Ano: 2024, Mes: 3, CNPJCPF: "123", NumDocumento: "NF1", Valor: 10, Fornecedor: "A LTDA"},
+		{DeputadoID: 1, Ano: 2024, Mes: 3, CNPJCPF: "123", NumDocumento: "NF1", Valor: 12.5}, // Valor diferente
+	}
+	for i := range despesas {
+		despesas[i].calcularCentavos()
+	}
+
+	unicas := deduplicarDespesas(despesas)
+	if len(unicas) != 3 {
+		t.Fatalf("esperadas 3 despesas, obtidas %d: %+v", len(unicas), unicas)
+	}
+	if unicas[0].NumDocumento != "NF1" || unicas[0].Fornecedor != "A LTDA" {
+		t.Errorf("deveria prevalecer o ultimo lancamento repetido: %+v", unicas[0])
+	}
+}
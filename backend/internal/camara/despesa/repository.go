@@ -0,0 +1,77 @@
+package despesa
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository encapsula operacoes de banco de dados para DespesaCEAP
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository cria um novo repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// FindByDeputadoID retorna despesas de um deputado com paginacao e filtros
+func (r *Repository) FindByDeputadoID(deputadoID int, ano *int, limit, offset int, tipo string) ([]DespesaCEAP, int64, error) {
+	var despesas []DespesaCEAP
+	var total int64
+
+	query := r.db.Model(&DespesaCEAP{}).Where("deputado_id = ?", deputadoID)
+	if ano != nil {
+		query = query.Where("ano = ?", *ano)
+	}
+	if tipo != "" && tipo != "todos" {
+		query = query.Where("tipo_despesa = ?", tipo)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := query.Order("ano DESC, mes DESC, data_emissao DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&despesas)
+
+	return despesas, total, result.Error
+}
+
+// AggregateByTipo retorna gastos agregados por tipo de despesa
+func (r *Repository) AggregateByTipo(deputadoID int, ano *int) ([]AggregatedDespesa, error) {
+	var result []AggregatedDespesa
+
+	query := r.db.Model(&DespesaCEAP{}).
+		Select("tipo_despesa, SUM(valor) as total, COUNT(*) as quantidade").
+		Where("deputado_id = ?", deputadoID).
+		Group("tipo_despesa").
+		Order("total DESC")
+
+	if ano != nil {
+		query = query.Where("ano = ?", *ano)
+	}
+
+	err := query.Scan(&result).Error
+	return result, err
+}
+
+// UpsertBatch insere ou atualiza multiplas despesas
+func (r *Repository) UpsertBatch(despesas []DespesaCEAP) error {
+	if len(despesas) == 0 {
+		return nil
+	}
+	for i := range despesas {
+		despesas[i].calcularCentavos()
+	}
+	despesas = deduplicarDespesas(despesas)
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "deputado_id"}, {Name: "ano"}, {Name: "mes"},
+			{Name: "cnpj_cpf"}, {Name: "num_documento"}, {Name: "valor_centavos"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{"tipo_despesa", "tipo_documento", "fornecedor", "data_emissao", "valor", "valor_glosa", "url_documento", "updated_at"}),
+	}).CreateInBatches(despesas, 200).Error
+}
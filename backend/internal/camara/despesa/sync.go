@@ -0,0 +1,102 @@
+package despesa
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Alzarus/to-de-olho/internal/camara/deputado"
+	"github.com/Alzarus/to-de-olho/pkg/camara"
+)
+
+// SyncService gerencia sincronizacao de despesas CEAP da Camara
+type SyncService struct {
+	repo         *Repository
+	deputadoRepo *deputado.Repository
+	client       *camara.Client
+}
+
+// NewSyncService cria um novo servico de sincronizacao
+func NewSyncService(repo *Repository, deputadoRepo *deputado.Repository, client *camara.Client) *SyncService {
+	return &SyncService{
+		repo:         repo,
+		deputadoRepo: deputadoRepo,
+		client:       client,
+	}
+}
+
+// SyncFromAPI busca despesas de um ano para todos os deputados em exercicio
+func (s *SyncService) SyncFromAPI(ctx context.Context, ano int) error {
+	slog.Info("iniciando sync de despesas CEAP da camara", "ano", ano)
+
+	deputados, err := s.deputadoRepo.FindAll(false, "", "")
+	if err != nil {
+		return err
+	}
+
+	var totalDespesas, totalDeputados int
+	for _, dep := range deputados {
+		count, err := s.SyncDeputado(ctx, dep, ano)
+		if err != nil {
+			slog.Warn("falha ao buscar despesas do deputado", "deputado", dep.Nome, "error", err)
+			continue
+		}
+		totalDespesas += count
+		totalDeputados++
+	}
+
+	slog.Info("sync de despesas CEAP da camara concluido", "ano", ano, "deputados", totalDeputados, "despesas", totalDespesas)
+	return nil
+}
+
+// SyncDeputado busca todas as paginas de despesas de um deputado em um ano
+func (s *SyncService) SyncDeputado(ctx context.Context, dep deputado.Deputado, ano int) (int, error) {
+	var count int
+	for pagina := 1; ; pagina++ {
+		despesasAPI, temProxima, err := s.client.ListarDespesasDeputado(ctx, dep.CodigoDeputado, ano, pagina)
+		if err != nil {
+			return count, err
+		}
+
+		despesas := make([]DespesaCEAP, 0, len(despesasAPI))
+		for _, d := range despesasAPI {
+			despesas = append(despesas, s.convertToDespesa(d, dep.ID))
+		}
+		if err := s.repo.UpsertBatch(despesas); err != nil {
+			return count, err
+		}
+		count += len(despesas)
+
+		if !temProxima {
+			break
+		}
+	}
+	return count, nil
+}
+
+// convertToDespesa converte dados da API para modelo interno
+func (s *SyncService) convertToDespesa(d camara.DespesaAPI, deputadoID int) DespesaCEAP {
+	var dataEmissao *time.Time
+	if d.DataDocumento != "" {
+		if t, err := time.Parse("2006-01-02T15:04:05", d.DataDocumento); err == nil {
+			dataEmissao = &t
+		} else if t, err := time.Parse("2006-01-02", d.DataDocumento); err == nil {
+			dataEmissao = &t
+		}
+	}
+
+	return DespesaCEAP{
+		DeputadoID:    deputadoID,
+		Ano:           d.Ano,
+		Mes:           d.Mes,
+		TipoDespesa:   d.TipoDespesa,
+		TipoDocumento: d.TipoDocumento,
+		Fornecedor:    d.NomeFornecedor,
+		CNPJCPF:       d.CNPJCPFFornecedor,
+		NumDocumento:  d.NumDocumento,
+		DataEmissao:   dataEmissao,
+		Valor:         d.ValorLiquido,
+		ValorGlosa:    d.ValorGlosa,
+		URLDocumento:  d.URLDocumento,
+	}
+}
@@ -0,0 +1,89 @@
+package votacao
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler gerencia endpoints REST de votacoes da Camara
+type Handler struct {
+	repo *Repository
+}
+
+// NewHandler cria um novo handler
+func NewHandler(repo *Repository) *Handler {
+	return &Handler{repo: repo}
+}
+
+// ListByDeputado godoc
+// @Summary Lista votos de um deputado
+// @Tags deputados
+// @Produce json
+// @Param id path int true "ID do deputado"
+// @Param page query int false "Pagina (default 1)"
+// @Param limit query int false "Limite (default 20)"
+// @Param voto query string false "Tipo de voto"
+// @Param ano query int false "Ano"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/deputados/{id}/votacoes [get]
+func (h *Handler) ListByDeputado(c *gin.Context) {
+	deputadoID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalido"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	ano, _ := strconv.Atoi(c.Query("ano"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	offset := (page - 1) * limit
+
+	votacoes, total, err := h.repo.FindByDeputadoID(deputadoID, limit, offset, c.Query("voto"), ano)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar votacoes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"deputado_id": deputadoID,
+		"total":       total,
+		"page":        page,
+		"limit":       limit,
+		"total_pages": int((total + int64(limit) - 1) / int64(limit)),
+		"votacoes":    votacoes,
+	})
+}
+
+// GetStats godoc
+// @Summary Retorna estatisticas de votacao de um deputado
+// @Tags deputados
+// @Produce json
+// @Param id path int true "ID do deputado"
+// @Param ano query int false "Ano (default legislatura atual)"
+// @Success 200 {object} VotacaoStats
+// @Router /api/v1/deputados/{id}/votacoes/stats [get]
+func (h *Handler) GetStats(c *gin.Context) {
+	deputadoID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalido"})
+		return
+	}
+	ano, _ := strconv.Atoi(c.Query("ano"))
+
+	stats, err := h.repo.GetStats(deputadoID, ano)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao calcular estatisticas"})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
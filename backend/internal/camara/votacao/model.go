@@ -0,0 +1,66 @@
+package votacao
+
+import "time"
+
+// Votacao representa o voto de um deputado em uma votacao nominal da Camara
+type Votacao struct {
+	ID         int       `gorm:"primaryKey" json:"id"`
+	DeputadoID int       `gorm:"uniqueIndex:idx_votacao_camara_unica,priority:1;index:idx_votacao_camara_deputado;not null" json:"deputado_id"`
+	VotacaoID  string    `gorm:"uniqueIndex:idx_votacao_camara_unica,priority:2;index:idx_votacao_camara_votacao;not null" json:"votacao_id"` // Ex: "2367548-7"
+	Data       time.Time `gorm:"index" json:"data"`
+	Voto       string    `json:"voto"` // Sim, Nao, Abstencao, Obstrucao, Artigo17
+	SiglaOrgao string    `json:"sigla_orgao,omitempty"`
+	Descricao  string    `json:"descricao,omitempty"`
+	Aprovacao  *bool     `json:"aprovacao,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName define o nome da tabela
+func (Votacao) TableName() string {
+	return "votacoes_camara"
+}
+
+// VotacaoStats representa estatisticas de votacao de um deputado
+// A API da Camara so lista quem registrou voto, entao ausencias sao inferidas
+// a partir do total de votacoes nominais do periodo. Sem o historico de exercicio,
+// o periodo e limitado ao intervalo entre o primeiro e o ultimo voto do deputado,
+// para que suplentes e quem assumiu no meio da legislatura nao acumulem como
+// ausencia as votacoes de antes da posse ou depois da saida.
+type VotacaoStats struct {
+	DeputadoID       int        `json:"deputado_id"`
+	PrimeiroVoto     *time.Time `json:"primeiro_voto,omitempty"`
+	UltimoVoto       *time.Time `json:"ultimo_voto,omitempty"`
+	TotalVotacoes    int        `json:"total_votacoes"`    // Votacoes nominais entre o primeiro e o ultimo voto
+	VotosRegistrados int        `json:"votos_registrados"` // Sim + Nao + Abstencao
+	Obstrucoes       int        `json:"obstrucoes"`
+	Ausencias        int        `json:"ausencias"`
+	TaxaPresenca     float64    `json:"taxa_presenca"`     // 0-100
+	TaxaParticipacao float64    `json:"taxa_participacao"` // Votos efetivos / Total
+}
+
+// calcularTaxas preenche votos, ausencias e taxas a partir do total de votacoes do
+// periodo e da contagem de votos do deputado por tipo
+func (s *VotacaoStats) calcularTaxas(porVoto map[string]int) {
+	var presentes int
+	for voto, total := range porVoto {
+		presentes += total
+		switch voto {
+		case "Sim", "Nao", "Abstencao":
+			s.VotosRegistrados += total
+		case "Obstrucao":
+			s.Obstrucoes += total
+		}
+	}
+
+	s.Ausencias = s.TotalVotacoes - presentes
+	if s.Ausencias < 0 {
+		s.Ausencias = 0
+	}
+
+	if s.TotalVotacoes > 0 {
+		s.TaxaPresenca = float64(presentes) / float64(s.TotalVotacoes) * 100
+		s.TaxaParticipacao = float64(s.VotosRegistrados) / float64(s.TotalVotacoes) * 100
+	}
+}
@@ -0,0 +1,28 @@
+package votacao
+
+import "testing"
+
+func TestCalcularTaxas(t *testing.T) {
+	casos := []struct {
+		nome                           string
+		total                          int
+		porVoto                        map[string]int
+		registrados, ausencias         int
+		taxaPresenca, taxaParticipacao float64
+	}{
+		{"presente em todas", 10, map[string]int{"Sim": 6, "Nao": 3, "Obstrucao": 1}, 9, 0, 100, 90},
+		{"metade das votacoes", 8, map[string]int{"Sim": 2, "Abstencao": 1, "Artigo17": 1}, 3, 4, 50, 37.5},
+		{"sem votacoes", 0, nil, 0, 0, 0, 0},
+	}
+
+	for _, c := range casos {
+		stats := VotacaoStats{TotalVotacoes: c.total}
+		stats.calcularTaxas(c.porVoto)
+		if stats.VotosRegistrados != c.registrados || stats.Ausencias != c.ausencias {
+			t.Errorf("%s: registrados %d ausencias %d; esperado %d e %d", c.nome, stats.VotosRegistrados, stats.Ausencias, c.registrados, c.ausencias)
+		}
+		if stats.TaxaPresenca != c.taxaPresenca || stats.TaxaParticipacao != c.taxaParticipacao {
+			t.Errorf("%s: presenca %.2f participacao %.2f; esperado %.2f e %.2f", c.nome, stats.TaxaPresenca, stats.TaxaParticipacao, c.taxaPresenca, c.taxaParticipacao)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package votacao
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/Alzarus/to-de-olho/internal/utils"
+)
+
+// Repository encapsula operacoes de banco de dados para votacoes da Camara
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository cria um novo repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// FindByDeputadoID retorna votos de um deputado com paginacao e filtros
+func (r *Repository) FindByDeputadoID(deputadoID int, limit, offset int, voto string, ano int) ([]Votacao, int64, error) {
+	var votacoes []Votacao
+	var total int64
+
+	query := r.db.Model(&Votacao{}).Where("deputado_id = ?", deputadoID)
+
+	if ano > 0 {
+		query = query.Where("EXTRACT(YEAR FROM data) = ?", ano)
+	}
+	if voto != "" {
+		query = query.Where("voto = ?", voto)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := query.Order("data DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&votacoes)
+
+	return votacoes, total, result.Error
+}
+
+// GetStats retorna estatisticas de votacao de um deputado no ano informado
+// (ou na legislatura atual quando ano == 0). O total de votacoes considera apenas
+// o intervalo entre o primeiro e o ultimo voto do deputado no periodo.
+func (r *Repository) GetStats(deputadoID int, ano int) (*VotacaoStats, error) {
+	stats := VotacaoStats{DeputadoID: deputadoID}
+
+	dataInicio := fmt.Sprintf("%d-01-01", utils.GetInicioLegislaturaAtual())
+	dataFim := "9999-12-31"
+	if ano > 0 {
+		dataInicio = fmt.Sprintf("%d-01-01", ano)
+		dataFim = fmt.Sprintf("%d-01-01", ano+1)
+	}
+	dateFilter := "data >= ? AND data < ?"
+
+	// Intervalo em que o deputado aparece votando no periodo
+	var janela struct {
+		Inicio *time.Time
+		Fim    *time.Time
+	}
+	if err := r.db.Model(&Votacao{}).
+		Select("MIN(data) as inicio, MAX(data) as fim").
+		Where("deputado_id = ? AND "+dateFilter, deputadoID, dataInicio, dataFim).
+		Scan(&janela).Error; err != nil {
+		return nil, err
+	}
+	if janela.Inicio == nil || janela.Fim == nil {
+		return &stats, nil
+	}
+	stats.PrimeiroVoto, stats.UltimoVoto = janela.Inicio, janela.Fim
+
+	// Total de votacoes nominais nesse intervalo (qualquer deputado votou)
+	var total int64
+	if err := r.db.Model(&Votacao{}).
+		Where("data >= ? AND data <= ?", *janela.Inicio, *janela.Fim).
+		Distinct("votacao_id").
+		Count(&total).Error; err != nil {
+		return nil, err
+	}
+	stats.TotalVotacoes = int(total)
+
+	var contagem []struct {
+		Voto  string
+		Total int
+	}
+	if err := r.db.Model(&Votacao{}).
+		Select("voto, COUNT(*) as total").
+		Where("deputado_id = ? AND "+dateFilter, deputadoID, dataInicio, dataFim).
+		Group("voto").
+		Scan(&contagem).Error; err != nil {
+		return nil, err
+	}
+
+	porVoto := make(map[string]int, len(contagem))
+	for _, c := range contagem {
+		porVoto[c.Voto] += c.Total
+	}
+	stats.calcularTaxas(porVoto)
+
+	return &stats, nil
+}
+
+// UpsertBatch insere ou atualiza votos usando a chave (deputado_id, votacao_id)
+func (r *Repository) UpsertBatch(votacoes []Votacao) error {
+	if len(votacoes) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "deputado_id"}, {Name: "votacao_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"data", "voto", "sigla_orgao", "descricao", "aprovacao", "updated_at"}),
+	}).CreateInBatches(votacoes, 500).Error
+}
+
+// Count retorna total de votos da Camara no banco
+func (r *Repository) Count() (int64, error) {
+	var count int64
+	result := r.db.Model(&Votacao{}).Count(&count)
+	return count, result.Error
+}
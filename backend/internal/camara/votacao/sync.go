@@ -0,0 +1,165 @@
+package votacao
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Alzarus/to-de-olho/internal/camara/deputado"
+	"github.com/Alzarus/to-de-olho/pkg/camara"
+)
+
+// SyncService gerencia sincronizacao de votacoes da Camara
+type SyncService struct {
+	repo         *Repository
+	deputadoRepo *deputado.Repository
+	client       *camara.Client
+}
+
+// NewSyncService cria um novo servico de sincronizacao
+func NewSyncService(repo *Repository, deputadoRepo *deputado.Repository, client *camara.Client) *SyncService {
+	return &SyncService{
+		repo:         repo,
+		deputadoRepo: deputadoRepo,
+		client:       client,
+	}
+}
+
+// SyncFromAPI sincroniza todas as votacoes nominais de um ano (backfill)
+func (s *SyncService) SyncFromAPI(ctx context.Context, ano int) error {
+	inicio := time.Date(ano, time.January, 1, 0, 0, 0, 0, time.UTC)
+	fim := time.Date(ano, time.December, 31, 0, 0, 0, 0, time.UTC)
+	if hoje := time.Now().UTC(); fim.After(hoje) {
+		fim = hoje
+	}
+	return s.SyncPeriodo(ctx, inicio, fim)
+}
+
+// SyncRecentes sincroniza as votacoes dos ultimos dias (sync diario)
+func (s *SyncService) SyncRecentes(ctx context.Context, dias int) error {
+	fim := time.Now().UTC()
+	return s.SyncPeriodo(ctx, fim.AddDate(0, 0, -dias), fim)
+}
+
+// SyncPeriodo sincroniza votacoes entre duas datas
+// O intervalo e quebrado em janelas dentro do mesmo ano de no maximo 3 meses (limite da API)
+func (s *SyncService) SyncPeriodo(ctx context.Context, inicio, fim time.Time) error {
+	slog.Info("iniciando sync de votacoes da camara", "inicio", inicio.Format("2006-01-02"), "fim", fim.Format("2006-01-02"))
+
+	deputados, err := s.deputadoRepo.FindAll(true, "", "")
+	if err != nil {
+		return err
+	}
+	codigoToID := make(map[int]int, len(deputados))
+	for _, d := range deputados {
+		codigoToID[d.CodigoDeputado] = d.ID
+	}
+
+	var totalVotacoes, totalVotos int
+	for _, janela := range janelasPeriodo(inicio, fim) {
+		votacoesAPI, err := s.client.ListarVotacoes(ctx, janela[0], janela[1])
+		if err != nil {
+			slog.Warn("falha ao listar votacoes da camara", "inicio", janela[0], "fim", janela[1], "error", err)
+			continue
+		}
+
+		for _, v := range votacoesAPI {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+
+			votosAPI, err := s.client.ListarVotosVotacao(ctx, v.ID)
+			if err != nil {
+				slog.Warn("falha ao buscar votos da votacao", "votacao", v.ID, "error", err)
+				continue
+			}
+			if len(votosAPI) == 0 {
+				continue // Votacao simbolica
+			}
+
+			votos := make([]Votacao, 0, len(votosAPI))
+			for _, voto := range votosAPI {
+				deputadoID, ok := codigoToID[voto.Deputado.ID]
+				if !ok {
+					continue
+				}
+				votos = append(votos, s.convertToVotacao(v, voto, deputadoID))
+			}
+
+			if err := s.repo.UpsertBatch(votos); err != nil {
+				slog.Warn("falha ao salvar votos da camara", "votacao", v.ID, "error", err)
+				continue
+			}
+			totalVotacoes++
+			totalVotos += len(votos)
+		}
+	}
+
+	slog.Info("sync de votacoes da camara concluido", "votacoes", totalVotacoes, "votos", totalVotos)
+	return nil
+}
+
+// convertToVotacao converte um voto da API para modelo interno
+func (s *SyncService) convertToVotacao(v camara.VotacaoAPI, voto camara.VotoAPI, deputadoID int) Votacao {
+	var data time.Time
+	if t, err := time.Parse("2006-01-02T15:04:05", v.DataHoraRegistro); err == nil {
+		data = t
+	} else if t, err := time.Parse("2006-01-02", v.Data); err == nil {
+		// Meio-dia para evitar shift de timezone
+		data = time.Date(t.Year(), t.Month(), t.Day(), 12, 0, 0, 0, time.UTC)
+	}
+
+	var aprovacao *bool
+	if v.Aprovacao != nil {
+		aprovada := *v.Aprovacao == 1
+		aprovacao = &aprovada
+	}
+
+	return Votacao{
+		DeputadoID: deputadoID,
+		VotacaoID:  v.ID,
+		Data:       data,
+		Voto:       normalizeVoto(voto.TipoVoto),
+		SiglaOrgao: v.SiglaOrgao,
+		Descricao:  v.Descricao,
+		Aprovacao:  aprovacao,
+	}
+}
+
+// janelasPeriodo divide [inicio, fim] em janelas de ate 3 meses sem cruzar a virada de ano
+func janelasPeriodo(inicio, fim time.Time) [][2]time.Time {
+	var janelas [][2]time.Time
+	for atual := inicio; !atual.After(fim); {
+		limite := atual.AddDate(0, 3, -1)
+		fimAno := time.Date(atual.Year(), time.December, 31, 0, 0, 0, 0, atual.Location())
+		if limite.After(fimAno) {
+			limite = fimAno
+		}
+		if limite.After(fim) {
+			limite = fim
+		}
+		janelas = append(janelas, [2]time.Time{atual, limite})
+		atual = limite.AddDate(0, 0, 1)
+	}
+	return janelas
+}
+
+func normalizeVoto(voto string) string {
+	switch strings.TrimSpace(voto) {
+	case "Não", "Nao":
+		return "Nao"
+	case "Sim":
+		return "Sim"
+	case "Obstrução", "Obstrucao":
+		return "Obstrucao"
+	case "Abstenção", "Abstencao":
+		return "Abstencao"
+	case "Artigo 17":
+		return "Artigo17"
+	default:
+		return voto
+	}
+}
@@ -0,0 +1,50 @@
+package votacao
+
+import (
+	"testing"
+	"time"
+)
+
+// TestJanelasPeriodo verifica a quebra do periodo em janelas aceitas pela API da Camara
+func TestJanelasPeriodo(t *testing.T) {
+	data := func(ano int, mes time.Month, dia int) time.Time {
+		return time.Date(ano, mes, dia, 0, 0, 0, 0, time.UTC)
+	}
+
+	janelas := janelasPeriodo(data(2024, time.November, 15), data(2025, time.February, 10))
+
+	esperado := [][2]time.Time{
+		{data(2024, time.November, 15), data(2024, time.December, 31)},
+		{data(2025, time.January, 1), data(2025, time.February, 10)},
+	}
+
+	if len(janelas) != len(esperado) {
+		t.Fatalf("esperadas %d janelas, obtidas %d: %v", len(esperado), len(janelas), janelas)
+	}
+	for i := range esperado {
+		if !janelas[i][0].Equal(esperado[i][0]) || !janelas[i][1].Equal(esperado[i][1]) {
+			t.Errorf("janela %d = %v; esperado %v", i, janelas[i], esperado[i])
+		}
+	}
+
+	// Ano inteiro deve virar 4 janelas de 3 meses
+	if n := len(janelasPeriodo(data(2024, time.January, 1), data(2024, time.December, 31))); n != 4 {
+		t.Errorf("ano completo deveria gerar 4 janelas, obtidas %d", n)
+	}
+}
+
+// TestNormalizeVoto verifica a padronizacao dos tipos de voto da Camara
+func TestNormalizeVoto(t *testing.T) {
+	casos := map[string]string{
+		"Não":       "Nao",
+		"Sim":       "Sim",
+		"Obstrução": "Obstrucao",
+		"Abstenção": "Abstencao",
+		"Artigo 17": "Artigo17",
+	}
+	for entrada, esperado := range casos {
+		if got := normalizeVoto(entrada); got != esperado {
+			t.Errorf("normalizeVoto(%q) = %q; esperado %q", entrada, got, esperado)
+		}
+	}
+}
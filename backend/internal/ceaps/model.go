@@ -3,6 +3,7 @@ package ceaps
 import (
 	"time"
 
+	"github.com/Alzarus/to-de-olho/internal/utils"
 	"gorm.io/gorm"
 )
 
@@ -25,6 +26,9 @@ type DespesaCEAPS struct {
 	// Composta: (senador_id, cnpj_cpf, data_emissao, valor_centavos)
 	ValorCentavos int64 `gorm:"uniqueIndex:idx_despesa_unica,priority:4" json:"-"`
 
+	// CNPJ/CPF sem mascara, chave de juncao com a tabela de fornecedores
+	DocumentoLimpo string `gorm:"index" json:"-"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -34,9 +38,10 @@ func (DespesaCEAPS) TableName() string {
 	return "despesas_ceaps"
 }
 
-// BeforeCreate converte valor para centavos antes de inserir
+// BeforeCreate converte valor para centavos e limpa o documento antes de inserir
 func (d *DespesaCEAPS) BeforeCreate(_ *gorm.DB) error {
 	d.ValorCentavos = int64(d.Valor * 100)
+	d.DocumentoLimpo = utils.ApenasDigitos(d.CNPJCPF)
 	return nil
 }
 
@@ -53,3 +58,16 @@ type SenadorGastoMensal struct {
 	Mes   int     `json:"mes"`
 	Total float64 `json:"total"`
 }
+
+// TetoMensalPorUF define o valor mensal do teto por estado (referencia marco 2025, reajuste 12%)
+// Fonte: Senado Federal - Ato da Comissao Diretora (atualizado 10/01/2026)
+// Media nacional: R$ 46.402,62/mes
+var TetoMensalPorUF = map[string]float64{
+	"AC": 50426.26, "AL": 44500.00, "AM": 52798.82, "AP": 51103.82,
+	"BA": 45000.00, "CE": 48245.57, "DF": 36582.46, "ES": 42000.00,
+	"GO": 36582.46, "MA": 47500.00, "MG": 40000.00, "MS": 42000.00,
+	"MT": 44500.00, "PA": 48207.30, "PB": 45000.00, "PE": 46000.00,
+	"PI": 49000.00, "PR": 43000.00, "RJ": 42000.00, "RN": 46000.00,
+	"RO": 44000.00, "RR": 51500.00, "RS": 45500.00, "SC": 42000.00,
+	"SE": 53000.00, "SP": 40000.00, "TO": 36582.46,
+}
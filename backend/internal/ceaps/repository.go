@@ -119,6 +119,15 @@ func (r *Repository) GetTotal(senadorID int) (float64, error) {
 	return total, err
 }
 
+// FindByAno retorna todas as despesas de um ano (todos os senadores)
+func (r *Repository) FindByAno(ano int) ([]DespesaCEAPS, error) {
+	var despesas []DespesaCEAPS
+	result := r.db.Where("ano = ?", ano).
+		Order("senador_id, data_emissao").
+		Find(&despesas)
+	return despesas, result.Error
+}
+
 // Upsert insere ou atualiza uma despesa usando chave composta
 func (r *Repository) Upsert(despesa *DespesaCEAPS) error {
 	_ = despesa.BeforeCreate(nil)
@@ -127,6 +136,16 @@ func (r *Repository) Upsert(despesa *DespesaCEAPS) error {
 		Assign(*despesa).FirstOrCreate(despesa).Error
 }
 
+// PreencherDocumentosLimpos preenche o documento sem mascara das despesas gravadas antes
+// da coluna existir. Retorna o numero de despesas atualizadas.
+func (r *Repository) PreencherDocumentosLimpos() (int64, error) {
+	result := r.db.Exec(`
+		UPDATE despesas_ceaps
+		SET documento_limpo = regexp_replace(cnpj_cpf, '[^0-9]', '', 'g')
+		WHERE documento_limpo IS NULL OR (documento_limpo = '' AND cnpj_cpf <> '')`)
+	return result.RowsAffected, result.Error
+}
+
 // DeleteByAno remove todas as despesas de um determinado ano
 func (r *Repository) DeleteByAno(ano int) error {
 	return r.db.Where("ano = ?", ano).Delete(&DespesaCEAPS{}).Error
@@ -1,8 +1,11 @@
 package comissao
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -151,3 +154,148 @@ func (h *Handler) GetPorCasa(c *gin.Context) {
 		"por_casa":   casas,
 	})
 }
+
+// GetPresenca godoc
+// @Summary Retorna a presenca de um senador nas reunioes das comissoes em que e titular
+// @Tags comissoes
+// @Produce json
+// @Param id path int true "ID do senador"
+// @Param ano query int false "Ano (default legislatura atual)"
+// @Success 200 {object} PresencaStats
+// @Router /api/v1/senadores/{id}/comissoes/presenca [get]
+func (h *Handler) GetPresenca(c *gin.Context) {
+	senadorID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalido"})
+		return
+	}
+	ano, _ := strconv.Atoi(c.Query("ano"))
+
+	stats, err := h.repo.GetPresencaStats(senadorID, ano)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao calcular presenca em comissoes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// ListComissoes godoc
+// @Summary Lista comissoes com numero de membros e partido majoritario
+// @Tags comissoes
+// @Produce json
+// @Param data query string false "Data de referencia YYYY-MM-DD (default hoje)"
+// @Param casa query string false "Casa da comissao (SF/CN)"
+// @Param partido query string false "Apenas comissoes em que o partido tem a maior bancada de titulares"
+// @Param q query string false "Busca por sigla ou nome"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/comissoes [get]
+func (h *Handler) ListComissoes(c *gin.Context) {
+	data, err := parseDataReferencia(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	membros, err := h.repo.FindMembrosNaData("", data)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar comissoes"})
+		return
+	}
+
+	casa := strings.ToUpper(c.Query("casa"))
+	partido := strings.ToUpper(c.Query("partido"))
+	busca := strings.ToLower(c.Query("q"))
+
+	comissoes := make([]ResumoComissao, 0)
+	for _, r := range ResumirComissoes(membros) {
+		if casa != "" && !strings.EqualFold(r.SiglaCasaComissao, casa) {
+			continue
+		}
+		if partido != "" && !strings.EqualFold(r.PartidoMajoritario, partido) {
+			continue
+		}
+		if busca != "" && !strings.Contains(strings.ToLower(r.SiglaComissao+" "+r.NomeComissao), busca) {
+			continue
+		}
+		comissoes = append(comissoes, r)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":      data.Format("2006-01-02"),
+		"total":     len(comissoes),
+		"comissoes": comissoes,
+	})
+}
+
+// GetByCodigo godoc
+// @Summary Retorna a composicao de uma comissao: titulares, suplentes, partidos, UFs e historico
+// @Tags comissoes
+// @Produce json
+// @Param codigo path string true "Codigo da comissao"
+// @Param data query string false "Data de referencia YYYY-MM-DD (default hoje)"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/comissoes/{codigo} [get]
+func (h *Handler) GetByCodigo(c *gin.Context) {
+	codigo := c.Param("codigo")
+
+	data, err := parseDataReferencia(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	historico, err := h.repo.FindHistorico(codigo)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar historico"})
+		return
+	}
+	if len(historico) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "comissao nao encontrada"})
+		return
+	}
+
+	membros, err := h.repo.FindMembrosNaData(codigo, data)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar membros"})
+		return
+	}
+
+	titulares := make([]MembroComissao, 0, len(membros))
+	suplentes := make([]MembroComissao, 0, len(membros))
+	for _, m := range membros {
+		if m.Titular() {
+			titulares = append(titulares, m)
+		} else {
+			suplentes = append(suplentes, m)
+		}
+	}
+
+	ref := historico[0]
+	c.JSON(http.StatusOK, gin.H{
+		"codigo_comissao":     codigo,
+		"sigla_comissao":      ref.SiglaComissao,
+		"nome_comissao":       ref.NomeComissao,
+		"sigla_casa_comissao": ref.SiglaCasaComissao,
+		"data":                data.Format("2006-01-02"),
+		"total_membros":       len(membros),
+		"titulares":           titulares,
+		"suplentes":           suplentes,
+		"por_partido":         ComporPor(membros, PorPartido),
+		"por_uf":              ComporPor(membros, PorUF),
+		"historico":           historico,
+	})
+}
+
+// parseDataReferencia le ?data= (YYYY-MM-DD); sem parametro, usa a data atual
+func parseDataReferencia(c *gin.Context) (time.Time, error) {
+	v := c.Query("data")
+	if v == "" {
+		return time.Now().UTC().Truncate(24 * time.Hour), nil
+	}
+	data, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		return time.Time{}, errors.New("data invalida (use YYYY-MM-DD)")
+	}
+	return data, nil
+}
@@ -1,6 +1,9 @@
 package comissao
 
-import "time"
+import (
+	"sort"
+	"time"
+)
 
 // ComissaoMembro representa a participacao de um senador em uma comissao
 type ComissaoMembro struct {
@@ -38,3 +41,142 @@ type ComissoesPorCasa struct {
 	Casa  string `json:"casa"`
 	Total int    `json:"total"`
 }
+
+// ParticipacaoTitular e ParticipacaoSuplente sao os valores de DescricaoParticipacao
+const (
+	ParticipacaoTitular  = "Titular"
+	ParticipacaoSuplente = "Suplente"
+)
+
+// MembroComissao e uma participacao em comissao com os dados do senador.
+// Partido e o vigente na data de referencia da consulta.
+type MembroComissao struct {
+	ComissaoMembro
+	SenadorNome string `json:"senador_nome"`
+	Partido     string `json:"partido"`
+	UF          string `json:"uf"`
+	FotoURL     string `json:"foto_url,omitempty"`
+}
+
+// Titular indica se a participacao e como titular
+func (m MembroComissao) Titular() bool {
+	return m.DescricaoParticipacao == ParticipacaoTitular
+}
+
+// ResumoComissao descreve uma comissao e sua composicao em uma data
+type ResumoComissao struct {
+	CodigoComissao      string  `json:"codigo_comissao"`
+	SiglaComissao       string  `json:"sigla_comissao"`
+	NomeComissao        string  `json:"nome_comissao"`
+	SiglaCasaComissao   string  `json:"sigla_casa_comissao"`
+	Titulares           int     `json:"titulares"`
+	Suplentes           int     `json:"suplentes"`
+	TotalMembros        int     `json:"total_membros"`
+	PartidoMajoritario  string  `json:"partido_majoritario,omitempty"` // Maior bancada de titulares; vazio em caso de empate
+	AssentosMajoritario int     `json:"assentos_majoritario"`
+	Participacao        float64 `json:"participacao_majoritario"` // % dos titulares
+}
+
+// Composicao conta os membros de um grupo (partido ou UF) em uma comissao
+type Composicao struct {
+	Grupo      string  `json:"grupo"`
+	Titulares  int     `json:"titulares"`
+	Suplentes  int     `json:"suplentes"`
+	Total      int     `json:"total"`
+	Percentual float64 `json:"percentual"` // % dos titulares
+}
+
+// PorPartido e PorUF sao chaves de agrupamento para ComporPor
+var (
+	PorPartido = func(m MembroComissao) string { return m.Partido }
+	PorUF      = func(m MembroComissao) string { return m.UF }
+)
+
+// ComporPor agrupa os membros pela chave, do grupo com mais titulares ao com menos
+func ComporPor(membros []MembroComissao, chave func(MembroComissao) string) []Composicao {
+	porGrupo := make(map[string]*Composicao)
+	var grupos []string
+	var titulares int
+	for _, m := range membros {
+		grupo := chave(m)
+		if grupo == "" {
+			grupo = "N/I"
+		}
+		c, ok := porGrupo[grupo]
+		if !ok {
+			c = &Composicao{Grupo: grupo}
+			porGrupo[grupo] = c
+			grupos = append(grupos, grupo)
+		}
+		if m.Titular() {
+			c.Titulares++
+			titulares++
+		} else {
+			c.Suplentes++
+		}
+		c.Total++
+	}
+
+	composicao := make([]Composicao, 0, len(grupos))
+	for _, g := range grupos {
+		c := porGrupo[g]
+		if titulares > 0 {
+			c.Percentual = float64(c.Titulares) / float64(titulares) * 100
+		}
+		composicao = append(composicao, *c)
+	}
+	sort.SliceStable(composicao, func(i, j int) bool {
+		if composicao[i].Titulares != composicao[j].Titulares {
+			return composicao[i].Titulares > composicao[j].Titulares
+		}
+		if composicao[i].Total != composicao[j].Total {
+			return composicao[i].Total > composicao[j].Total
+		}
+		return composicao[i].Grupo < composicao[j].Grupo
+	})
+	return composicao
+}
+
+// ResumirComissoes agrupa os membros por comissao, ordenando pela sigla. O partido
+// majoritario e o com mais titulares; empates na primeira posicao ficam sem partido.
+func ResumirComissoes(membros []MembroComissao) []ResumoComissao {
+	porCodigo := make(map[string][]MembroComissao)
+	var codigos []string
+	for _, m := range membros {
+		if _, ok := porCodigo[m.CodigoComissao]; !ok {
+			codigos = append(codigos, m.CodigoComissao)
+		}
+		porCodigo[m.CodigoComissao] = append(porCodigo[m.CodigoComissao], m)
+	}
+
+	resumos := make([]ResumoComissao, 0, len(codigos))
+	for _, codigo := range codigos {
+		lista := porCodigo[codigo]
+		r := ResumoComissao{
+			CodigoComissao:    codigo,
+			SiglaComissao:     lista[0].SiglaComissao,
+			NomeComissao:      lista[0].NomeComissao,
+			SiglaCasaComissao: lista[0].SiglaCasaComissao,
+			TotalMembros:      len(lista),
+		}
+		for _, m := range lista {
+			if m.Titular() {
+				r.Titulares++
+			} else {
+				r.Suplentes++
+			}
+		}
+		partidos := ComporPor(lista, PorPartido)
+		if len(partidos) > 0 && partidos[0].Titulares > 0 &&
+			(len(partidos) == 1 || partidos[1].Titulares < partidos[0].Titulares) {
+			r.PartidoMajoritario = partidos[0].Grupo
+			r.AssentosMajoritario = partidos[0].Titulares
+			r.Participacao = partidos[0].Percentual
+		}
+		resumos = append(resumos, r)
+	}
+	sort.SliceStable(resumos, func(i, j int) bool {
+		return resumos[i].SiglaComissao < resumos[j].SiglaComissao
+	})
+	return resumos
+}
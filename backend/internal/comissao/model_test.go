@@ -0,0 +1,71 @@
+package comissao
+
+import "testing"
+
+func membro(codigo, sigla, participacao, partido, uf string) MembroComissao {
+	return MembroComissao{
+		ComissaoMembro: ComissaoMembro{CodigoComissao: codigo, SiglaComissao: sigla, DescricaoParticipacao: participacao},
+		Partido:        partido,
+		UF:             uf,
+	}
+}
+
+func TestComporPor(t *testing.T) {
+	membros := []MembroComissao{
+		membro("1", "CCJ", ParticipacaoTitular, "PL", "SP"),
+		membro("1", "CCJ", ParticipacaoTitular, "PT", "BA"),
+		membro("1", "CCJ", ParticipacaoTitular, "PT", "SP"),
+		membro("1", "CCJ", ParticipacaoSuplente, "PL", "RJ"),
+		membro("1", "CCJ", ParticipacaoTitular, "MDB", "SP"),
+		membro("1", "CCJ", ParticipacaoSuplente, "", "SP"),
+	}
+
+	partidos := ComporPor(membros, PorPartido)
+	if len(partidos) != 4 {
+		t.Fatalf("esperado 4 grupos, obtido %d: %+v", len(partidos), partidos)
+	}
+	if p := partidos[0]; p.Grupo != "PT" || p.Titulares != 2 || p.Percentual != 50 {
+		t.Errorf("primeiro grupo: %+v", p)
+	}
+	// PL e MDB empatam em titulares; PL tem mais membros no total
+	if p := partidos[1]; p.Grupo != "PL" || p.Titulares != 1 || p.Suplentes != 1 || p.Total != 2 {
+		t.Errorf("segundo grupo: %+v", p)
+	}
+	if p := partidos[3]; p.Grupo != "N/I" || p.Titulares != 0 || p.Suplentes != 1 {
+		t.Errorf("grupo sem partido: %+v", p)
+	}
+
+	ufs := ComporPor(membros, PorUF)
+	if ufs[0].Grupo != "SP" || ufs[0].Titulares != 3 || ufs[0].Total != 4 {
+		t.Errorf("UF principal: %+v", ufs[0])
+	}
+}
+
+func TestResumirComissoes(t *testing.T) {
+	membros := []MembroComissao{
+		membro("2", "CRE", ParticipacaoTitular, "PL", "SP"),
+		membro("2", "CRE", ParticipacaoTitular, "PT", "BA"),
+		membro("1", "CAE", ParticipacaoTitular, "PSD", "MG"),
+		membro("1", "CAE", ParticipacaoTitular, "PSD", "PR"),
+		membro("1", "CAE", ParticipacaoTitular, "PT", "BA"),
+		membro("1", "CAE", ParticipacaoSuplente, "PT", "CE"),
+	}
+
+	resumos := ResumirComissoes(membros)
+	if len(resumos) != 2 {
+		t.Fatalf("esperado 2 comissoes, obtido %d", len(resumos))
+	}
+
+	cae := resumos[0]
+	if cae.SiglaComissao != "CAE" || cae.Titulares != 3 || cae.Suplentes != 1 || cae.TotalMembros != 4 {
+		t.Errorf("CAE: %+v", cae)
+	}
+	if cae.PartidoMajoritario != "PSD" || cae.AssentosMajoritario != 2 {
+		t.Errorf("CAE majoritario: %+v", cae)
+	}
+
+	// Empate entre PL e PT: sem partido majoritario
+	if cre := resumos[1]; cre.SiglaComissao != "CRE" || cre.PartidoMajoritario != "" || cre.AssentosMajoritario != 0 {
+		t.Errorf("CRE: %+v", cre)
+	}
+}
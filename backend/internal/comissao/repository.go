@@ -2,8 +2,12 @@ package comissao
 
 import (
 	"fmt"
+	"time"
+
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"github.com/Alzarus/to-de-olho/internal/senador"
 	"github.com/Alzarus/to-de-olho/internal/utils"
 )
 
@@ -199,3 +203,113 @@ func (r *Repository) GetStatsByAno(senadorID int, ano int) (*ComissaoStats, erro
 
 	return &stats, nil
 }
+
+// membrosComSenador monta a consulta de participacoes com dados do senador e o partido
+// vigente na data de referencia
+func (r *Repository) membrosComSenador(data time.Time) *gorm.DB {
+	dataRef := fmt.Sprintf("'%s'", data.Format("2006-01-02"))
+	return r.db.Table("comissao_membros").
+		Select("comissao_membros.*, senadores.nome as senador_nome, senadores.uf as uf, senadores.foto_url as foto_url, "+
+			senador.PartidoNaDataSQL("comissao_membros.senador_id", dataRef)+" as partido").
+		Joins("JOIN senadores ON senadores.id = comissao_membros.senador_id")
+}
+
+// FindMembrosNaData retorna as participacoes vigentes na data. Com codigo vazio,
+// considera todas as comissoes.
+func (r *Repository) FindMembrosNaData(codigo string, data time.Time) ([]MembroComissao, error) {
+	dia := data.Format("2006-01-02")
+	query := r.membrosComSenador(data).
+		Where("(comissao_membros.data_inicio IS NULL OR comissao_membros.data_inicio <= ?)", dia).
+		Where("(comissao_membros.data_fim IS NULL OR comissao_membros.data_fim >= ?)", dia)
+	if codigo != "" {
+		query = query.Where("comissao_membros.codigo_comissao = ?", codigo)
+	}
+
+	var membros []MembroComissao
+	err := query.Order("comissao_membros.sigla_comissao, comissao_membros.descricao_participacao DESC, senadores.nome").
+		Scan(&membros).Error
+	return membros, err
+}
+
+// FindHistorico retorna todas as participacoes registradas na comissao, da mais recente
+// para a mais antiga. O partido e o vigente no inicio de cada participacao.
+func (r *Repository) FindHistorico(codigo string) ([]MembroComissao, error) {
+	var membros []MembroComissao
+	err := r.db.Table("comissao_membros").
+		Select("comissao_membros.*, senadores.nome as senador_nome, senadores.uf as uf, senadores.foto_url as foto_url, "+
+			senador.PartidoNaDataSQL("comissao_membros.senador_id", "COALESCE(comissao_membros.data_inicio, CURRENT_DATE)")+" as partido").
+		Joins("JOIN senadores ON senadores.id = comissao_membros.senador_id").
+		Where("comissao_membros.codigo_comissao = ?", codigo).
+		Order("comissao_membros.data_inicio DESC NULLS LAST, senadores.nome").
+		Scan(&membros).Error
+	return membros, err
+}
+
+// GetReunioesComPresenca retorna os codigos das reunioes do periodo [inicio, fim) que ja
+// tem lista de presenca registrada
+func (r *Repository) GetReunioesComPresenca(inicio, fim time.Time) ([]string, error) {
+	var codigos []string
+	err := r.db.Model(&Reuniao{}).
+		Where("total_presentes > 0 AND data >= ? AND data < ?", inicio, fim).
+		Pluck("codigo_reuniao", &codigos).Error
+	return codigos, err
+}
+
+// SaveReuniao insere ou atualiza a reuniao e substitui sua lista de presenca
+func (r *Repository) SaveReuniao(reuniao *Reuniao, presencas []Presenca) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "codigo_reuniao"}},
+			DoUpdates: clause.AssignmentColumns([]string{"codigo_comissao", "sigla_comissao", "nome_comissao", "casa", "data", "tipo", "situacao", "total_presentes", "updated_at"}),
+		}).Create(reuniao).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("codigo_reuniao = ?", reuniao.CodigoReuniao).Delete(&Presenca{}).Error; err != nil {
+			return err
+		}
+		if len(presencas) == 0 {
+			return nil
+		}
+		return tx.Create(&presencas).Error
+	})
+}
+
+// GetPresencaStats calcula a presenca de um senador nas reunioes das comissoes em que era
+// titular na data de cada reuniao. Com ano = 0 considera a legislatura atual.
+func (r *Repository) GetPresencaStats(senadorID, ano int) (*PresencaStats, error) {
+	inicio := time.Date(utils.GetInicioLegislaturaAtual(), time.February, 1, 0, 0, 0, 0, time.UTC)
+	fim := time.Now().AddDate(0, 0, 1)
+	if ano > 0 {
+		inicio = time.Date(ano, time.January, 1, 0, 0, 0, 0, time.UTC)
+		fim = time.Date(ano+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	var porComissao []PresencaComissao
+	err := r.db.Table("comissao_reunioes r").
+		Select(`r.codigo_comissao, MIN(r.sigla_comissao) as sigla_comissao, MIN(r.nome_comissao) as nome_comissao,
+			COUNT(*) as reunioes_esperadas, COUNT(p.id) as presencas`).
+		Joins(`JOIN comissao_membros m ON m.codigo_comissao = r.codigo_comissao AND m.senador_id = ?
+			AND m.descricao_participacao = ?
+			AND (m.data_inicio IS NULL OR m.data_inicio <= r.data)
+			AND (m.data_fim IS NULL OR m.data_fim >= r.data)`, senadorID, ParticipacaoTitular).
+		Joins("LEFT JOIN comissao_presencas p ON p.codigo_reuniao = r.codigo_reuniao AND p.senador_id = m.senador_id").
+		Where("r.total_presentes > 0 AND r.data >= ? AND r.data < ?", inicio, fim).
+		Group("r.codigo_comissao").
+		Order("reunioes_esperadas DESC, r.codigo_comissao").
+		Scan(&porComissao).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var presencasTotais int64
+	err = r.db.Table("comissao_presencas p").
+		Joins("JOIN comissao_reunioes r ON r.codigo_reuniao = p.codigo_reuniao").
+		Where("p.senador_id = ? AND r.data >= ? AND r.data < ?", senadorID, inicio, fim).
+		Count(&presencasTotais).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return consolidarPresenca(senadorID, int(presencasTotais), porComissao), nil
+}
@@ -0,0 +1,92 @@
+package comissao
+
+import (
+	"strings"
+	"time"
+)
+
+// Reuniao representa uma reuniao de comissao realizada
+type Reuniao struct {
+	ID             int       `gorm:"primaryKey" json:"id"`
+	CodigoReuniao  string    `gorm:"uniqueIndex;not null" json:"codigo_reuniao"`
+	CodigoComissao string    `gorm:"index" json:"codigo_comissao"` // Mesmo codigo de comissao_membros
+	SiglaComissao  string    `json:"sigla_comissao"`
+	NomeComissao   string    `json:"nome_comissao"`
+	Casa           string    `json:"casa"` // SF, CN
+	Data           time.Time `gorm:"index" json:"data"`
+	Tipo           string    `json:"tipo"` // Deliberativa, Audiencia Publica, etc.
+	Situacao       string    `json:"situacao"`
+	TotalPresentes int       `json:"total_presentes"` // 0 = lista de presenca nao publicada
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName define o nome da tabela
+func (Reuniao) TableName() string {
+	return "comissao_reunioes"
+}
+
+// Presenca registra um senador na lista de presenca de uma reuniao
+type Presenca struct {
+	ID            int       `gorm:"primaryKey" json:"id"`
+	CodigoReuniao string    `gorm:"uniqueIndex:idx_presenca_unica,priority:1;not null" json:"codigo_reuniao"`
+	SenadorID     int       `gorm:"uniqueIndex:idx_presenca_unica,priority:2;index;not null" json:"senador_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// TableName define o nome da tabela
+func (Presenca) TableName() string {
+	return "comissao_presencas"
+}
+
+// PresencaComissao resume a presenca de um senador nas reunioes de uma comissao.
+// Reunioes esperadas sao as realizadas, com lista de presenca publicada, enquanto
+// o senador era titular da comissao.
+type PresencaComissao struct {
+	CodigoComissao    string  `json:"codigo_comissao"`
+	SiglaComissao     string  `json:"sigla_comissao"`
+	NomeComissao      string  `json:"nome_comissao"`
+	ReunioesEsperadas int     `json:"reunioes_esperadas"`
+	Presencas         int     `json:"presencas"`
+	TaxaPresenca      float64 `json:"taxa_presenca"` // 0-100
+}
+
+// PresencaStats resume a presenca de um senador em reunioes de comissoes
+type PresencaStats struct {
+	SenadorID         int     `json:"senador_id"`
+	ReunioesEsperadas int     `json:"reunioes_esperadas"` // Como titular
+	Presencas         int     `json:"presencas"`          // Nas reunioes esperadas
+	TaxaPresenca      float64 `json:"taxa_presenca"`      // 0-100
+	PresencasTotais   int     `json:"presencas_totais"`   // Inclui comissoes como suplente ou nao membro
+
+	PorComissao []PresencaComissao `json:"por_comissao,omitempty"`
+}
+
+// taxa calcula o percentual de presencas sobre as reunioes esperadas
+func taxa(presencas, esperadas int) float64 {
+	if esperadas == 0 {
+		return 0
+	}
+	return float64(presencas) / float64(esperadas) * 100
+}
+
+// consolidarPresenca soma as comissoes no resumo geral e calcula as taxas
+func consolidarPresenca(senadorID, presencasTotais int, porComissao []PresencaComissao) *PresencaStats {
+	stats := &PresencaStats{SenadorID: senadorID, PresencasTotais: presencasTotais, PorComissao: porComissao}
+	for i := range porComissao {
+		c := &porComissao[i]
+		c.TaxaPresenca = taxa(c.Presencas, c.ReunioesEsperadas)
+		stats.ReunioesEsperadas += c.ReunioesEsperadas
+		stats.Presencas += c.Presencas
+	}
+	stats.TaxaPresenca = taxa(stats.Presencas, stats.ReunioesEsperadas)
+	return stats
+}
+
+// reuniaoRealizada indica se a situacao informada pela API corresponde a uma reuniao
+// que aconteceu (as canceladas e adiadas nao tem lista de presenca)
+func reuniaoRealizada(situacao string) bool {
+	s := strings.ToLower(situacao)
+	return strings.Contains(s, "realizada") || strings.Contains(s, "encerrada")
+}
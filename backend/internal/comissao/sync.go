@@ -3,6 +3,7 @@ package comissao
 import (
 	"context"
 	"log/slog"
+	"strconv"
 	"time"
 
 	"github.com/Alzarus/to-de-olho/internal/senador"
@@ -105,3 +106,111 @@ func (s *SyncService) convertToModel(api senadoapi.ComissaoAPI, senadorID int) C
 		DataFim:               dataFim,
 	}
 }
+
+// SyncReunioes busca as reunioes de comissao realizadas no ano e suas listas de presenca.
+// Reunioes que ja tem presenca registrada sao ignoradas; as demais sao consultadas
+// novamente, pois a lista costuma ser publicada dias depois da reuniao.
+func (s *SyncService) SyncReunioes(ctx context.Context, ano int) error {
+	slog.Info("iniciando sync de reunioes de comissoes", "ano", ano)
+
+	senadores, err := s.senadorRepo.FindAll(true)
+	if err != nil {
+		return err
+	}
+	codigoToID := make(map[int]int, len(senadores))
+	for _, sen := range senadores {
+		codigoToID[sen.CodigoParlamentar] = sen.ID
+	}
+
+	inicio := time.Date(ano, time.January, 1, 0, 0, 0, 0, time.UTC)
+	fim := inicio.AddDate(1, 0, 0)
+	importadas, err := s.repo.GetReunioesComPresenca(inicio, fim)
+	if err != nil {
+		return err
+	}
+	jaImportada := make(map[string]bool, len(importadas))
+	for _, codigo := range importadas {
+		jaImportada[codigo] = true
+	}
+
+	var totalReunioes, totalPresencas int
+	for mes := inicio; mes.Before(fim) && mes.Before(time.Now()); mes = mes.AddDate(0, 1, 0) {
+		reunioes, err := s.client.ListarAgendaComissoes(ctx, mes, mes.AddDate(0, 1, -1))
+		if err != nil {
+			slog.Warn("falha ao listar reunioes de comissoes", "mes", mes.Format("2006-01"), "error", err)
+			continue
+		}
+
+		for _, r := range reunioes {
+			if r.Codigo == "" || jaImportada[r.Codigo] || !reuniaoRealizada(r.Situacao) {
+				continue
+			}
+
+			detalhe, err := s.client.ObterReuniaoComissao(ctx, r.Codigo)
+			if err != nil {
+				slog.Warn("falha ao buscar presenca da reuniao", "reuniao", r.Codigo, "error", err)
+				continue
+			}
+
+			reuniao, presencas := convertReuniao(*detalhe, r, codigoToID)
+			if reuniao.Data.IsZero() {
+				continue
+			}
+			if err := s.repo.SaveReuniao(&reuniao, presencas); err != nil {
+				slog.Warn("falha ao salvar reuniao", "reuniao", r.Codigo, "error", err)
+				continue
+			}
+			totalReunioes++
+			totalPresencas += len(presencas)
+		}
+	}
+
+	slog.Info("sync de reunioes de comissoes concluido", "ano", ano, "reunioes", totalReunioes, "presencas", totalPresencas)
+	return nil
+}
+
+// convertReuniao converte o detalhe da reuniao e sua lista de presenca. Campos ausentes no
+// detalhe sao completados com os da agenda; parlamentares fora do banco (deputados em
+// comissoes mistas) sao ignorados.
+func convertReuniao(detalhe senadoapi.ReuniaoComissaoAPI, agenda senadoapi.ReuniaoAgendaAPI, codigoToID map[int]int) (Reuniao, []Presenca) {
+	reuniao := Reuniao{
+		CodigoReuniao:  agenda.Codigo,
+		CodigoComissao: primeiroNaoVazio(detalhe.Comissao.Codigo, agenda.Comissao.Codigo),
+		SiglaComissao:  primeiroNaoVazio(detalhe.Comissao.Sigla, agenda.Comissao.Sigla),
+		NomeComissao:   primeiroNaoVazio(detalhe.Comissao.Nome, agenda.Comissao.Nome),
+		Casa:           primeiroNaoVazio(detalhe.Comissao.SiglaCasa, agenda.Comissao.SiglaCasa),
+		Tipo:           primeiroNaoVazio(detalhe.Tipo, agenda.Tipo),
+		Situacao:       primeiroNaoVazio(detalhe.Situacao, agenda.Situacao),
+	}
+	if t, err := time.Parse("2006-01-02", primeiroNaoVazio(detalhe.Data, agenda.Data)); err == nil {
+		reuniao.Data = t
+	}
+
+	presencas := make([]Presenca, 0, len(detalhe.Presencas.Parlamentar))
+	vistos := make(map[int]bool)
+	for _, p := range detalhe.Presencas.Parlamentar {
+		codigo, err := strconv.Atoi(p.CodigoParlamentar)
+		if err != nil {
+			continue
+		}
+		senadorID, ok := codigoToID[codigo]
+		if !ok || vistos[senadorID] {
+			continue
+		}
+		vistos[senadorID] = true
+		presencas = append(presencas, Presenca{CodigoReuniao: reuniao.CodigoReuniao, SenadorID: senadorID})
+	}
+	// Lista publicada conta todos os presentes, inclusive deputados
+	reuniao.TotalPresentes = len(detalhe.Presencas.Parlamentar)
+
+	return reuniao, presencas
+}
+
+func primeiroNaoVazio(valores ...string) string {
+	for _, v := range valores {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
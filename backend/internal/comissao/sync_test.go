@@ -0,0 +1,80 @@
+package comissao
+
+import (
+	"testing"
+
+	senadoapi "github.com/Alzarus/to-de-olho/pkg/senado"
+)
+
+func TestConvertReuniao(t *testing.T) {
+	var agenda senadoapi.ReuniaoAgendaAPI
+	agenda.Codigo = "13050"
+	agenda.Data = "2025-04-09"
+	agenda.Situacao = "Realizada"
+	agenda.Comissao.Codigo = "34"
+	agenda.Comissao.Sigla = "CCJ"
+
+	var detalhe senadoapi.ReuniaoComissaoAPI
+	detalhe.Tipo = "Deliberativa"
+	detalhe.Presencas.Parlamentar = senadoapi.Lista[senadoapi.PresencaReuniaoAPI]{
+		{CodigoParlamentar: "5012"},
+		{CodigoParlamentar: "5012"}, // duplicado
+		{CodigoParlamentar: "9999"}, // deputado em comissao mista
+		{CodigoParlamentar: "abc"},
+	}
+
+	reuniao, presencas := convertReuniao(detalhe, agenda, map[int]int{5012: 7})
+
+	if reuniao.CodigoReuniao != "13050" || reuniao.CodigoComissao != "34" || reuniao.SiglaComissao != "CCJ" {
+		t.Errorf("identificacao incorreta: %+v", reuniao)
+	}
+	if reuniao.Tipo != "Deliberativa" || reuniao.Situacao != "Realizada" {
+		t.Errorf("tipo/situacao incorretos: %+v", reuniao)
+	}
+	if reuniao.Data.Format("2006-01-02") != "2025-04-09" {
+		t.Errorf("data incorreta: %v", reuniao.Data)
+	}
+	if reuniao.TotalPresentes != 4 {
+		t.Errorf("total de presentes esperado 4, obtido %d", reuniao.TotalPresentes)
+	}
+	if len(presencas) != 1 || presencas[0].SenadorID != 7 || presencas[0].CodigoReuniao != "13050" {
+		t.Errorf("presencas incorretas: %+v", presencas)
+	}
+}
+
+func TestReuniaoRealizada(t *testing.T) {
+	casos := map[string]bool{
+		"Realizada": true,
+		"Encerrada": true,
+		"Agendada":  false,
+		"Cancelada": false,
+		"Adiada":    false,
+		"":          false,
+	}
+	for situacao, esperado := range casos {
+		if got := reuniaoRealizada(situacao); got != esperado {
+			t.Errorf("reuniaoRealizada(%q) = %v, esperado %v", situacao, got, esperado)
+		}
+	}
+}
+
+func TestConsolidarPresenca(t *testing.T) {
+	stats := consolidarPresenca(1, 12, []PresencaComissao{
+		{CodigoComissao: "34", ReunioesEsperadas: 8, Presencas: 6},
+		{CodigoComissao: "38", ReunioesEsperadas: 2, Presencas: 0},
+	})
+
+	if stats.ReunioesEsperadas != 10 || stats.Presencas != 6 || stats.TaxaPresenca != 60 {
+		t.Errorf("resumo incorreto: %+v", stats)
+	}
+	if stats.PorComissao[0].TaxaPresenca != 75 || stats.PorComissao[1].TaxaPresenca != 0 {
+		t.Errorf("taxas por comissao incorretas: %+v", stats.PorComissao)
+	}
+	if stats.PresencasTotais != 12 {
+		t.Errorf("presencas totais esperadas 12, obtido %d", stats.PresencasTotais)
+	}
+
+	if vazio := consolidarPresenca(1, 0, nil); vazio.TaxaPresenca != 0 {
+		t.Errorf("sem reunioes esperadas a taxa deve ser 0: %+v", vazio)
+	}
+}
@@ -0,0 +1,87 @@
+package discurso
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler gerencia endpoints REST de discursos
+type Handler struct {
+	repo *Repository
+}
+
+// NewHandler cria um novo handler
+func NewHandler(repo *Repository) *Handler {
+	return &Handler{repo: repo}
+}
+
+// ListBySenador godoc
+// @Summary Lista discursos de um senador
+// @Tags discursos
+// @Produce json
+// @Param id path int true "ID do senador"
+// @Param limit query int false "Limite de resultados (default 20)"
+// @Param page query int false "Pagina (default 1)"
+// @Param ano query int false "Ano do discurso"
+// @Param tipo query string false "Tipo de uso da palavra"
+// @Param q query string false "Busca no resumo/indexacao"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/senadores/{id}/discursos [get]
+func (h *Handler) ListBySenador(c *gin.Context) {
+	senadorID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalido"})
+		return
+	}
+
+	limit := 20
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+	page := 1
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	ano, _ := strconv.Atoi(c.Query("ano"))
+	offset := (page - 1) * limit
+
+	discursos, total, err := h.repo.FindBySenadorID(senadorID, limit, offset, ano, c.Query("tipo"), c.Query("q"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar discursos"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"senador_id":  senadorID,
+		"total":       total,
+		"limit":       limit,
+		"page":        page,
+		"total_pages": (int(total) + limit - 1) / limit,
+		"discursos":   discursos,
+	})
+}
+
+// GetStats godoc
+// @Summary Retorna estatisticas de discursos de um senador
+// @Tags discursos
+// @Produce json
+// @Param id path int true "ID do senador"
+// @Success 200 {object} DiscursoStats
+// @Router /api/v1/senadores/{id}/discursos/stats [get]
+func (h *Handler) GetStats(c *gin.Context) {
+	senadorID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalido"})
+		return
+	}
+
+	stats, err := h.repo.GetStats(senadorID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao calcular estatisticas"})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
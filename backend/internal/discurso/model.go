@@ -0,0 +1,48 @@
+package discurso
+
+import "time"
+
+// Discurso representa um pronunciamento de um senador em sessao
+type Discurso struct {
+	ID                   int        `gorm:"primaryKey" json:"id"`
+	SenadorID            int        `gorm:"index:idx_discurso_senador_data,priority:1;not null" json:"senador_id"`
+	CodigoPronunciamento string     `gorm:"uniqueIndex;not null" json:"codigo_pronunciamento"`
+	Data                 *time.Time `gorm:"index:idx_discurso_senador_data,priority:2" json:"data,omitempty"`
+	Casa                 string     `json:"casa"` // SF, CN
+	TipoUsoPalavra       string     `json:"tipo_uso_palavra"`
+	CodigoSessao         string     `json:"codigo_sessao,omitempty"`
+	TipoSessao           string     `json:"tipo_sessao,omitempty"`
+	NumeroSessao         string     `json:"numero_sessao,omitempty"`
+	Resumo               string     `json:"resumo,omitempty"`
+	Indexacao            string     `json:"indexacao,omitempty"`
+	URLTexto             string     `json:"url_texto,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName define o nome da tabela
+func (Discurso) TableName() string {
+	return "discursos"
+}
+
+// DiscursoStats representa estatisticas de discursos de um senador
+type DiscursoStats struct {
+	SenadorID      int                `json:"senador_id"`
+	TotalDiscursos int                `json:"total_discursos"`
+	UltimoDiscurso *time.Time         `json:"ultimo_discurso,omitempty"`
+	PorTipo        []DiscursosPorTipo `json:"por_tipo"`
+	PorAno         []DiscursosPorAno  `json:"por_ano"`
+}
+
+// DiscursosPorTipo representa contagem de discursos por tipo de uso da palavra
+type DiscursosPorTipo struct {
+	Tipo  string `json:"tipo"`
+	Total int    `json:"total"`
+}
+
+// DiscursosPorAno representa contagem de discursos por ano
+type DiscursosPorAno struct {
+	Ano   int `json:"ano"`
+	Total int `json:"total"`
+}
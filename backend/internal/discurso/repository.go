@@ -0,0 +1,116 @@
+package discurso
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/Alzarus/to-de-olho/internal/utils"
+)
+
+// Repository encapsula operacoes de banco de dados para Discurso
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository cria um novo repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// FindBySenadorID retorna discursos de um senador com paginacao, busca e filtros
+func (r *Repository) FindBySenadorID(senadorID int, limit, offset int, ano int, tipo, queryStr string) ([]Discurso, int64, error) {
+	var discursos []Discurso
+	var total int64
+
+	dbQuery := r.db.Model(&Discurso{}).Where("senador_id = ?", senadorID)
+
+	if ano > 0 {
+		dbQuery = dbQuery.Where("EXTRACT(YEAR FROM data) = ?", ano)
+	}
+	if tipo != "" && tipo != "todos" {
+		dbQuery = dbQuery.Where("tipo_uso_palavra = ?", tipo)
+	}
+	if queryStr != "" {
+		search := "%" + queryStr + "%"
+		dbQuery = dbQuery.Where("(resumo ILIKE ? OR indexacao ILIKE ?)", search, search)
+	}
+
+	if err := dbQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := dbQuery.Order("data DESC NULLS LAST").
+		Limit(limit).
+		Offset(offset).
+		Find(&discursos)
+
+	return discursos, total, result.Error
+}
+
+// GetStats retorna estatisticas de discursos de um senador
+func (r *Repository) GetStats(senadorID int) (*DiscursoStats, error) {
+	stats := DiscursoStats{SenadorID: senadorID}
+
+	var total int64
+	if err := r.db.Model(&Discurso{}).Where("senador_id = ?", senadorID).Count(&total).Error; err != nil {
+		return nil, err
+	}
+	stats.TotalDiscursos = int(total)
+
+	var ultimo struct {
+		Data *time.Time
+	}
+	r.db.Model(&Discurso{}).
+		Select("MAX(data) as data").
+		Where("senador_id = ?", senadorID).
+		Scan(&ultimo)
+	stats.UltimoDiscurso = ultimo.Data
+
+	if err := r.db.Model(&Discurso{}).
+		Select("tipo_uso_palavra as tipo, COUNT(*) as total").
+		Where("senador_id = ?", senadorID).
+		Group("tipo_uso_palavra").
+		Order("total DESC").
+		Scan(&stats.PorTipo).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Model(&Discurso{}).
+		Select("CAST(EXTRACT(YEAR FROM data) AS INTEGER) as ano, COUNT(*) as total").
+		Where("senador_id = ? AND data IS NOT NULL", senadorID).
+		Group("ano").
+		Order("ano DESC").
+		Scan(&stats.PorAno).Error; err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// CountBySenadorID retorna o total de discursos de um senador no ano informado
+// ou no mandato atual (legislatura corrente) quando ano e nil
+func (r *Repository) CountBySenadorID(senadorID int, ano *int) (int64, error) {
+	var count int64
+	query := r.db.Model(&Discurso{}).Where("senador_id = ?", senadorID)
+	if ano != nil {
+		query = query.Where("data >= ? AND data < ?", fmt.Sprintf("%d-01-01", *ano), fmt.Sprintf("%d-01-01", *ano+1))
+	} else {
+		query = query.Where("data >= ?", fmt.Sprintf("%d-01-01", utils.GetInicioLegislaturaAtual()))
+	}
+	result := query.Count(&count)
+	return count, result.Error
+}
+
+// UpsertBatch insere ou atualiza discursos usando o codigo do pronunciamento
+func (r *Repository) UpsertBatch(discursos []Discurso) error {
+	if len(discursos) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "codigo_pronunciamento"}},
+		DoUpdates: clause.AssignmentColumns([]string{"data", "casa", "tipo_uso_palavra", "codigo_sessao", "tipo_sessao", "numero_sessao", "resumo", "indexacao", "url_texto", "updated_at"}),
+	}).CreateInBatches(discursos, 100).Error
+}
@@ -0,0 +1,105 @@
+package discurso
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Alzarus/to-de-olho/internal/senador"
+	senadoapi "github.com/Alzarus/to-de-olho/pkg/senado"
+)
+
+// SyncService gerencia sincronizacao de discursos
+type SyncService struct {
+	repo        *Repository
+	senadorRepo *senador.Repository
+	client      *senadoapi.LegisClient
+}
+
+// NewSyncService cria um novo servico de sincronizacao
+func NewSyncService(repo *Repository, senadorRepo *senador.Repository, client *senadoapi.LegisClient) *SyncService {
+	return &SyncService{
+		repo:        repo,
+		senadorRepo: senadorRepo,
+		client:      client,
+	}
+}
+
+// SyncFromAPI busca discursos de um ano para todos os senadores em exercicio
+func (s *SyncService) SyncFromAPI(ctx context.Context, ano int) error {
+	slog.Info("iniciando sync de discursos", "ano", ano)
+
+	senadores, err := s.senadorRepo.FindAll(false)
+	if err != nil {
+		return err
+	}
+
+	var totalDiscursos, totalSenadores int
+	for _, sen := range senadores {
+		count, err := s.SyncSenador(ctx, sen, ano)
+		if err != nil {
+			slog.Warn("falha ao buscar discursos", "senador", sen.Nome, "error", err)
+			continue
+		}
+		totalDiscursos += count
+		totalSenadores++
+	}
+
+	slog.Info("sync de discursos concluido", "ano", ano, "senadores", totalSenadores, "discursos", totalDiscursos)
+	return nil
+}
+
+// SyncSenador busca discursos de um senador em um ano
+func (s *SyncService) SyncSenador(ctx context.Context, sen senador.Senador, ano int) (int, error) {
+	inicio := time.Date(ano, time.January, 1, 0, 0, 0, 0, time.UTC)
+	fim := time.Date(ano, time.December, 31, 0, 0, 0, 0, time.UTC)
+	if hoje := time.Now(); fim.After(hoje) {
+		fim = hoje
+	}
+
+	pronunciamentos, err := s.client.ListarDiscursosParlamentar(ctx, sen.CodigoParlamentar, inicio, fim)
+	if err != nil {
+		return 0, err
+	}
+
+	discursos := make([]Discurso, 0, len(pronunciamentos))
+	for _, p := range pronunciamentos {
+		if p.CodigoPronunciamento == "" {
+			continue
+		}
+		discursos = append(discursos, s.convertToModel(p, sen.ID))
+	}
+
+	if err := s.repo.UpsertBatch(discursos); err != nil {
+		return 0, err
+	}
+
+	return len(discursos), nil
+}
+
+// convertToModel converte um pronunciamento da API para modelo interno
+func (s *SyncService) convertToModel(p senadoapi.PronunciamentoAPI, senadorID int) Discurso {
+	var data *time.Time
+	if t, err := time.Parse("2006-01-02", p.DataPronunciamento); err == nil {
+		data = &t
+	}
+
+	tipo := p.TipoUsoPalavra.Descricao
+	if tipo == "" {
+		tipo = p.TipoUsoPalavra.Sigla
+	}
+
+	return Discurso{
+		SenadorID:            senadorID,
+		CodigoPronunciamento: p.CodigoPronunciamento,
+		Data:                 data,
+		Casa:                 p.SiglaCasaPronunciamento,
+		TipoUsoPalavra:       tipo,
+		CodigoSessao:         p.SessaoPlenaria.CodigoSessao,
+		TipoSessao:           p.SessaoPlenaria.SiglaTipoSessao,
+		NumeroSessao:         p.SessaoPlenaria.NumeroSessao,
+		Resumo:               p.TextoResumo,
+		Indexacao:            p.Indexacao,
+		URLTexto:             p.UrlTexto,
+	}
+}
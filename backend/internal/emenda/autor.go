@@ -0,0 +1,265 @@
+package emenda
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Alzarus/to-de-olho/internal/senador"
+	"github.com/Alzarus/to-de-olho/internal/utils"
+)
+
+// Origens de um alias de autor
+const (
+	AliasAutomatico = "automatico" // Aprendido na sincronizacao (nome exato ou codigo)
+	AliasManual     = "manual"     // Definido por um administrador; tem prioridade
+	AliasPendente   = "pendente"   // Autor nao identificado, aguardando revisao
+)
+
+// Metodos de identificacao do autor
+const (
+	MetodoAliasManual = "alias_manual"
+	MetodoCodigo      = "codigo"
+	MetodoAlias       = "alias"
+	MetodoNome        = "nome"
+)
+
+// AutorAlias associa um nome de autor do Portal da Transparencia (e o codigo do autor,
+// quando conhecido) a um senador. SenadorID nil em um alias manual indica que o autor
+// nao e senador e deve ser ignorado; em um alias pendente, que ainda nao foi revisado.
+type AutorAlias struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Nome        string    `gorm:"uniqueIndex;not null" json:"nome"` // Normalizado (normalizarNomeAutor)
+	CodigoAutor string    `gorm:"index" json:"codigo_autor,omitempty"`
+	SenadorID   *uint     `gorm:"index" json:"senador_id"`
+	Origem      string    `gorm:"index;not null" json:"origem"`
+	Ocorrencias int       `json:"ocorrencias"` // Vezes em que o autor pendente foi visto
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName define o nome da tabela
+func (AutorAlias) TableName() string {
+	return "emenda_autores"
+}
+
+// IdentificacaoAutor e o resultado da resolucao de um autor
+type IdentificacaoAutor struct {
+	SenadorID uint
+	Metodo    string
+	Ignorado  bool // Alias manual sem senador: autor conhecido que nao e senador
+	Ambiguo   bool // Nome igual ao de mais de um senador
+}
+
+// Encontrado indica se o autor foi associado a um senador
+func (i IdentificacaoAutor) Encontrado() bool {
+	return i.SenadorID > 0
+}
+
+// ResolvedorAutores identifica o senador autor de uma emenda pelo codigo do autor,
+// pelos aliases gravados e, por ultimo, pelo nome exato (normalizado) do senador.
+// Nomes iguais aos de mais de um senador nunca sao resolvidos pelo nome.
+type ResolvedorAutores struct {
+	manuais    map[string]AutorAlias // Nome -> alias manual
+	automatico map[string]uint       // Nome -> senador
+	porCodigo  map[string]uint       // Codigo do autor -> senador
+	porNome    map[string][]uint     // Nome ou nome completo do senador -> senadores
+}
+
+// NewResolvedorAutores indexa os senadores e os aliases. Codigos de aliases manuais
+// prevalecem sobre os automaticos.
+func NewResolvedorAutores(senadores []senador.Senador, aliases []AutorAlias) *ResolvedorAutores {
+	r := &ResolvedorAutores{
+		manuais:    make(map[string]AutorAlias),
+		automatico: make(map[string]uint),
+		porCodigo:  make(map[string]uint),
+		porNome:    make(map[string][]uint),
+	}
+
+	for _, sen := range senadores {
+		vistos := make(map[string]bool)
+		for _, nome := range []string{sen.Nome, sen.NomeCompleto} {
+			chave := normalizarNomeAutor(nome)
+			if chave == "" || vistos[chave] {
+				continue
+			}
+			vistos[chave] = true
+			r.porNome[chave] = append(r.porNome[chave], uint(sen.ID))
+		}
+	}
+
+	for _, a := range aliases {
+		if a.Origem == AliasAutomatico && a.SenadorID != nil {
+			r.Aprender(a.CodigoAutor, a.Nome, *a.SenadorID)
+		}
+	}
+	for _, a := range aliases {
+		if a.Origem != AliasManual {
+			continue
+		}
+		r.manuais[normalizarNomeAutor(a.Nome)] = a
+		if codigo := normalizarCodigoAutor(a.CodigoAutor); codigo != "" && a.SenadorID != nil {
+			r.porCodigo[codigo] = *a.SenadorID
+		}
+	}
+	return r
+}
+
+// Aprender registra em memoria que o codigo e o nome pertencem ao senador
+func (r *ResolvedorAutores) Aprender(codigo, nome string, senadorID uint) {
+	if chave := normalizarNomeAutor(nome); chave != "" {
+		r.automatico[chave] = senadorID
+	}
+	if codigo = normalizarCodigoAutor(codigo); codigo != "" {
+		if _, existe := r.porCodigo[codigo]; !existe {
+			r.porCodigo[codigo] = senadorID
+		}
+	}
+}
+
+// conhecido indica se o nome (se houver) ja tem alias automatico e o codigo (se houver)
+// ja esta indexado
+func (r *ResolvedorAutores) conhecido(codigo, nome string) bool {
+	if chave := normalizarNomeAutor(nome); chave != "" {
+		if _, ok := r.automatico[chave]; !ok {
+			return false
+		}
+	}
+	codigo = normalizarCodigoAutor(codigo)
+	_, ok := r.porCodigo[codigo]
+	return codigo == "" || ok
+}
+
+// Resolver identifica o senador a partir do codigo do autor (pode ser vazio) e do nome
+func (r *ResolvedorAutores) Resolver(codigo, nome string) IdentificacaoAutor {
+	chave := normalizarNomeAutor(nome)
+	codigo = normalizarCodigoAutor(codigo)
+
+	if a, ok := r.manuais[chave]; ok && chave != "" {
+		if a.SenadorID == nil {
+			return IdentificacaoAutor{Ignorado: true}
+		}
+		return IdentificacaoAutor{SenadorID: *a.SenadorID, Metodo: MetodoAliasManual}
+	}
+	if id, ok := r.porCodigo[codigo]; ok && codigo != "" {
+		return IdentificacaoAutor{SenadorID: id, Metodo: MetodoCodigo}
+	}
+	if id, ok := r.automatico[chave]; ok {
+		return IdentificacaoAutor{SenadorID: id, Metodo: MetodoAlias}
+	}
+	switch candidatos := r.porNome[chave]; len(candidatos) {
+	case 0:
+		return IdentificacaoAutor{}
+	case 1:
+		return IdentificacaoAutor{SenadorID: candidatos[0], Metodo: MetodoNome}
+	default:
+		return IdentificacaoAutor{Ambiguo: true}
+	}
+}
+
+// NomesAlias retorna os nomes dos aliases (manuais e automaticos) de um senador
+func (r *ResolvedorAutores) NomesAlias(senadorID uint) []string {
+	var nomes []string
+	for nome, a := range r.manuais {
+		if a.SenadorID != nil && *a.SenadorID == senadorID {
+			nomes = append(nomes, nome)
+		}
+	}
+	for nome, id := range r.automatico {
+		if id == senadorID {
+			nomes = append(nomes, nome)
+		}
+	}
+	return nomes
+}
+
+// IdentificadorAutores aplica o ResolvedorAutores persistindo o que aprende: aliases
+// automaticos para autores identificados pelo nome e pendentes para os nao identificados
+type IdentificadorAutores struct {
+	repo       *Repository
+	resolvedor *ResolvedorAutores
+	pendentes  map[string]bool // Registrados nesta execucao
+}
+
+// NewIdentificadorAutores carrega os senadores (inclusive inativos) e os aliases gravados
+func NewIdentificadorAutores(repo *Repository, senadorRepo *senador.Repository) (*IdentificadorAutores, error) {
+	senadores, err := senadorRepo.FindAll(true)
+	if err != nil {
+		return nil, err
+	}
+	aliases, err := repo.FindAliases("")
+	if err != nil {
+		return nil, err
+	}
+	return &IdentificadorAutores{
+		repo:       repo,
+		resolvedor: NewResolvedorAutores(senadores, aliases),
+		pendentes:  make(map[string]bool),
+	}, nil
+}
+
+// Identificar retorna o senador autor da emenda. Autores nao identificados sao
+// registrados como pendentes para revisao.
+func (i *IdentificadorAutores) Identificar(codigo, nome string) (uint, bool) {
+	id := i.resolvedor.Resolver(codigo, nome)
+	if id.Encontrado() {
+		// Nomes e codigos ainda nao gravados viram alias para as proximas sincronizacoes.
+		// Sem nome nao ha alias a gravar (o nome e a chave da tabela).
+		if id.Metodo != MetodoAliasManual && !i.resolvedor.conhecido(codigo, nome) {
+			i.resolvedor.Aprender(codigo, nome, id.SenadorID)
+			if chave := normalizarNomeAutor(nome); chave != "" {
+				if err := i.repo.SalvarAliasAutomatico(chave, codigo, id.SenadorID); err != nil {
+					slog.Warn("falha ao salvar alias de autor", "nome", nome, "erro", err)
+				}
+			}
+		}
+		return id.SenadorID, true
+	}
+	if id.Ignorado {
+		return 0, false
+	}
+
+	chave := normalizarNomeAutor(nome)
+	if chave == "" || i.pendentes[chave] {
+		return 0, false
+	}
+	i.pendentes[chave] = true
+	slog.Warn("autor de emenda nao identificado", "nome", nome, "codigo_autor", codigo, "ambiguo", id.Ambiguo)
+	if err := i.repo.RegistrarAutorPendente(chave, codigo); err != nil {
+		slog.Warn("falha ao registrar autor pendente", "nome", nome, "erro", err)
+	}
+	return 0, false
+}
+
+// NomesBusca retorna os nomes usados para consultar as emendas de um senador na API:
+// nome parlamentar, nome completo e aliases conhecidos
+func (i *IdentificadorAutores) NomesBusca(sen senador.Senador) []string {
+	nomes := montarNomesBusca(sen)
+	vistos := make(map[string]bool, len(nomes))
+	for _, n := range nomes {
+		vistos[n] = true
+	}
+	for _, n := range i.resolvedor.NomesAlias(uint(sen.ID)) {
+		if !vistos[n] {
+			vistos[n] = true
+			nomes = append(nomes, n)
+		}
+	}
+	return nomes
+}
+
+// codigoAutorDaEmenda extrai o codigo do autor do codigo da emenda, que segue o formato
+// AAAA + codigo do autor (4 digitos) + sequencial (4 digitos)
+func codigoAutorDaEmenda(codigoEmenda string) string {
+	digitos := utils.ApenasDigitos(codigoEmenda)
+	if len(digitos) != 12 {
+		return ""
+	}
+	return digitos[4:8]
+}
+
+// normalizarCodigoAutor deixa apenas os digitos significativos do codigo do autor,
+// igualando "0123" (extraido do codigo da emenda) e "123" (coluna do CSV)
+func normalizarCodigoAutor(codigo string) string {
+	return strings.TrimLeft(utils.ApenasDigitos(codigo), "0")
+}
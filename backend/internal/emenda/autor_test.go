@@ -0,0 +1,93 @@
+package emenda
+
+import (
+	"testing"
+
+	"github.com/Alzarus/to-de-olho/internal/senador"
+)
+
+func ptrUint(v uint) *uint { return &v }
+
+func TestResolvedorAutores(t *testing.T) {
+	senadores := []senador.Senador{
+		{ID: 1, Nome: "Otto Alencar", NomeCompleto: "Otto Roberto Mendonça de Alencar"},
+		{ID: 2, Nome: "Jaques Wagner", NomeCompleto: "Jaques Wagner"},
+		{ID: 3, Nome: "Eduardo Braga", NomeCompleto: "Carlos Eduardo de Souza Braga"},
+		{ID: 4, Nome: "Eduardo Braga", NomeCompleto: "Eduardo Braga Filho"}, // Homonimo
+	}
+	aliases := []AutorAlias{
+		{Nome: "OTTO", CodigoAutor: "2747", SenadorID: ptrUint(1), Origem: AliasAutomatico},
+		{Nome: "JAQUES WAGNER", CodigoAutor: "0815", SenadorID: ptrUint(2), Origem: AliasAutomatico},
+		{Nome: "BANCADA DA BAHIA", Origem: AliasManual},                                  // Ignorado
+		{Nome: "BRAGA", CodigoAutor: "0815", SenadorID: ptrUint(3), Origem: AliasManual}, // Prevalece sobre o automatico
+		{Nome: "NOME PENDENTE", Origem: AliasPendente, Ocorrencias: 3},
+	}
+	r := NewResolvedorAutores(senadores, aliases)
+
+	casos := []struct {
+		nome, codigo  string
+		senadorID     uint
+		metodo        string
+		ignorado, amb bool
+	}{
+		{"Otto Roberto Mendonça de Alencar", "", 1, MetodoNome, false, false},
+		{"QUALQUER GRAFIA", "2747", 1, MetodoCodigo, false, false},
+		{"Qualquer grafia", "002747", 1, MetodoCodigo, false, false},
+		{"", "2747", 1, MetodoCodigo, false, false}, // Sem nome no CSV
+		{"Otto", "", 1, MetodoAlias, false, false},
+		{"JAQUES WAGNER", "0815", 3, MetodoCodigo, false, false},
+		{"Braga", "", 3, MetodoAliasManual, false, false},
+		{"Bancada da Bahia", "2747", 0, "", true, false},
+		{"Eduardo Braga", "", 0, "", false, true},
+		{"Nome Pendente", "", 0, "", false, false},
+		{"", "", 0, "", false, false},
+	}
+	for _, c := range casos {
+		id := r.Resolver(c.codigo, c.nome)
+		if id.SenadorID != c.senadorID || id.Metodo != c.metodo || id.Ignorado != c.ignorado || id.Ambiguo != c.amb {
+			t.Errorf("Resolver(%q, %q) = %+v, esperado senador %d metodo %q ignorado %v ambiguo %v",
+				c.codigo, c.nome, id, c.senadorID, c.metodo, c.ignorado, c.amb)
+		}
+	}
+}
+
+func TestResolvedorAutoresAprender(t *testing.T) {
+	r := NewResolvedorAutores([]senador.Senador{{ID: 7, Nome: "Fulano"}}, nil)
+	if r.conhecido("1234", "Fulano") {
+		t.Fatal("autor ainda nao aprendido marcado como conhecido")
+	}
+
+	r.Aprender("1234", "Fulano", 7)
+	if !r.conhecido("1234", "Fulano") {
+		t.Fatal("autor aprendido deveria ser conhecido")
+	}
+	if id := r.Resolver("1234", "Apelido do Fulano"); id.SenadorID != 7 || id.Metodo != MetodoCodigo {
+		t.Errorf("codigo aprendido nao resolvido: %+v", id)
+	}
+
+	// Sem nome, o autor e conhecido apenas pelo codigo
+	if !r.conhecido("1234", "") || r.conhecido("9999", "") {
+		t.Error("autor sem nome deveria ser conhecido apenas pelo codigo indexado")
+	}
+
+	// Um codigo ja indexado nao e reatribuido
+	r.Aprender("1234", "Outro", 8)
+	if id := r.Resolver("1234", ""); id.SenadorID != 7 {
+		t.Errorf("codigo reatribuido para %d", id.SenadorID)
+	}
+}
+
+func TestCodigoAutorDaEmenda(t *testing.T) {
+	casos := map[string]string{
+		"202427470001":   "2747",
+		"2024.2747.0001": "2747",
+		"202400120005":   "0012",
+		"12345":          "",
+		"":               "",
+	}
+	for codigo, esperado := range casos {
+		if got := codigoAutorDaEmenda(codigo); got != esperado {
+			t.Errorf("codigoAutorDaEmenda(%q) = %q, esperado %q", codigo, got, esperado)
+		}
+	}
+}
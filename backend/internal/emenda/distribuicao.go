@@ -0,0 +1,146 @@
+package emenda
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Alzarus/to-de-olho/internal/municipio"
+)
+
+// MunicipioDistribuicao e o valor pago pelas emendas de um senador em um municipio
+type MunicipioDistribuicao struct {
+	CodigoIBGE string   `json:"codigo_ibge"`
+	Nome       string   `json:"nome"`
+	UF         string   `json:"uf"`
+	NaUF       *bool    `json:"na_uf,omitempty"` // Municipio da UF do senador (nil com UF desconhecida)
+	Valor      float64  `json:"valor"`
+	Quantidade int      `json:"quantidade"`
+	Populacao  int64    `json:"populacao,omitempty"`
+	PerCapita  *float64 `json:"per_capita,omitempty"` // nil sem estimativa de populacao
+	Percentual float64  `json:"percentual"`           // Do valor destinado a municipios
+}
+
+// Distribuicao descreve para onde vai o dinheiro das emendas de um senador. Os valores
+// sao os pagos; a UF de cada destino vem do codigo IBGE resolvido da localidade.
+type Distribuicao struct {
+	SenadorID    uint   `json:"senador_id"`
+	UF           string `json:"uf"`
+	Ano          int    `json:"ano"`
+	AnoPopulacao int    `json:"ano_populacao,omitempty"`
+
+	TotalEmpenhado float64            `json:"total_empenhado"`
+	TotalPago      float64            `json:"total_pago"`
+	PorNivel       map[string]float64 `json:"por_nivel"` // municipio, uf, regiao, nacional, ...
+
+	// Destinos com UF conhecida (municipio ou UF). Ficam nil quando a UF do senador
+	// nao tem codigo IBGE: sem ela nao ha como separar dentro e fora.
+	ValorNaUF          *float64 `json:"valor_na_uf,omitempty"`
+	ValorForaUF        *float64 `json:"valor_fora_uf,omitempty"`
+	ParticipacaoForaUF *float64 `json:"participacao_fora_uf,omitempty"` // 0-100
+
+	// Municipios: concentracao (HHI 0-10000) e valores per capita
+	TotalMunicipios        int                     `json:"total_municipios"`
+	HHI                    float64                 `json:"hhi"`
+	MunicipiosEquivalentes float64                 `json:"municipios_equivalentes"` // 10000 / HHI
+	PerCapitaUF            *float64                `json:"per_capita_uf,omitempty"` // Valor nos municipios da UF / populacao da UF
+	Municipios             []MunicipioDistribuicao `json:"municipios"`
+}
+
+// CalcularDistribuicao agrega as emendas por destino. codigoUF e o codigo IBGE (2 digitos)
+// da UF do senador (vazio = desconhecida); municipios e populacao complementam os destinos municipais, e
+// populacaoUF (0 = desconhecida) permite o per capita na UF.
+func CalcularDistribuicao(
+	emendas []Emenda,
+	codigoUF string,
+	municipios map[string]municipio.Municipio,
+	populacao map[string]int64,
+	populacaoUF int64,
+) Distribuicao {
+	d := Distribuicao{
+		PorNivel:   make(map[string]float64),
+		Municipios: []MunicipioDistribuicao{},
+	}
+
+	porCodigo := make(map[string]*MunicipioDistribuicao)
+	ufConhecida := codigoUF != ""
+	var totalMunicipios, valorNaUF, valorForaUF, valorMunicipiosUF float64
+	for _, e := range emendas {
+		d.TotalEmpenhado += e.ValorEmpenhado
+		d.TotalPago += e.ValorPago
+
+		nivel := e.NivelLocalidade
+		if nivel == "" {
+			nivel = municipio.NivelNaoIdentificado
+		}
+		d.PorNivel[nivel] += e.ValorPago
+
+		if nivel != municipio.NivelMunicipio && nivel != municipio.NivelUF {
+			continue
+		}
+		naUF := ufConhecida && strings.HasPrefix(e.CodigoIBGE, codigoUF)
+		if naUF {
+			valorNaUF += e.ValorPago
+		} else {
+			valorForaUF += e.ValorPago
+		}
+
+		if nivel != municipio.NivelMunicipio {
+			continue
+		}
+		m, ok := porCodigo[e.CodigoIBGE]
+		if !ok {
+			ref := municipios[e.CodigoIBGE]
+			m = &MunicipioDistribuicao{
+				CodigoIBGE: e.CodigoIBGE,
+				Nome:       ref.Nome,
+				UF:         ref.UF,
+				Populacao:  populacao[e.CodigoIBGE],
+			}
+			if ufConhecida {
+				m.NaUF = &naUF
+			}
+			porCodigo[e.CodigoIBGE] = m
+		}
+		m.Valor += e.ValorPago
+		m.Quantidade++
+		totalMunicipios += e.ValorPago
+		if naUF {
+			valorMunicipiosUF += e.ValorPago
+		}
+	}
+
+	if ufConhecida {
+		var participacao float64
+		if conhecido := valorNaUF + valorForaUF; conhecido > 0 {
+			participacao = valorForaUF / conhecido * 100
+		}
+		d.ValorNaUF, d.ValorForaUF, d.ParticipacaoForaUF = &valorNaUF, &valorForaUF, &participacao
+	}
+
+	for _, m := range porCodigo {
+		if m.Populacao > 0 {
+			v := m.Valor / float64(m.Populacao)
+			m.PerCapita = &v
+		}
+		if totalMunicipios > 0 {
+			m.Percentual = m.Valor / totalMunicipios * 100
+			d.HHI += m.Percentual * m.Percentual
+		}
+		d.Municipios = append(d.Municipios, *m)
+	}
+	sort.Slice(d.Municipios, func(i, j int) bool {
+		if d.Municipios[i].Valor != d.Municipios[j].Valor {
+			return d.Municipios[i].Valor > d.Municipios[j].Valor
+		}
+		return d.Municipios[i].CodigoIBGE < d.Municipios[j].CodigoIBGE
+	})
+	d.TotalMunicipios = len(d.Municipios)
+	if d.HHI > 0 {
+		d.MunicipiosEquivalentes = 10000 / d.HHI
+	}
+	if ufConhecida && populacaoUF > 0 {
+		v := valorMunicipiosUF / float64(populacaoUF)
+		d.PerCapitaUF = &v
+	}
+	return d
+}
@@ -0,0 +1,80 @@
+package emenda
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Alzarus/to-de-olho/internal/municipio"
+)
+
+func TestCalcularDistribuicao(t *testing.T) {
+	emendas := []Emenda{
+		{ValorEmpenhado: 400, ValorPago: 300, NivelLocalidade: municipio.NivelMunicipio, CodigoIBGE: "2927408"},
+		{ValorEmpenhado: 100, ValorPago: 100, NivelLocalidade: municipio.NivelMunicipio, CodigoIBGE: "2927408"},
+		{ValorEmpenhado: 200, ValorPago: 200, NivelLocalidade: municipio.NivelMunicipio, CodigoIBGE: "3550308"},
+		{ValorEmpenhado: 100, ValorPago: 100, NivelLocalidade: municipio.NivelUF, CodigoIBGE: "29"},
+		{ValorEmpenhado: 50, ValorPago: 50, NivelLocalidade: municipio.NivelNacional},
+		{ValorEmpenhado: 10, ValorPago: 10},
+	}
+	municipios := map[string]municipio.Municipio{
+		"2927408": {CodigoIBGE: "2927408", Nome: "Salvador", UF: "BA"},
+		"3550308": {CodigoIBGE: "3550308", Nome: "São Paulo", UF: "SP"},
+	}
+	populacao := map[string]int64{"2927408": 2000}
+
+	d := CalcularDistribuicao(emendas, "29", municipios, populacao, 10000)
+
+	if d.TotalPago != 760 || d.TotalEmpenhado != 860 {
+		t.Errorf("totais incorretos: pago=%v empenhado=%v", d.TotalPago, d.TotalEmpenhado)
+	}
+	if d.PorNivel[municipio.NivelNacional] != 50 || d.PorNivel[municipio.NivelNaoIdentificado] != 10 {
+		t.Errorf("por nivel incorreto: %+v", d.PorNivel)
+	}
+	if d.ValorNaUF == nil || d.ValorForaUF == nil || *d.ValorNaUF != 500 || *d.ValorForaUF != 200 {
+		t.Fatalf("na UF=%v fora=%v, esperado 500/200", d.ValorNaUF, d.ValorForaUF)
+	}
+	if d.ParticipacaoForaUF == nil || math.Abs(*d.ParticipacaoForaUF-200.0/700*100) > 1e-9 {
+		t.Errorf("participacao fora da UF = %v", d.ParticipacaoForaUF)
+	}
+
+	if d.TotalMunicipios != 2 || d.Municipios[0].CodigoIBGE != "2927408" {
+		t.Fatalf("municipios: %+v", d.Municipios)
+	}
+	salvador := d.Municipios[0]
+	if salvador.Valor != 400 || salvador.Quantidade != 2 || salvador.NaUF == nil || !*salvador.NaUF || salvador.PerCapita == nil || *salvador.PerCapita != 0.2 {
+		t.Errorf("salvador: %+v", salvador)
+	}
+	if d.Municipios[1].PerCapita != nil || d.Municipios[1].NaUF == nil || *d.Municipios[1].NaUF {
+		t.Errorf("sao paulo sem populacao e fora da UF: %+v", d.Municipios[1])
+	}
+
+	// Participacoes de 66,7% e 33,3%: HHI = 4444,4 + 1111,1
+	if math.Abs(d.HHI-5555.5556) > 0.01 || math.Abs(d.MunicipiosEquivalentes-1.8) > 0.001 {
+		t.Errorf("HHI = %v, equivalentes = %v", d.HHI, d.MunicipiosEquivalentes)
+	}
+	if d.PerCapitaUF == nil || *d.PerCapitaUF != 0.04 {
+		t.Errorf("per capita na UF = %v, esperado 0.04", d.PerCapitaUF)
+	}
+}
+
+func TestCalcularDistribuicaoSemEmendas(t *testing.T) {
+	d := CalcularDistribuicao(nil, "29", nil, nil, 0)
+	if d.HHI != 0 || d.ParticipacaoForaUF == nil || *d.ParticipacaoForaUF != 0 || d.Municipios == nil || d.PerCapitaUF != nil {
+		t.Errorf("distribuicao vazia incorreta: %+v", d)
+	}
+}
+
+func TestCalcularDistribuicaoSemUF(t *testing.T) {
+	emendas := []Emenda{
+		{ValorPago: 300, NivelLocalidade: municipio.NivelMunicipio, CodigoIBGE: "2927408"},
+		{ValorPago: 100, NivelLocalidade: municipio.NivelUF, CodigoIBGE: "29"},
+	}
+
+	d := CalcularDistribuicao(emendas, "", nil, nil, 10000)
+	if d.ValorNaUF != nil || d.ValorForaUF != nil || d.ParticipacaoForaUF != nil || d.PerCapitaUF != nil {
+		t.Errorf("sem UF do senador a divisao deveria ficar vazia: %+v", d)
+	}
+	if len(d.Municipios) != 1 || d.Municipios[0].NaUF != nil || d.TotalPago != 400 {
+		t.Errorf("municipios sem UF incorretos: %+v", d.Municipios)
+	}
+}
@@ -0,0 +1,273 @@
+package emenda
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Alzarus/to-de-olho/internal/utils"
+)
+
+// Categoria classifica a emenda pelo tipo informado no Portal da Transparencia
+type Categoria string
+
+const (
+	CategoriaIndividual            Categoria = "individual"             // Transferencias com finalidade definida
+	CategoriaTransferenciaEspecial Categoria = "transferencia_especial" // "Emendas PIX"
+	CategoriaBancada               Categoria = "bancada"
+	CategoriaComissao              Categoria = "comissao"
+	CategoriaRelator               Categoria = "relator"
+	CategoriaOutra                 Categoria = "outra"
+)
+
+// Esferas do ente beneficiario de uma transferencia especial
+const (
+	EsferaMunicipal  = "Municipal"
+	EsferaEstadual   = "Estadual"
+	EsferaIndefinida = "Indefinida" // Nome fora dos padroes conhecidos (ex.: fundos municipais)
+)
+
+const (
+	// LimiarDestaquePix e o desvio robusto (MAD normalizado) a partir do qual a participacao
+	// de transferencias especiais de um senador e considerada muito acima dos pares
+	LimiarDestaquePix = 2.0
+
+	// AmostraMinimaPix evita comparar senadores quando poucos tem emendas no periodo
+	AmostraMinimaPix = 10
+)
+
+// ClassificarTipo converte o tipo textual da emenda ("Emenda Individual - Transferências
+// Especiais", "Emenda de Bancada", ...) em uma categoria
+func ClassificarTipo(tipo string) Categoria {
+	t := utils.RemoverAcentos(strings.ToLower(strings.TrimSpace(tipo)))
+	switch {
+	case t == "":
+		return ""
+	case strings.Contains(t, "especia"):
+		return CategoriaTransferenciaEspecial
+	case strings.Contains(t, "individual"):
+		return CategoriaIndividual
+	case strings.Contains(t, "bancada"):
+		return CategoriaBancada
+	case strings.Contains(t, "comissao"):
+		return CategoriaComissao
+	case strings.Contains(t, "relator"):
+		return CategoriaRelator
+	}
+	return CategoriaOutra
+}
+
+// TransferenciaEspecial e o plano de acao de uma transferencia especial no Transferegov:
+// o ente que recebeu o recurso e o que declarou que fara com ele
+type TransferenciaEspecial struct {
+	ID                uint    `gorm:"primaryKey" json:"id"`
+	IDPlanoAcao       int64   `gorm:"uniqueIndex;not null" json:"id_plano_acao"`
+	CodigoPlanoAcao   string  `json:"codigo_plano_acao"`
+	NumeroEmenda      string  `gorm:"index;not null" json:"numero_emenda"` // Mesmo codigo de Emenda.Numero
+	AnoEmenda         int     `gorm:"index" json:"ano_emenda"`
+	Situacao          string  `json:"situacao"`
+	BeneficiarioCNPJ  string  `gorm:"index" json:"beneficiario_cnpj"`
+	BeneficiarioNome  string  `json:"beneficiario_nome"`
+	Esfera            string  `json:"esfera"`                  // Municipal, Estadual ou Indefinida
+	Municipio         string  `gorm:"index" json:"municipio"`  // Vazio para estados
+	UF                string  `gorm:"index;size:2" json:"uf"`  // UF do ente
+	AreaPolitica      string  `json:"area_politica,omitempty"` // Areas de politicas publicas declaradas
+	Programacao       string  `json:"programacao,omitempty"`   // Programacao orcamentaria
+	ValorCusteio      float64 `json:"valor_custeio"`
+	ValorInvestimento float64 `json:"valor_investimento"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName define o nome da tabela
+func (TransferenciaEspecial) TableName() string {
+	return "emenda_transferencias_especiais"
+}
+
+// ValorTotal soma custeio e investimento do plano de acao
+func (t TransferenciaEspecial) ValorTotal() float64 {
+	return t.ValorCusteio + t.ValorInvestimento
+}
+
+// ParticipacaoPix compara o volume de transferencias especiais de um senador com o total
+// das suas emendas. Participacao e Escore usam o valor empenhado.
+type ParticipacaoPix struct {
+	SenadorID      uint    `json:"senador_id"`
+	Nome           string  `json:"nome,omitempty"`
+	Partido        string  `json:"partido,omitempty"`
+	UF             string  `json:"uf,omitempty"`
+	TotalEmpenhado float64 `json:"total_empenhado"`
+	PixEmpenhado   float64 `json:"pix_empenhado"`
+	PixPago        float64 `json:"pix_pago"`
+	QuantidadePix  int     `json:"quantidade_pix"`
+	Participacao   float64 `json:"participacao"` // 0-100
+	Escore         float64 `json:"escore"`       // Desvios robustos acima da mediana dos pares
+	Destaque       bool    `json:"destaque"`     // Participacao muito acima dos pares
+}
+
+// BeneficiarioPix soma as transferencias especiais recebidas por um ente
+type BeneficiarioPix struct {
+	BeneficiarioCNPJ string  `json:"beneficiario_cnpj"`
+	BeneficiarioNome string  `json:"beneficiario_nome"`
+	Esfera           string  `json:"esfera"`
+	Municipio        string  `json:"municipio,omitempty"`
+	UF               string  `json:"uf"`
+	Valor            float64 `json:"valor"`
+	Planos           int     `json:"planos"`
+	Senadores        int     `json:"senadores"`
+}
+
+// CalcularDestaquesPix calcula a participacao de cada senador e marca os que estao muito
+// acima dos pares, usando mediana e desvio absoluto mediano (MAD), robustos aos proprios
+// outliers. Senadores sem valor empenhado ficam fora da comparacao. Retorna a mediana.
+func CalcularDestaquesPix(participacoes []ParticipacaoPix) float64 {
+	var valores []float64
+	for i := range participacoes {
+		p := &participacoes[i]
+		p.Participacao, p.Escore, p.Destaque = 0, 0, false
+		if p.TotalEmpenhado <= 0 {
+			continue
+		}
+		p.Participacao = p.PixEmpenhado / p.TotalEmpenhado * 100
+		valores = append(valores, p.Participacao)
+	}
+
+	med := mediana(valores)
+	if len(valores) < AmostraMinimaPix {
+		return med
+	}
+
+	desvios := make([]float64, len(valores))
+	for i, v := range valores {
+		desvios[i] = math.Abs(v - med)
+	}
+	mad := mediana(desvios) * 1.4826 // Escala para equivaler ao desvio padrao na normal
+	if mad == 0 {
+		return med
+	}
+
+	for i := range participacoes {
+		p := &participacoes[i]
+		if p.TotalEmpenhado <= 0 {
+			continue
+		}
+		p.Escore = (p.Participacao - med) / mad
+		p.Destaque = p.Escore > LimiarDestaquePix
+	}
+	return med
+}
+
+// mediana calcula a mediana sem alterar o slice original
+func mediana(valores []float64) float64 {
+	if len(valores) == 0 {
+		return 0
+	}
+	ordenados := append([]float64(nil), valores...)
+	sort.Float64s(ordenados)
+	meio := len(ordenados) / 2
+	if len(ordenados)%2 == 0 {
+		return (ordenados[meio-1] + ordenados[meio]) / 2
+	}
+	return ordenados[meio]
+}
+
+// prefixosEnte mapeia o inicio do nome do beneficiario para a esfera do ente
+var prefixosEnte = []struct {
+	prefixo string
+	esfera  string
+}{
+	{"PREFEITURA MUNICIPAL DE ", EsferaMunicipal},
+	{"PREFEITURA MUNICIPAL DA ", EsferaMunicipal},
+	{"PREFEITURA MUNICIPAL DO ", EsferaMunicipal},
+	{"MUNICIPIO DE ", EsferaMunicipal},
+	{"MUNICIPIO DA ", EsferaMunicipal},
+	{"MUNICIPIO DO ", EsferaMunicipal},
+	{"GOVERNO DO ESTADO DE ", EsferaEstadual},
+	{"GOVERNO DO ESTADO DA ", EsferaEstadual},
+	{"GOVERNO DO ESTADO DO ", EsferaEstadual},
+	{"ESTADO DE ", EsferaEstadual},
+	{"ESTADO DA ", EsferaEstadual},
+	{"ESTADO DO ", EsferaEstadual},
+	{"DISTRITO FEDERAL", EsferaEstadual},
+}
+
+// identificarEnte extrai a esfera e o nome do municipio do nome do beneficiario
+// ("MUNICÍPIO DE SÃO PAULO" -> Municipal, "SÃO PAULO"). Nomes fora do padrao
+// ("FUNDO MUNICIPAL DE SAUDE DE X") ficam com esfera indefinida e sem municipio, em vez
+// de contar o nome inteiro como um municipio.
+func identificarEnte(nome string) (esfera, municipio string) {
+	nome = strings.Join(strings.Fields(nome), " ")
+	normalizado := normalizarNomeAutor(nome)
+	for _, p := range prefixosEnte {
+		if !strings.HasPrefix(normalizado, p.prefixo) {
+			continue
+		}
+		if p.esfera == EsferaEstadual {
+			return EsferaEstadual, ""
+		}
+		// Remove as palavras do prefixo preservando a acentuacao original
+		palavras := strings.Fields(nome)
+		return EsferaMunicipal, strings.Join(palavras[len(strings.Fields(p.prefixo)):], " ")
+	}
+	return EsferaIndefinida, ""
+}
+
+// AutorPix identifica o senador autor de uma transferencia especial
+type AutorPix struct {
+	SenadorID uint   `json:"senador_id"`
+	Nome      string `json:"nome"`
+	Partido   string `json:"partido,omitempty"`
+	UF        string `json:"uf,omitempty"`
+}
+
+// TransferenciaAutoria e um plano de acao com os senadores autores da emenda
+type TransferenciaAutoria struct {
+	TransferenciaEspecial
+	Autores []AutorPix `json:"autores"`
+}
+
+// AgruparBeneficiarios soma os planos de acao por ente beneficiario (CNPJ), contando os
+// senadores distintos que enviaram recursos, em ordem decrescente de valor
+func AgruparBeneficiarios(transferencias []TransferenciaEspecial, autores map[string][]uint) []BeneficiarioPix {
+	porCNPJ := make(map[string]*BeneficiarioPix)
+	senadores := make(map[string]map[uint]bool)
+	var ordem []string
+
+	for _, t := range transferencias {
+		chave := t.BeneficiarioCNPJ
+		if chave == "" {
+			chave = t.BeneficiarioNome
+		}
+		b, ok := porCNPJ[chave]
+		if !ok {
+			b = &BeneficiarioPix{
+				BeneficiarioCNPJ: t.BeneficiarioCNPJ,
+				BeneficiarioNome: t.BeneficiarioNome,
+				Esfera:           t.Esfera,
+				Municipio:        t.Municipio,
+				UF:               t.UF,
+			}
+			porCNPJ[chave] = b
+			senadores[chave] = make(map[uint]bool)
+			ordem = append(ordem, chave)
+		}
+		b.Valor += t.ValorTotal()
+		b.Planos++
+		for _, id := range autores[t.NumeroEmenda] {
+			senadores[chave][id] = true
+		}
+	}
+
+	beneficiarios := make([]BeneficiarioPix, 0, len(ordem))
+	for _, chave := range ordem {
+		b := porCNPJ[chave]
+		b.Senadores = len(senadores[chave])
+		beneficiarios = append(beneficiarios, *b)
+	}
+	sort.SliceStable(beneficiarios, func(i, j int) bool {
+		return beneficiarios[i].Valor > beneficiarios[j].Valor
+	})
+	return beneficiarios
+}
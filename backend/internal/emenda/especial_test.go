@@ -0,0 +1,107 @@
+package emenda
+
+import "testing"
+
+func TestClassificarTipo(t *testing.T) {
+	casos := map[string]Categoria{
+		"Emenda Individual - Transferências Especiais":               CategoriaTransferenciaEspecial,
+		"Emenda Individual - Transferências com Finalidade Definida": CategoriaIndividual,
+		"Emenda Individual":   CategoriaIndividual,
+		"Emenda de Bancada":   CategoriaBancada,
+		"Emenda de Comissão":  CategoriaComissao,
+		"Emenda de Relator":   CategoriaRelator,
+		"Especial":            CategoriaTransferenciaEspecial,
+		"Outro tipo qualquer": CategoriaOutra,
+		"  ":                  "",
+	}
+	for tipo, esperado := range casos {
+		if got := ClassificarTipo(tipo); got != esperado {
+			t.Errorf("ClassificarTipo(%q) = %q, esperado %q", tipo, got, esperado)
+		}
+	}
+}
+
+func TestIdentificarEnte(t *testing.T) {
+	casos := []struct {
+		nome, esfera, municipio string
+	}{
+		{"MUNICÍPIO DE SÃO PAULO", EsferaMunicipal, "SÃO PAULO"},
+		{"Prefeitura Municipal de  Feira de Santana", EsferaMunicipal, "Feira de Santana"},
+		{"ESTADO DA BAHIA", EsferaEstadual, ""},
+		{"DISTRITO FEDERAL", EsferaEstadual, ""},
+		{"FUNDO MUNICIPAL DE SAUDE DE IRECE", EsferaIndefinida, ""},
+	}
+	for _, c := range casos {
+		esfera, municipio := identificarEnte(c.nome)
+		if esfera != c.esfera || municipio != c.municipio {
+			t.Errorf("identificarEnte(%q) = (%q, %q), esperado (%q, %q)", c.nome, esfera, municipio, c.esfera, c.municipio)
+		}
+	}
+}
+
+func TestCalcularDestaquesPix(t *testing.T) {
+	// Dez senadores com participacao entre 40% e 58%, um com 95% e um sem emendas
+	var participacoes []ParticipacaoPix
+	for i := 0; i < 10; i++ {
+		participacoes = append(participacoes, ParticipacaoPix{
+			SenadorID:      uint(i + 1),
+			TotalEmpenhado: 100,
+			PixEmpenhado:   float64(40 + 2*i),
+		})
+	}
+	participacoes = append(participacoes,
+		ParticipacaoPix{SenadorID: 11, TotalEmpenhado: 200, PixEmpenhado: 190},
+		ParticipacaoPix{SenadorID: 12},
+	)
+
+	med := CalcularDestaquesPix(participacoes)
+	if med != 50 {
+		t.Errorf("mediana = %v, esperado 50", med)
+	}
+
+	for _, p := range participacoes {
+		esperado := p.SenadorID == 11
+		if p.Destaque != esperado {
+			t.Errorf("senador %d: destaque = %v (participacao %.1f, escore %.2f)", p.SenadorID, p.Destaque, p.Participacao, p.Escore)
+		}
+	}
+	if participacoes[10].Participacao != 95 {
+		t.Errorf("participacao = %v, esperado 95", participacoes[10].Participacao)
+	}
+	if participacoes[11].Participacao != 0 || participacoes[11].Escore != 0 {
+		t.Errorf("senador sem emendas deve ficar fora da comparacao: %+v", participacoes[11])
+	}
+}
+
+func TestCalcularDestaquesPixAmostraPequena(t *testing.T) {
+	participacoes := []ParticipacaoPix{
+		{SenadorID: 1, TotalEmpenhado: 100, PixEmpenhado: 10},
+		{SenadorID: 2, TotalEmpenhado: 100, PixEmpenhado: 100},
+	}
+	CalcularDestaquesPix(participacoes)
+	for _, p := range participacoes {
+		if p.Destaque {
+			t.Errorf("com menos de %d senadores nao deve haver destaque: %+v", AmostraMinimaPix, p)
+		}
+	}
+}
+
+func TestAgruparBeneficiarios(t *testing.T) {
+	transferencias := []TransferenciaEspecial{
+		{NumeroEmenda: "A", BeneficiarioCNPJ: "1", BeneficiarioNome: "MUNICIPIO DE X", ValorCusteio: 100, ValorInvestimento: 50},
+		{NumeroEmenda: "B", BeneficiarioCNPJ: "2", BeneficiarioNome: "MUNICIPIO DE Y", ValorInvestimento: 400},
+		{NumeroEmenda: "C", BeneficiarioCNPJ: "1", BeneficiarioNome: "MUNICIPIO DE X", ValorInvestimento: 300},
+	}
+	autores := map[string][]uint{"A": {10}, "B": {10}, "C": {20}}
+
+	beneficiarios := AgruparBeneficiarios(transferencias, autores)
+	if len(beneficiarios) != 2 {
+		t.Fatalf("esperado 2 beneficiarios, obtido %d", len(beneficiarios))
+	}
+	if b := beneficiarios[0]; b.BeneficiarioCNPJ != "1" || b.Valor != 450 || b.Planos != 2 || b.Senadores != 2 {
+		t.Errorf("primeiro beneficiario: %+v", b)
+	}
+	if b := beneficiarios[1]; b.BeneficiarioCNPJ != "2" || b.Valor != 400 || b.Senadores != 1 {
+		t.Errorf("segundo beneficiario: %+v", b)
+	}
+}
@@ -1,10 +1,15 @@
 package emenda
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/Alzarus/to-de-olho/internal/utils"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type Handler struct {
@@ -45,3 +50,399 @@ func (h *Handler) GetBySenador(c *gin.Context) {
 		"resumo":  resumo,
 	})
 }
+
+// GetDistribuicao godoc
+// @Summary Distribuicao geografica das emendas de um senador: fora da UF, concentracao (HHI) e per capita
+// @Description Valores pagos. HHI = soma dos quadrados das participacoes (%) de cada municipio, em 0-10000.
+// @Tags emendas
+// @Produce json
+// @Param id path int true "ID do senador"
+// @Param ano query int false "Ano das emendas (default todos)"
+// @Success 200 {object} Distribuicao
+// @Router /api/v1/senadores/{id}/emendas/distribuicao [get]
+func (h *Handler) GetDistribuicao(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id invalido"})
+		return
+	}
+	ano, _ := strconv.Atoi(c.Query("ano"))
+
+	distribuicao, err := h.service.GetDistribuicao(uint(id), ano)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "senador nao encontrado"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao calcular distribuicao"})
+		return
+	}
+
+	c.JSON(http.StatusOK, distribuicao)
+}
+
+// GetByNumero godoc
+// @Summary Retorna uma emenda com a execucao: empenhos, liquidacoes, pagamentos e favorecidos
+// @Tags emendas
+// @Produce json
+// @Param numero path string true "Codigo da emenda"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/emendas/{numero} [get]
+func (h *Handler) GetByNumero(c *gin.Context) {
+	numero := strings.TrimSpace(c.Param("numero"))
+
+	emendas, err := h.service.GetByNumero(numero)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar emenda"})
+		return
+	}
+
+	rastro, err := h.service.GetRastro(numero, emendas)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar documentos da emenda"})
+		return
+	}
+
+	if len(emendas) == 0 && len(rastro.Empenhos)+len(rastro.Liquidacoes)+len(rastro.Pagamentos) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "emenda nao encontrada"})
+		return
+	}
+
+	// Autorias do mesmo numero compartilham os documentos; a sincronizacao mais antiga vale para todas
+	var atualizadosEm *time.Time
+	for _, e := range emendas {
+		if e.DocumentosAtualizadosEm == nil {
+			atualizadosEm = nil
+			break
+		}
+		if atualizadosEm == nil || e.DocumentosAtualizadosEm.Before(*atualizadosEm) {
+			atualizadosEm = e.DocumentosAtualizadosEm
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"numero":                    numero,
+		"autorias":                  emendas,
+		"execucao":                  rastro,
+		"documentos_atualizados_em": atualizadosEm,
+	})
+}
+
+// GetPixBySenador godoc
+// @Summary Transferencias especiais ("emendas PIX") de um senador comparadas com os pares
+// @Description Participacao = valor empenhado em transferencias especiais / total empenhado em emendas.
+// @Tags emendas
+// @Produce json
+// @Param id path int true "ID do senador"
+// @Param ano query int false "Ano das emendas (default todos)"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/senadores/{id}/emendas/pix [get]
+func (h *Handler) GetPixBySenador(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id invalido"})
+		return
+	}
+	ano, _ := strconv.Atoi(c.Query("ano"))
+
+	if _, err := h.service.GetSenador(uint(id)); errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "senador nao encontrado"})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar senador"})
+		return
+	}
+
+	participacoes, med, err := h.service.GetParticipacaoPix(ano)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao calcular participacao"})
+		return
+	}
+	participacao := ParticipacaoPix{SenadorID: uint(id)}
+	for _, p := range participacoes {
+		if p.SenadorID == uint(id) {
+			participacao = p
+			break
+		}
+	}
+
+	beneficiarios, err := h.service.ListBeneficiariosPix(FiltroPix{Ano: ano, SenadorID: uint(id)})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar beneficiarios"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"senador_id":    id,
+		"ano":           ano,
+		"participacao":  participacao,
+		"mediana_pares": med,
+		"total_pares":   len(participacoes),
+		"limiar":        LimiarDestaquePix,
+		"beneficiarios": beneficiarios,
+	})
+}
+
+// ListPixSenadores godoc
+// @Summary Participacao de transferencias especiais nas emendas de cada senador, com destaques
+// @Description Destaque = participacao mais de LimiarDestaquePix desvios robustos (MAD) acima da mediana dos senadores.
+// @Tags emendas
+// @Produce json
+// @Param ano query int false "Ano das emendas (default todos)"
+// @Param destaques query bool false "Apenas senadores em destaque"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/emendas/pix/senadores [get]
+func (h *Handler) ListPixSenadores(c *gin.Context) {
+	ano, _ := strconv.Atoi(c.Query("ano"))
+
+	participacoes, med, err := h.service.GetParticipacaoPix(ano)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao calcular participacao"})
+		return
+	}
+
+	destaques := 0
+	for _, p := range participacoes {
+		if p.Destaque {
+			destaques++
+		}
+	}
+	if c.Query("destaques") == "true" {
+		filtradas := make([]ParticipacaoPix, 0, destaques)
+		for _, p := range participacoes {
+			if p.Destaque {
+				filtradas = append(filtradas, p)
+			}
+		}
+		participacoes = filtradas
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ano":             ano,
+		"mediana":         med,
+		"limiar":          LimiarDestaquePix,
+		"total_destaques": destaques,
+		"senadores":       participacoes,
+	})
+}
+
+// ListPixMunicipios godoc
+// @Summary Entes que mais receberam transferencias especiais de senadores
+// @Tags emendas
+// @Produce json
+// @Param ano query int false "Ano das emendas (default todos)"
+// @Param uf query string false "UF do ente"
+// @Param esfera query string false "municipal (default), estadual ou indefinida"
+// @Param limit query int false "Quantidade (default 50, max 500)"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/emendas/pix/municipios [get]
+func (h *Handler) ListPixMunicipios(c *gin.Context) {
+	ano, _ := strconv.Atoi(c.Query("ano"))
+
+	esfera := EsferaMunicipal
+	for _, e := range []string{EsferaEstadual, EsferaIndefinida} {
+		if strings.EqualFold(c.Query("esfera"), e) {
+			esfera = e
+		}
+	}
+
+	limit := 50
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 500 {
+		limit = l
+	}
+
+	beneficiarios, err := h.service.ListBeneficiariosPix(FiltroPix{
+		Ano:    ano,
+		UF:     strings.ToUpper(c.Query("uf")),
+		Esfera: esfera,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar transferencias especiais"})
+		return
+	}
+
+	total := len(beneficiarios)
+	if len(beneficiarios) > limit {
+		beneficiarios = beneficiarios[:limit]
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ano":           ano,
+		"esfera":        esfera,
+		"total":         total,
+		"beneficiarios": beneficiarios,
+	})
+}
+
+// GetPixMunicipio godoc
+// @Summary Transferencias especiais recebidas por um ente, com os planos de acao e os senadores autores
+// @Tags emendas
+// @Produce json
+// @Param cnpj path string true "CNPJ do ente beneficiario"
+// @Param ano query int false "Ano das emendas (default todos)"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/emendas/pix/municipios/{cnpj} [get]
+func (h *Handler) GetPixMunicipio(c *gin.Context) {
+	cnpj := utils.ApenasDigitos(c.Param("cnpj"))
+	if len(cnpj) != 14 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "cnpj invalido"})
+		return
+	}
+	ano, _ := strconv.Atoi(c.Query("ano"))
+
+	transferencias, err := h.service.GetTransferenciasComAutores(FiltroPix{Ano: ano, BeneficiarioCNPJ: cnpj})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar transferencias especiais"})
+		return
+	}
+	if len(transferencias) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "nenhuma transferencia especial para o ente"})
+		return
+	}
+
+	planos := make([]TransferenciaEspecial, len(transferencias))
+	autores := make(map[string][]uint)
+	for i, t := range transferencias {
+		planos[i] = t.TransferenciaEspecial
+		for _, a := range t.Autores {
+			autores[t.NumeroEmenda] = append(autores[t.NumeroEmenda], a.SenadorID)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ano":            ano,
+		"beneficiario":   AgruparBeneficiarios(planos, autores)[0],
+		"transferencias": transferencias,
+	})
+}
+
+// GetByMunicipio godoc
+// @Summary Emendas destinadas a um municipio, agrupadas por senador autor
+// @Tags emendas
+// @Produce json
+// @Param ibge path string true "Codigo IBGE do municipio (7 digitos)"
+// @Param ano query int false "Ano das emendas (default todos)"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/municipios/{ibge}/emendas [get]
+func (h *Handler) GetByMunicipio(c *gin.Context) {
+	codigo := c.Param("ibge")
+	if len(codigo) != 7 || utils.ApenasDigitos(codigo) != codigo {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "codigo IBGE invalido"})
+		return
+	}
+	ano, _ := strconv.Atoi(c.Query("ano"))
+
+	municipio, err := h.service.GetMunicipio(codigo)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "municipio nao encontrado"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar municipio"})
+		return
+	}
+
+	emendas, err := h.service.ListByMunicipio(codigo, ano)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar emendas"})
+		return
+	}
+	senadores, resumo := ResumirPorSenador(emendas)
+
+	c.JSON(http.StatusOK, gin.H{
+		"municipio": municipio,
+		"ano":       ano,
+		"resumo": gin.H{
+			"total_empenhado": resumo.TotalEmpenhado,
+			"total_pago":      resumo.TotalPago,
+			"quantidade":      resumo.Quantidade,
+		},
+		"senadores": senadores,
+		"emendas":   emendas,
+	})
+}
+
+// ListAutores godoc
+// @Summary Aliases de autores de emendas (admin)
+// @Description Nomes do Portal da Transparencia associados a senadores. Com pendentes=true, apenas os autores nao identificados nas sincronizacoes.
+// @Tags admin
+// @Produce json
+// @Param pendentes query bool false "Apenas autores nao identificados"
+// @Param X-Sync-Secret header string true "Segredo de administracao"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/emendas/autores [get]
+func (h *Handler) ListAutores(c *gin.Context) {
+	pendentes := c.Query("pendentes") == "true"
+	aliases, err := h.service.ListAliasesAutores(pendentes)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar autores"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"total":   len(aliases),
+		"autores": aliases,
+	})
+}
+
+// AutorAliasRequest e o corpo do cadastro manual de um alias de autor
+type AutorAliasRequest struct {
+	Nome        string `json:"nome" binding:"required"`
+	CodigoAutor string `json:"codigo_autor"`
+	SenadorID   *uint  `json:"senador_id"` // null: autor nao e senador e deve ser ignorado
+}
+
+// SalvarAutor godoc
+// @Summary Define manualmente o senador de um autor de emenda (admin)
+// @Description Aliases manuais tem prioridade sobre a identificacao automatica e valem a partir da proxima sincronizacao. senador_id null faz o autor ser ignorado.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param body body AutorAliasRequest true "Alias"
+// @Param X-Sync-Secret header string true "Segredo de administracao"
+// @Success 200 {object} AutorAlias
+// @Router /api/v1/admin/emendas/autores [put]
+func (h *Handler) SalvarAutor(c *gin.Context) {
+	var req AutorAliasRequest
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Nome) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "nome do autor obrigatorio"})
+		return
+	}
+
+	alias, err := h.service.SalvarAliasManual(req.Nome, req.CodigoAutor, req.SenadorID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "senador nao encontrado"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao salvar autor"})
+		return
+	}
+	c.JSON(http.StatusOK, alias)
+}
+
+// RemoverAutor godoc
+// @Summary Remove um alias de autor de emenda (admin)
+// @Tags admin
+// @Produce json
+// @Param id path int true "ID do alias"
+// @Param X-Sync-Secret header string true "Segredo de administracao"
+// @Success 204
+// @Router /api/v1/admin/emendas/autores/{id} [delete]
+func (h *Handler) RemoverAutor(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id invalido"})
+		return
+	}
+
+	removido, err := h.service.RemoverAlias(uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao remover autor"})
+		return
+	}
+	if !removido {
+		c.JSON(http.StatusNotFound, gin.H{"error": "alias nao encontrado"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -1,6 +1,8 @@
 package emenda
 
 import (
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/Alzarus/to-de-olho/internal/senador"
@@ -12,12 +14,18 @@ type Emenda struct {
 	Senador               *senador.Senador `gorm:"foreignKey:SenadorID" json:"senador,omitempty"`
 	Ano                   int              `gorm:"index;uniqueIndex:idx_emenda_unique,priority:3" json:"ano"`
 	Numero                string           `gorm:"index;uniqueIndex:idx_emenda_unique,priority:1" json:"numero"`
-	Tipo                  string           `json:"tipo"` // Individual, Bancada, Especial, Relator, Comissao
+	Tipo                  string           `json:"tipo"`                   // Individual, Bancada, Especial, Relator, Comissao
+	Categoria             Categoria        `gorm:"index" json:"categoria"` // Tipo classificado (ClassificarTipo)
 	FuncionalProgramatica string           `json:"funcional_programatica"`
-	Localidade            string           `json:"localidade"` // UF ou Município
+	Localidade            string           `json:"localidade"`                                // UF ou Município
+	CodigoIBGE            string           `gorm:"index;size:7" json:"codigo_ibge,omitempty"` // Municipio (7 digitos) ou UF (2)
+	NivelLocalidade       string           `gorm:"index" json:"nivel_localidade,omitempty"`   // municipio.Nivel*; vazio = nao resolvida
 	ValorEmpenhado        float64          `json:"valor_empenhado"`
 	ValorPago             float64          `json:"valor_pago"`
 	DataUltimaAtualizacao time.Time        `json:"data_ultima_atualizacao"`
+
+	// Ultima sincronizacao dos documentos de execucao (nil = nunca sincronizados)
+	DocumentosAtualizadosEm *time.Time `json:"documentos_atualizados_em,omitempty"`
 }
 
 type ResumoEmendas struct {
@@ -31,3 +39,229 @@ type LocalidadeValor struct {
 	Localidade string  `json:"localidade"`
 	Valor      float64 `json:"valor"`
 }
+
+// SenadorMunicipio soma as emendas de um senador destinadas a um municipio
+type SenadorMunicipio struct {
+	SenadorID      uint    `json:"senador_id"`
+	Nome           string  `json:"nome"`
+	Partido        string  `json:"partido"`
+	UF             string  `json:"uf"`
+	ValorEmpenhado float64 `json:"valor_empenhado"`
+	ValorPago      float64 `json:"valor_pago"`
+	Quantidade     int     `json:"quantidade"`
+}
+
+// ResumirPorSenador agrupa as emendas por senador autor, dos maiores valores pagos para
+// os menores, e retorna o resumo geral
+func ResumirPorSenador(emendas []Emenda) ([]SenadorMunicipio, ResumoEmendas) {
+	resumo := ResumoEmendas{TopLocalidades: []LocalidadeValor{}}
+	porSenador := make(map[uint]*SenadorMunicipio)
+	var ordem []uint
+
+	for _, e := range emendas {
+		resumo.TotalEmpenhado += e.ValorEmpenhado
+		resumo.TotalPago += e.ValorPago
+		resumo.Quantidade++
+
+		s, ok := porSenador[e.SenadorID]
+		if !ok {
+			s = &SenadorMunicipio{SenadorID: e.SenadorID}
+			if e.Senador != nil {
+				s.Nome, s.Partido, s.UF = e.Senador.Nome, e.Senador.Partido, e.Senador.UF
+			}
+			porSenador[e.SenadorID] = s
+			ordem = append(ordem, e.SenadorID)
+		}
+		s.ValorEmpenhado += e.ValorEmpenhado
+		s.ValorPago += e.ValorPago
+		s.Quantidade++
+	}
+
+	senadores := make([]SenadorMunicipio, 0, len(ordem))
+	for _, id := range ordem {
+		senadores = append(senadores, *porSenador[id])
+	}
+	sort.SliceStable(senadores, func(i, j int) bool {
+		return senadores[i].ValorPago > senadores[j].ValorPago
+	})
+	return senadores, resumo
+}
+
+// Fases de execucao da despesa
+const (
+	FaseEmpenho    = "Empenho"
+	FaseLiquidacao = "Liquidacao"
+	FasePagamento  = "Pagamento"
+)
+
+// Documento e um documento de execucao (empenho, liquidacao ou pagamento) de uma emenda
+type Documento struct {
+	ID                uint              `gorm:"primaryKey" json:"id"`
+	NumeroEmenda      string            `gorm:"uniqueIndex:idx_documento_emenda,priority:1;not null" json:"numero_emenda"`
+	CodigoDocumento   string            `gorm:"uniqueIndex:idx_documento_emenda,priority:2;not null" json:"codigo_documento"`
+	CodigoResumido    string            `json:"codigo_resumido"`
+	Fase              string            `gorm:"index" json:"fase"` // Empenho, Liquidacao, Pagamento
+	Data              *time.Time        `json:"data,omitempty"`
+	Especie           string            `json:"especie,omitempty"`
+	Valor             float64           `json:"valor"`
+	UnidadeGestora    string            `json:"unidade_gestora,omitempty"`
+	Orgao             string            `json:"orgao,omitempty"`
+	FavorecidoCodigo  string            `gorm:"index" json:"favorecido_codigo"` // CPF/CNPJ do favorecido direto
+	FavorecidoNome    string            `json:"favorecido_nome"`
+	FavorecidoUF      string            `json:"favorecido_uf,omitempty"`
+	Observacao        string            `json:"observacao,omitempty"`
+	FavorecidosFinais []FavorecidoFinal `gorm:"foreignKey:DocumentoID;constraint:OnDelete:CASCADE" json:"favorecidos_finais,omitempty"`
+	CreatedAt         time.Time         `json:"created_at"`
+
+	// Documento tambem vinculado a outras emendas: Valor e o do documento inteiro, nao a
+	// parcela desta emenda. Calculado na consulta (FindDocumentos).
+	Compartilhado bool `gorm:"->;-:migration" json:"compartilhado"`
+}
+
+// TableName define o nome da tabela
+func (Documento) TableName() string {
+	return "emenda_documentos"
+}
+
+// FavorecidoFinal e quem recebeu o recurso ao final de um pagamento
+type FavorecidoFinal struct {
+	ID          uint    `gorm:"primaryKey" json:"id"`
+	DocumentoID uint    `gorm:"index;not null" json:"documento_id"`
+	Codigo      string  `gorm:"index" json:"codigo"` // CPF/CNPJ
+	Nome        string  `json:"nome"`
+	UF          string  `json:"uf,omitempty"`
+	Municipio   string  `json:"municipio,omitempty"`
+	Valor       float64 `json:"valor"`
+}
+
+// TableName define o nome da tabela
+func (FavorecidoFinal) TableName() string {
+	return "emenda_favorecidos_finais"
+}
+
+// Recebedor soma os valores recebidos por um favorecido
+type Recebedor struct {
+	Codigo     string  `json:"codigo"`
+	Nome       string  `json:"nome"`
+	UF         string  `json:"uf,omitempty"`
+	Municipio  string  `json:"municipio,omitempty"`
+	Valor      float64 `json:"valor"`
+	Documentos int     `json:"documentos"`
+}
+
+// Rastro descreve o caminho do dinheiro de uma emenda, do empenho ao recebedor final
+type Rastro struct {
+	TotalEmpenhado    float64     `json:"total_empenhado"`
+	TotalLiquidado    float64     `json:"total_liquidado"`
+	TotalPago         float64     `json:"total_pago"`
+	Empenhos          []Documento `json:"empenhos"`
+	Liquidacoes       []Documento `json:"liquidacoes"`
+	Pagamentos        []Documento `json:"pagamentos"`
+	Favorecidos       []Recebedor `json:"favorecidos"`        // Favorecidos diretos dos pagamentos
+	FavorecidosFinais []Recebedor `json:"favorecidos_finais"` // Quem recebeu ao final
+
+	// Documentos compartilhados com outras emendas: as somas por fase e por favorecido nao
+	// representam esta emenda (ver ConferirComEmenda)
+	DocumentosCompartilhados bool   `json:"documentos_compartilhados"`
+	Aviso                    string `json:"aviso,omitempty"`
+}
+
+// toleranciaRastro absorve diferencas de arredondamento entre documentos e emenda
+const toleranciaRastro = 1.0
+
+// ConferirComEmenda confronta o rastro com os valores empenhado e pago da propria emenda.
+// A API so informa o valor do documento inteiro, que pode cobrir varias emendas; quando
+// algum documento e compartilhado (ou as somas superam os valores da emenda), os totais
+// passam a ser os da emenda e as somas por favorecido deixam de ser apresentadas. Os
+// documentos continuam listados, cada um com o proprio valor.
+func (r *Rastro) ConferirComEmenda(empenhado, pago float64) {
+	compartilhado := (empenhado > 0 && r.TotalEmpenhado > empenhado+toleranciaRastro) ||
+		(pago > 0 && r.TotalPago > pago+toleranciaRastro)
+	for _, fase := range [][]Documento{r.Empenhos, r.Liquidacoes, r.Pagamentos} {
+		for _, d := range fase {
+			compartilhado = compartilhado || d.Compartilhado
+		}
+	}
+	if !compartilhado {
+		return
+	}
+
+	r.DocumentosCompartilhados = true
+	r.TotalEmpenhado = empenhado
+	r.TotalLiquidado = 0
+	r.TotalPago = pago
+	r.Favorecidos = []Recebedor{}
+	r.FavorecidosFinais = []Recebedor{}
+	r.Aviso = "documentos compartilhados com outras emendas: os valores dos documentos sao integrais; " +
+		"totais empenhado e pago sao os da emenda e o liquidado por emenda nao e informado"
+}
+
+// RastrearRecursos separa os documentos por fase e soma os valores por favorecido,
+// do maior para o menor recebedor
+func RastrearRecursos(documentos []Documento) Rastro {
+	rastro := Rastro{
+		Empenhos:    []Documento{},
+		Liquidacoes: []Documento{},
+		Pagamentos:  []Documento{},
+	}
+	diretos := newSomaRecebedores()
+	finais := newSomaRecebedores()
+
+	for _, d := range documentos {
+		switch d.Fase {
+		case FaseEmpenho:
+			rastro.TotalEmpenhado += d.Valor
+			rastro.Empenhos = append(rastro.Empenhos, d)
+		case FaseLiquidacao:
+			rastro.TotalLiquidado += d.Valor
+			rastro.Liquidacoes = append(rastro.Liquidacoes, d)
+		case FasePagamento:
+			rastro.TotalPago += d.Valor
+			rastro.Pagamentos = append(rastro.Pagamentos, d)
+			diretos.somar(Recebedor{Codigo: d.FavorecidoCodigo, Nome: d.FavorecidoNome, UF: d.FavorecidoUF, Valor: d.Valor})
+			for _, f := range d.FavorecidosFinais {
+				finais.somar(Recebedor{Codigo: f.Codigo, Nome: f.Nome, UF: f.UF, Municipio: f.Municipio, Valor: f.Valor})
+			}
+		}
+	}
+
+	rastro.Favorecidos = diretos.ordenados()
+	rastro.FavorecidosFinais = finais.ordenados()
+	return rastro
+}
+
+// somaRecebedores acumula valores por codigo (ou nome, sem codigo) do favorecido
+type somaRecebedores struct {
+	porChave map[string]*Recebedor
+	chaves   []string
+}
+
+func newSomaRecebedores() *somaRecebedores {
+	return &somaRecebedores{porChave: make(map[string]*Recebedor)}
+}
+
+func (s *somaRecebedores) somar(r Recebedor) {
+	chave := r.Codigo
+	if chave == "" {
+		chave = strings.ToUpper(r.Nome)
+	}
+	atual, ok := s.porChave[chave]
+	if !ok {
+		atual = &Recebedor{Codigo: r.Codigo, Nome: r.Nome, UF: r.UF, Municipio: r.Municipio}
+		s.porChave[chave] = atual
+		s.chaves = append(s.chaves, chave)
+	}
+	atual.Valor += r.Valor
+	atual.Documentos++
+}
+
+func (s *somaRecebedores) ordenados() []Recebedor {
+	recebedores := make([]Recebedor, 0, len(s.chaves))
+	for _, chave := range s.chaves {
+		recebedores = append(recebedores, *s.porChave[chave])
+	}
+	sort.SliceStable(recebedores, func(i, j int) bool {
+		return recebedores[i].Valor > recebedores[j].Valor
+	})
+	return recebedores
+}
@@ -0,0 +1,118 @@
+package emenda
+
+import "testing"
+
+func TestRastrearRecursos(t *testing.T) {
+	documentos := []Documento{
+		{CodigoDocumento: "E1", Fase: FaseEmpenho, Valor: 1000},
+		{CodigoDocumento: "L1", Fase: FaseLiquidacao, Valor: 800},
+		{
+			CodigoDocumento: "P1", Fase: FasePagamento, Valor: 500,
+			FavorecidoCodigo: "11111111000111", FavorecidoNome: "MUNICIPIO A",
+			FavorecidosFinais: []FavorecidoFinal{
+				{Codigo: "22222222000122", Nome: "CONSTRUTORA X", Valor: 300},
+				{Codigo: "33333333000133", Nome: "FORNECEDOR Y", Valor: 200},
+			},
+		},
+		{
+			CodigoDocumento: "P2", Fase: FasePagamento, Valor: 300,
+			FavorecidoCodigo: "11111111000111", FavorecidoNome: "MUNICIPIO A",
+			FavorecidosFinais: []FavorecidoFinal{
+				{Codigo: "33333333000133", Nome: "FORNECEDOR Y", Valor: 250},
+			},
+		},
+		{CodigoDocumento: "P3", Fase: FasePagamento, Valor: 100, FavorecidoNome: "Entidade sem CNPJ"},
+	}
+
+	rastro := RastrearRecursos(documentos)
+
+	if rastro.TotalEmpenhado != 1000 || rastro.TotalLiquidado != 800 || rastro.TotalPago != 900 {
+		t.Errorf("totais incorretos: %+v", rastro)
+	}
+	if len(rastro.Empenhos) != 1 || len(rastro.Liquidacoes) != 1 || len(rastro.Pagamentos) != 3 {
+		t.Errorf("documentos por fase incorretos: %d/%d/%d", len(rastro.Empenhos), len(rastro.Liquidacoes), len(rastro.Pagamentos))
+	}
+
+	if len(rastro.Favorecidos) != 2 {
+		t.Fatalf("esperado 2 favorecidos diretos, obtido %+v", rastro.Favorecidos)
+	}
+	if f := rastro.Favorecidos[0]; f.Codigo != "11111111000111" || f.Valor != 800 || f.Documentos != 2 {
+		t.Errorf("favorecido direto principal: %+v", f)
+	}
+
+	if len(rastro.FavorecidosFinais) != 2 {
+		t.Fatalf("esperado 2 favorecidos finais, obtido %+v", rastro.FavorecidosFinais)
+	}
+	if f := rastro.FavorecidosFinais[0]; f.Nome != "FORNECEDOR Y" || f.Valor != 450 || f.Documentos != 2 {
+		t.Errorf("favorecido final principal: %+v", f)
+	}
+}
+
+func TestConferirComEmenda(t *testing.T) {
+	documentos := []Documento{
+		{CodigoDocumento: "E1", Fase: FaseEmpenho, Valor: 1000},
+		{CodigoDocumento: "P1", Fase: FasePagamento, Valor: 500, FavorecidoCodigo: "11111111000111"},
+	}
+
+	casos := []struct {
+		nome            string
+		compartilhado   bool
+		empenhado, pago float64
+		esperaFlag      bool
+	}{
+		{"valores da emenda cobrem os documentos", false, 1000, 500, false},
+		{"emenda sem valores informados", false, 0, 0, false},
+		{"documento vinculado a outra emenda", true, 1000, 500, true},
+		{"soma dos documentos supera a emenda", false, 400, 200, true},
+	}
+	for _, c := range casos {
+		docs := append([]Documento(nil), documentos...)
+		docs[1].Compartilhado = c.compartilhado
+		rastro := RastrearRecursos(docs)
+		rastro.ConferirComEmenda(c.empenhado, c.pago)
+
+		if rastro.DocumentosCompartilhados != c.esperaFlag {
+			t.Errorf("%s: compartilhados = %v, esperado %v", c.nome, rastro.DocumentosCompartilhados, c.esperaFlag)
+			continue
+		}
+		if !c.esperaFlag {
+			if rastro.TotalPago != 500 || len(rastro.Favorecidos) != 1 {
+				t.Errorf("%s: rastro alterado sem compartilhamento: %+v", c.nome, rastro)
+			}
+			continue
+		}
+		if rastro.TotalEmpenhado != c.empenhado || rastro.TotalPago != c.pago || len(rastro.Favorecidos) != 0 ||
+			len(rastro.Pagamentos) != 1 || rastro.Aviso == "" {
+			t.Errorf("%s: rastro compartilhado inesperado: %+v", c.nome, rastro)
+		}
+	}
+}
+
+func TestRastrearRecursosSemDocumentos(t *testing.T) {
+	rastro := RastrearRecursos(nil)
+	if rastro.Empenhos == nil || rastro.Pagamentos == nil || len(rastro.Favorecidos) != 0 {
+		t.Errorf("listas devem ser vazias e nao nulas: %+v", rastro)
+	}
+}
+
+func TestResumirPorSenador(t *testing.T) {
+	emendas := []Emenda{
+		{SenadorID: 1, ValorEmpenhado: 100, ValorPago: 50},
+		{SenadorID: 2, ValorEmpenhado: 300, ValorPago: 200},
+		{SenadorID: 1, ValorEmpenhado: 100, ValorPago: 100},
+	}
+
+	senadores, resumo := ResumirPorSenador(emendas)
+	if resumo.Quantidade != 3 || resumo.TotalEmpenhado != 500 || resumo.TotalPago != 350 {
+		t.Errorf("resumo incorreto: %+v", resumo)
+	}
+	if len(senadores) != 2 {
+		t.Fatalf("esperado 2 senadores, obtido %d", len(senadores))
+	}
+	if s := senadores[0]; s.SenadorID != 2 || s.ValorPago != 200 || s.Quantidade != 1 {
+		t.Errorf("primeiro senador: %+v", s)
+	}
+	if s := senadores[1]; s.SenadorID != 1 || s.ValorEmpenhado != 200 || s.ValorPago != 150 || s.Quantidade != 2 {
+		t.Errorf("segundo senador: %+v", s)
+	}
+}
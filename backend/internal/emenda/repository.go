@@ -1,6 +1,10 @@
 package emenda
 
 import (
+	"time"
+
+	"github.com/Alzarus/to-de-olho/internal/municipio"
+
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -16,14 +20,19 @@ func NewRepository(db *gorm.DB) *Repository {
 func (r *Repository) Upsert(emenda *Emenda) error {
 	return r.db.Clauses(clause.OnConflict{
 		Columns: []clause.Column{{Name: "numero"}, {Name: "senador_id"}, {Name: "ano"}},
-		DoUpdates: clause.AssignmentColumns([]string{
+		DoUpdates: append(clause.AssignmentColumns([]string{
 			"tipo",
+			"categoria",
 			"funcional_programatica",
 			"localidade",
 			"valor_empenhado",
 			"valor_pago",
 			"data_ultima_atualizacao",
-		}),
+		}), clause.Assignments(map[string]interface{}{
+			// A localizacao resolvida so e descartada quando o texto da localidade muda
+			"codigo_ibge":      gorm.Expr("CASE WHEN emendas.localidade IS DISTINCT FROM excluded.localidade THEN '' ELSE emendas.codigo_ibge END"),
+			"nivel_localidade": gorm.Expr("CASE WHEN emendas.localidade IS DISTINCT FROM excluded.localidade THEN '' ELSE emendas.nivel_localidade END"),
+		})...),
 	}).Create(emenda).Error
 }
 
@@ -98,3 +107,306 @@ func (r *Repository) GetResumo(senadorID uint, ano int) (*ResumoEmendas, error)
 
 	return &resumo, nil
 }
+
+// FindNumerosPendentesDocumentos retorna numeros de emendas com valor empenhado cujos
+// documentos nunca foram sincronizados ou estao desatualizados, priorizando os nunca
+// sincronizados e, entre eles, os maiores valores pagos
+func (r *Repository) FindNumerosPendentesDocumentos(limite int, atualizadasAntesDe time.Time) ([]string, error) {
+	var numeros []string
+	err := r.db.Model(&Emenda{}).
+		Select("numero").
+		Where("valor_empenhado > 0").
+		Group("numero").
+		Having("MIN(documentos_atualizados_em) IS NULL OR MIN(documentos_atualizados_em) < ?", atualizadasAntesDe).
+		Order("MIN(documentos_atualizados_em) ASC NULLS FIRST, MAX(valor_pago) DESC").
+		Limit(limite).
+		Pluck("numero", &numeros).Error
+	return numeros, err
+}
+
+// ReplaceDocumentos substitui os documentos (e favorecidos finais) de uma emenda e marca
+// a data de sincronizacao em todas as autorias do mesmo numero
+func (r *Repository) ReplaceDocumentos(numero string, documentos []Documento) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		ids := tx.Model(&Documento{}).Select("id").Where("numero_emenda = ?", numero)
+		if err := tx.Where("documento_id IN (?)", ids).Delete(&FavorecidoFinal{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("numero_emenda = ?", numero).Delete(&Documento{}).Error; err != nil {
+			return err
+		}
+		if len(documentos) > 0 {
+			if err := tx.CreateInBatches(documentos, 100).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&Emenda{}).
+			Where("numero = ?", numero).
+			Update("documentos_atualizados_em", time.Now()).Error
+	})
+}
+
+// FindByNumero retorna as emendas (uma por autor) com o numero informado
+func (r *Repository) FindByNumero(numero string) ([]Emenda, error) {
+	var emendas []Emenda
+	err := r.db.Preload("Senador").
+		Where("numero = ?", numero).
+		Order("ano DESC, senador_id").
+		Find(&emendas).Error
+	return emendas, err
+}
+
+// FindDocumentos retorna os documentos de uma emenda com seus favorecidos finais,
+// em ordem cronologica, marcando os que tambem aparecem em outras emendas
+func (r *Repository) FindDocumentos(numero string) ([]Documento, error) {
+	var documentos []Documento
+	err := r.db.Preload("FavorecidosFinais", func(db *gorm.DB) *gorm.DB {
+		return db.Order("valor DESC")
+	}).
+		Select(`emenda_documentos.*, EXISTS (
+			SELECT 1 FROM emenda_documentos o
+			WHERE o.codigo_documento = emenda_documentos.codigo_documento
+				AND o.numero_emenda <> emenda_documentos.numero_emenda
+		) as compartilhado`).
+		Where("numero_emenda = ?", numero).
+		Order("data ASC NULLS LAST, codigo_documento").
+		Find(&documentos).Error
+	return documentos, err
+}
+
+// ClassificarCategorias preenche a categoria das emendas gravadas sem classificacao,
+// aplicando ClassificarTipo a cada tipo distinto
+func (r *Repository) ClassificarCategorias() (int64, error) {
+	var tipos []string
+	err := r.db.Model(&Emenda{}).
+		Where("categoria = '' OR categoria IS NULL").
+		Distinct().
+		Pluck("tipo", &tipos).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, tipo := range tipos {
+		categoria := ClassificarTipo(tipo)
+		if categoria == "" {
+			continue
+		}
+		res := r.db.Model(&Emenda{}).
+			Where("tipo = ? AND (categoria = '' OR categoria IS NULL)", tipo).
+			Update("categoria", string(categoria))
+		if res.Error != nil {
+			return total, res.Error
+		}
+		total += res.RowsAffected
+	}
+	return total, nil
+}
+
+// FindNumerosPorAno retorna os numeros distintos das emendas de um ano
+func (r *Repository) FindNumerosPorAno(ano int) ([]string, error) {
+	var numeros []string
+	err := r.db.Model(&Emenda{}).
+		Where("ano = ?", ano).
+		Distinct().
+		Pluck("numero", &numeros).Error
+	return numeros, err
+}
+
+// UpsertTransferencias grava os planos de acao de transferencias especiais
+func (r *Repository) UpsertTransferencias(transferencias []TransferenciaEspecial) error {
+	if len(transferencias) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "id_plano_acao"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"codigo_plano_acao",
+			"numero_emenda",
+			"ano_emenda",
+			"situacao",
+			"beneficiario_cnpj",
+			"beneficiario_nome",
+			"esfera",
+			"municipio",
+			"uf",
+			"area_politica",
+			"programacao",
+			"valor_custeio",
+			"valor_investimento",
+			"updated_at",
+		}),
+	}).CreateInBatches(transferencias, 100).Error
+}
+
+// FiltroPix restringe as consultas de transferencias especiais
+type FiltroPix struct {
+	Ano              int    // Ano da emenda (0 = todos)
+	UF               string // UF do ente beneficiario
+	Esfera           string // Municipal ou Estadual
+	BeneficiarioCNPJ string
+	SenadorID        uint // Apenas emendas do senador
+}
+
+// FindTransferencias retorna os planos de acao que atendem ao filtro, dos maiores valores
+// para os menores
+func (r *Repository) FindTransferencias(filtro FiltroPix) ([]TransferenciaEspecial, error) {
+	query := r.db.Model(&TransferenciaEspecial{})
+	if filtro.Ano > 0 {
+		query = query.Where("ano_emenda = ?", filtro.Ano)
+	}
+	if filtro.UF != "" {
+		query = query.Where("uf = ?", filtro.UF)
+	}
+	if filtro.Esfera != "" {
+		query = query.Where("esfera = ?", filtro.Esfera)
+	}
+	if filtro.BeneficiarioCNPJ != "" {
+		query = query.Where("beneficiario_cnpj = ?", filtro.BeneficiarioCNPJ)
+	}
+	if filtro.SenadorID > 0 {
+		query = query.Where("numero_emenda IN (?)",
+			r.db.Model(&Emenda{}).Select("numero").Where("senador_id = ?", filtro.SenadorID))
+	}
+
+	var transferencias []TransferenciaEspecial
+	err := query.Order("valor_custeio + valor_investimento DESC").Find(&transferencias).Error
+	return transferencias, err
+}
+
+// FindAutoresPorNumero indexa os senadores autores de cada numero de emenda
+func (r *Repository) FindAutoresPorNumero(numeros []string) (map[string][]uint, error) {
+	autores := make(map[string][]uint)
+	if len(numeros) == 0 {
+		return autores, nil
+	}
+
+	var linhas []struct {
+		Numero    string
+		SenadorID uint
+	}
+	err := r.db.Model(&Emenda{}).
+		Distinct("numero", "senador_id").
+		Where("numero IN ?", numeros).
+		Scan(&linhas).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range linhas {
+		autores[l.Numero] = append(autores[l.Numero], l.SenadorID)
+	}
+	return autores, nil
+}
+
+// ListParticipacaoPix soma, por senador, o total empenhado em emendas e a parte em
+// transferencias especiais (ano 0 = todos os anos)
+func (r *Repository) ListParticipacaoPix(ano int) ([]ParticipacaoPix, error) {
+	query := r.db.Model(&Emenda{}).
+		Select(`senador_id,
+			COALESCE(SUM(valor_empenhado), 0) AS total_empenhado,
+			COALESCE(SUM(CASE WHEN categoria = @pix THEN valor_empenhado END), 0) AS pix_empenhado,
+			COALESCE(SUM(CASE WHEN categoria = @pix THEN valor_pago END), 0) AS pix_pago,
+			COUNT(CASE WHEN categoria = @pix THEN 1 END) AS quantidade_pix`,
+			map[string]interface{}{"pix": string(CategoriaTransferenciaEspecial)})
+	if ano > 0 {
+		query = query.Where("ano = ?", ano)
+	}
+
+	var participacoes []ParticipacaoPix
+	err := query.Group("senador_id").Scan(&participacoes).Error
+	return participacoes, err
+}
+
+// FindLocalidadesPendentes retorna as localidades distintas ainda nao resolvidas ou que
+// nao foram identificadas na ultima tentativa
+func (r *Repository) FindLocalidadesPendentes() ([]string, error) {
+	var localidades []string
+	err := r.db.Model(&Emenda{}).
+		Where("nivel_localidade IS NULL OR nivel_localidade IN ?", []string{"", municipio.NivelNaoIdentificado}).
+		Distinct().
+		Pluck("localidade", &localidades).Error
+	return localidades, err
+}
+
+// AtualizarLocalizacao grava o codigo IBGE resolvido em todas as emendas da localidade
+func (r *Repository) AtualizarLocalizacao(localidade string, loc municipio.Localizacao) (int64, error) {
+	res := r.db.Model(&Emenda{}).
+		Where("localidade = ?", localidade).
+		Updates(map[string]interface{}{
+			"codigo_ibge":      loc.CodigoIBGE,
+			"nivel_localidade": loc.Nivel,
+		})
+	return res.RowsAffected, res.Error
+}
+
+// ListByCodigoIBGE retorna as emendas destinadas a um municipio, com o senador autor
+func (r *Repository) ListByCodigoIBGE(codigo string, ano int) ([]Emenda, error) {
+	var emendas []Emenda
+	query := r.db.Preload("Senador").Where("codigo_ibge = ?", codigo)
+	if ano > 0 {
+		query = query.Where("ano = ?", ano)
+	}
+	err := query.Order("valor_pago DESC").Find(&emendas).Error
+	return emendas, err
+}
+
+// FindAliases lista os aliases de autores, opcionalmente de uma origem
+func (r *Repository) FindAliases(origem string) ([]AutorAlias, error) {
+	var aliases []AutorAlias
+	query := r.db.Model(&AutorAlias{})
+	if origem != "" {
+		query = query.Where("origem = ?", origem)
+	}
+	err := query.Order("ocorrencias DESC, nome").Find(&aliases).Error
+	return aliases, err
+}
+
+// SalvarAliasAutomatico grava o senador identificado para o nome. Aliases manuais
+// nunca sao sobrescritos.
+func (r *Repository) SalvarAliasAutomatico(nome, codigoAutor string, senadorID uint) error {
+	alias := AutorAlias{Nome: nome, CodigoAutor: codigoAutor, SenadorID: &senadorID, Origem: AliasAutomatico}
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "nome"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"codigo_autor": gorm.Expr("COALESCE(NULLIF(excluded.codigo_autor, ''), emenda_autores.codigo_autor)"),
+			"senador_id":   senadorID,
+			"origem":       AliasAutomatico,
+			"updated_at":   time.Now(),
+		}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Neq{Column: clause.Column{Table: "emenda_autores", Name: "origem"}, Value: AliasManual},
+		}},
+	}).Create(&alias).Error
+}
+
+// RegistrarAutorPendente registra (ou conta mais uma ocorrencia de) um autor nao
+// identificado para revisao
+func (r *Repository) RegistrarAutorPendente(nome, codigoAutor string) error {
+	alias := AutorAlias{Nome: nome, CodigoAutor: codigoAutor, Origem: AliasPendente, Ocorrencias: 1}
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "nome"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"codigo_autor": gorm.Expr("COALESCE(NULLIF(excluded.codigo_autor, ''), emenda_autores.codigo_autor)"),
+			"ocorrencias":  gorm.Expr("emenda_autores.ocorrencias + 1"),
+			"updated_at":   time.Now(),
+		}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Eq{Column: clause.Column{Table: "emenda_autores", Name: "origem"}, Value: AliasPendente},
+		}},
+	}).Create(&alias).Error
+}
+
+// SalvarAliasManual cria ou substitui o alias de um nome com origem manual
+func (r *Repository) SalvarAliasManual(alias *AutorAlias) error {
+	alias.Origem = AliasManual
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "nome"}},
+		DoUpdates: clause.AssignmentColumns([]string{"codigo_autor", "senador_id", "origem", "updated_at"}),
+	}).Create(alias).Error
+}
+
+// DeleteAlias remove um alias. Retorna false se nao existir.
+func (r *Repository) DeleteAlias(id uint) (bool, error) {
+	res := r.db.Delete(&AutorAlias{}, id)
+	return res.RowsAffected > 0, res.Error
+}
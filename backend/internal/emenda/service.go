@@ -7,23 +7,34 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"math"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/Alzarus/to-de-olho/internal/municipio"
 	"github.com/Alzarus/to-de-olho/internal/senador"
+	"github.com/Alzarus/to-de-olho/internal/utils"
+	"gorm.io/gorm"
 )
 
 type Service struct {
-	repo        *Repository
-	senadorRepo *senador.Repository
+	repo          *Repository
+	senadorRepo   *senador.Repository
+	municipioRepo *municipio.Repository
 }
 
 func NewService(repo *Repository, senadorRepo *senador.Repository) *Service {
 	return &Service{repo: repo, senadorRepo: senadorRepo}
 }
 
+// SetMunicipioRepository habilita as consultas por municipio
+func (s *Service) SetMunicipioRepository(repo *municipio.Repository) {
+	s.municipioRepo = repo
+}
+
 func (s *Service) ListBySenador(senadorID uint, ano int) ([]Emenda, error) {
 	return s.repo.ListBySenador(senadorID, ano)
 }
@@ -32,6 +43,193 @@ func (s *Service) GetResumo(senadorID uint, ano int) (*ResumoEmendas, error) {
 	return s.repo.GetResumo(senadorID, ano)
 }
 
+func (s *Service) GetByNumero(numero string) ([]Emenda, error) {
+	return s.repo.FindByNumero(numero)
+}
+
+// GetRastro monta o caminho do dinheiro de uma emenda a partir dos documentos sincronizados,
+// conferido com os valores das autorias da emenda
+func (s *Service) GetRastro(numero string, autorias []Emenda) (*Rastro, error) {
+	documentos, err := s.repo.FindDocumentos(numero)
+	if err != nil {
+		return nil, err
+	}
+	rastro := RastrearRecursos(documentos)
+
+	// Autorias do mesmo numero repetem os valores da emenda
+	var empenhado, pago float64
+	for _, e := range autorias {
+		empenhado = math.Max(empenhado, e.ValorEmpenhado)
+		pago = math.Max(pago, e.ValorPago)
+	}
+	rastro.ConferirComEmenda(empenhado, pago)
+	return &rastro, nil
+}
+
+// GetMunicipio busca o municipio na tabela de referencia do IBGE
+func (s *Service) GetMunicipio(codigo string) (*municipio.Municipio, error) {
+	if s.municipioRepo == nil {
+		return nil, errors.New("tabela de municipios nao configurada")
+	}
+	return s.municipioRepo.FindByCodigo(codigo)
+}
+
+// ListByMunicipio retorna as emendas destinadas a um municipio (codigo IBGE)
+func (s *Service) ListByMunicipio(codigo string, ano int) ([]Emenda, error) {
+	return s.repo.ListByCodigoIBGE(codigo, ano)
+}
+
+// GetDistribuicao analisa para onde vai o dinheiro das emendas de um senador: dentro ou
+// fora da sua UF, concentracao entre municipios e valores per capita
+func (s *Service) GetDistribuicao(senadorID uint, ano int) (*Distribuicao, error) {
+	if s.municipioRepo == nil {
+		return nil, errors.New("tabela de municipios nao configurada")
+	}
+	sen, err := s.senadorRepo.FindByID(int(senadorID))
+	if err != nil {
+		return nil, err
+	}
+	emendas, err := s.repo.ListBySenador(senadorID, ano)
+	if err != nil {
+		return nil, err
+	}
+
+	var codigoUF string
+	if uf, err := s.municipioRepo.FindUFBySigla(sen.UF); err == nil {
+		codigoUF = uf.Codigo
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	var codigos []string
+	for _, e := range emendas {
+		if e.NivelLocalidade == municipio.NivelMunicipio {
+			codigos = append(codigos, e.CodigoIBGE)
+		}
+	}
+	municipios, err := s.municipioRepo.FindByCodigos(codigos)
+	if err != nil {
+		return nil, err
+	}
+
+	anoPopulacao, err := s.municipioRepo.AnoPopulacaoReferencia(ano)
+	if err != nil {
+		return nil, err
+	}
+	populacao := map[string]int64{}
+	var populacaoUF int64
+	if anoPopulacao > 0 {
+		if populacao, err = s.municipioRepo.FindPopulacao(codigos, anoPopulacao); err != nil {
+			return nil, err
+		}
+		if codigoUF != "" {
+			if populacaoUF, err = s.municipioRepo.SomaPopulacaoUF(codigoUF, anoPopulacao); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	d := CalcularDistribuicao(emendas, codigoUF, municipios, populacao, populacaoUF)
+	d.SenadorID = senadorID
+	d.UF = sen.UF
+	d.Ano = ano
+	d.AnoPopulacao = anoPopulacao
+	return &d, nil
+}
+
+// GetSenador busca o senador pelo ID (gorm.ErrRecordNotFound se nao existir)
+func (s *Service) GetSenador(senadorID uint) (*senador.Senador, error) {
+	return s.senadorRepo.FindByID(int(senadorID))
+}
+
+// GetParticipacaoPix compara a participacao de transferencias especiais nas emendas de
+// cada senador, marcando os destaques. Retorna a lista (maiores participacoes primeiro)
+// e a mediana dos pares.
+func (s *Service) GetParticipacaoPix(ano int) ([]ParticipacaoPix, float64, error) {
+	participacoes, err := s.repo.ListParticipacaoPix(ano)
+	if err != nil {
+		return nil, 0, err
+	}
+	med := CalcularDestaquesPix(participacoes)
+
+	senadores, err := s.senadorRepo.FindAll(true)
+	if err != nil {
+		return nil, 0, err
+	}
+	porID := make(map[uint]senador.Senador, len(senadores))
+	for _, sen := range senadores {
+		porID[uint(sen.ID)] = sen
+	}
+	for i := range participacoes {
+		p := &participacoes[i]
+		if sen, ok := porID[p.SenadorID]; ok {
+			p.Nome, p.Partido, p.UF = sen.Nome, sen.Partido, sen.UF
+		}
+	}
+
+	sort.SliceStable(participacoes, func(i, j int) bool {
+		return participacoes[i].Participacao > participacoes[j].Participacao
+	})
+	return participacoes, med, nil
+}
+
+// ListBeneficiariosPix agrega as transferencias especiais por ente beneficiario
+func (s *Service) ListBeneficiariosPix(filtro FiltroPix) ([]BeneficiarioPix, error) {
+	transferencias, err := s.repo.FindTransferencias(filtro)
+	if err != nil {
+		return nil, err
+	}
+	autores, err := s.repo.FindAutoresPorNumero(numerosTransferencias(transferencias))
+	if err != nil {
+		return nil, err
+	}
+	return AgruparBeneficiarios(transferencias, autores), nil
+}
+
+// GetTransferenciasComAutores retorna os planos de acao do filtro com os senadores autores
+func (s *Service) GetTransferenciasComAutores(filtro FiltroPix) ([]TransferenciaAutoria, error) {
+	transferencias, err := s.repo.FindTransferencias(filtro)
+	if err != nil {
+		return nil, err
+	}
+	autores, err := s.repo.FindAutoresPorNumero(numerosTransferencias(transferencias))
+	if err != nil {
+		return nil, err
+	}
+	senadores, err := s.senadorRepo.FindAll(true)
+	if err != nil {
+		return nil, err
+	}
+	porID := make(map[uint]senador.Senador, len(senadores))
+	for _, sen := range senadores {
+		porID[uint(sen.ID)] = sen
+	}
+
+	resultado := make([]TransferenciaAutoria, 0, len(transferencias))
+	for _, t := range transferencias {
+		item := TransferenciaAutoria{TransferenciaEspecial: t, Autores: []AutorPix{}}
+		for _, id := range autores[t.NumeroEmenda] {
+			sen := porID[id]
+			item.Autores = append(item.Autores, AutorPix{SenadorID: id, Nome: sen.Nome, Partido: sen.Partido, UF: sen.UF})
+		}
+		resultado = append(resultado, item)
+	}
+	return resultado, nil
+}
+
+// numerosTransferencias lista os numeros de emenda distintos dos planos de acao
+func numerosTransferencias(transferencias []TransferenciaEspecial) []string {
+	vistos := make(map[string]bool)
+	var numeros []string
+	for _, t := range transferencias {
+		if !vistos[t.NumeroEmenda] {
+			vistos[t.NumeroEmenda] = true
+			numeros = append(numeros, t.NumeroEmenda)
+		}
+	}
+	return numeros
+}
+
 func (s *Service) ImportarCSV(caminho string) error {
 	slog.Info("iniciando importacao de emendas", "arquivo", caminho)
 
@@ -71,19 +269,9 @@ func (s *Service) ImportarCSV(caminho string) error {
 		indices[normalizarChave(col)] = i
 	}
 
-	senadores, err := s.senadorRepo.FindAll(false)
+	autores, err := NewIdentificadorAutores(s.repo, s.senadorRepo)
 	if err != nil {
-		return fmt.Errorf("falha ao carregar senadores: %w", err)
-	}
-
-	mapaSenadores := map[string]uint{}
-	for _, sen := range senadores {
-		if sen.Nome != "" {
-			mapaSenadores[normalizarChave(sen.Nome)] = uint(sen.ID)
-		}
-		if sen.NomeCompleto != "" {
-			mapaSenadores[normalizarChave(sen.NomeCompleto)] = uint(sen.ID)
-		}
+		return fmt.Errorf("falha ao carregar autores: %w", err)
 	}
 
 	importadas := 0
@@ -98,17 +286,6 @@ func (s *Service) ImportarCSV(caminho string) error {
 			return fmt.Errorf("erro ao ler CSV: %w", err)
 		}
 
-		nomeAutor := buscarValor(linha, indices, "nomeautor", "autor", "nome autor")
-		if nomeAutor == "" {
-			ignoradas++
-			continue
-		}
-		senadorID, ok := mapaSenadores[normalizarChave(nomeAutor)]
-		if !ok {
-			ignoradas++
-			continue
-		}
-
 		ano := parseAno(buscarValor(linha, indices, "ano"))
 		if ano == 0 {
 			ignoradas++
@@ -126,15 +303,33 @@ func (s *Service) ImportarCSV(caminho string) error {
 			continue
 		}
 
+		// Basta o nome ou o codigo do autor; o codigo tambem vem embutido no codigo da emenda
+		nomeAutor := buscarValor(linha, indices, "nome do autor da emenda", "nomeautor", "autor", "nome autor")
+		codigoAutor := utils.ApenasDigitos(buscarValor(linha, indices, "codigo do autor da emenda", "codigoautor", "codigo autor"))
+		if codigoAutor == "" {
+			codigoAutor = codigoAutorDaEmenda(numero)
+		}
+		if nomeAutor == "" && codigoAutor == "" {
+			ignoradas++
+			continue
+		}
+		senadorID, ok := autores.Identificar(codigoAutor, nomeAutor)
+		if !ok {
+			ignoradas++
+			continue
+		}
+
 		funcao := buscarValor(linha, indices, "funcao", "fun\u00e7\u00e3o")
 		subfuncao := buscarValor(linha, indices, "subfuncao", "subfun\u00e7\u00e3o")
 		funcional := strings.TrimSpace(strings.Trim(strings.Join([]string{funcao, subfuncao}, " - "), " - "))
 
+		tipo := buscarValor(linha, indices, "tipoemenda", "tipo emenda", "tipo")
 		emenda := Emenda{
 			SenadorID:             senadorID,
 			Ano:                   ano,
 			Numero:                numero,
-			Tipo:                  buscarValor(linha, indices, "tipoemenda", "tipo emenda", "tipo"),
+			Tipo:                  tipo,
+			Categoria:             ClassificarTipo(tipo),
 			FuncionalProgramatica: funcional,
 			Localidade:            buscarValor(linha, indices, "localidadedogasto", "localidade do gasto", "localidade"),
 			ValorEmpenhado:        parseMoeda(buscarValor(linha, indices, "valorempenhado", "valor empenhado")),
@@ -151,6 +346,10 @@ func (s *Service) ImportarCSV(caminho string) error {
 	}
 
 	slog.Info("importacao de emendas concluida", "importadas", importadas, "ignoradas", ignoradas)
+
+	if _, err := resolverLocalidades(s.repo, s.municipioRepo); err != nil {
+		slog.Warn("falha ao resolver localidades das emendas", "erro", err)
+	}
 	return nil
 }
 
@@ -163,24 +362,12 @@ func detectarDelimitador(linha string) rune {
 
 func normalizarChave(valor string) string {
 	valor = strings.TrimSpace(strings.ToLower(valor))
-	valor = substituirAcentos(valor)
+	valor = utils.RemoverAcentos(valor)
 	replacer := strings.NewReplacer(" ", "", "_", "", "-", "", ".", "", "\t", "")
 	valor = replacer.Replace(valor)
 	return valor
 }
 
-func substituirAcentos(texto string) string {
-	replacer := strings.NewReplacer(
-		"\u00e1", "a", "\u00e0", "a", "\u00e2", "a", "\u00e3", "a", "\u00e4", "a",
-		"\u00e9", "e", "\u00e8", "e", "\u00ea", "e", "\u00eb", "e",
-		"\u00ed", "i", "\u00ec", "i", "\u00ee", "i", "\u00ef", "i",
-		"\u00f3", "o", "\u00f2", "o", "\u00f4", "o", "\u00f5", "o", "\u00f6", "o",
-		"\u00fa", "u", "\u00f9", "u", "\u00fb", "u", "\u00fc", "u",
-		"\u00e7", "c",
-	)
-	return replacer.Replace(texto)
-}
-
 func buscarValor(linha []string, indices map[string]int, chaves ...string) string {
 	for _, chave := range chaves {
 		if idx, ok := indices[normalizarChave(chave)]; ok {
@@ -215,3 +402,35 @@ func parseMoeda(valor string) float64 {
 	}
 	return parsed
 }
+
+// ListAliasesAutores lista os aliases de autores; com pendentes, apenas os nao identificados
+func (s *Service) ListAliasesAutores(pendentes bool) ([]AutorAlias, error) {
+	if pendentes {
+		return s.repo.FindAliases(AliasPendente)
+	}
+	return s.repo.FindAliases("")
+}
+
+// SalvarAliasManual associa um nome de autor (e o codigo, se informado) a um senador.
+// senadorID nil marca o autor como nao senador, para ser ignorado nas sincronizacoes.
+func (s *Service) SalvarAliasManual(nome, codigoAutor string, senadorID *uint) (*AutorAlias, error) {
+	nome = normalizarNomeAutor(nome)
+	if nome == "" {
+		return nil, errors.New("nome do autor vazio")
+	}
+	if senadorID != nil {
+		if _, err := s.senadorRepo.FindByID(int(*senadorID)); err != nil {
+			return nil, err
+		}
+	}
+	alias := &AutorAlias{Nome: nome, CodigoAutor: utils.ApenasDigitos(codigoAutor), SenadorID: senadorID}
+	if err := s.repo.SalvarAliasManual(alias); err != nil {
+		return nil, err
+	}
+	return alias, nil
+}
+
+// RemoverAlias apaga um alias. Retorna false se nao existir.
+func (s *Service) RemoverAlias(id uint) (bool, error) {
+	return s.repo.DeleteAlias(id)
+}
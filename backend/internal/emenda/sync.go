@@ -7,7 +7,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Alzarus/to-de-olho/internal/municipio"
 	"github.com/Alzarus/to-de-olho/internal/senador"
+	"github.com/Alzarus/to-de-olho/internal/utils"
+	"github.com/Alzarus/to-de-olho/pkg/retry"
+	"github.com/Alzarus/to-de-olho/pkg/transferegov"
 	"github.com/Alzarus/to-de-olho/pkg/transparencia"
 )
 
@@ -15,6 +19,8 @@ type SyncService struct {
 	repo          *Repository
 	senadorRepo   *senador.Repository
 	transparencia *transparencia.Client
+	transferegov  *transferegov.Client
+	municipioRepo *municipio.Repository // Opcional: resolve localidades em codigos IBGE
 }
 
 func NewSyncService(repo *Repository, senadorRepo *senador.Repository, apiKey string) *SyncService {
@@ -22,20 +28,30 @@ func NewSyncService(repo *Repository, senadorRepo *senador.Repository, apiKey st
 		repo:          repo,
 		senadorRepo:   senadorRepo,
 		transparencia: transparencia.NewClient(apiKey),
+		transferegov:  transferegov.NewClient(),
 	}
 }
 
+// SetMunicipioRepository habilita a resolucao das localidades em codigos IBGE
+func (s *SyncService) SetMunicipioRepository(repo *municipio.Repository) {
+	s.municipioRepo = repo
+}
+
 func (s *SyncService) SyncAll(ctx context.Context, ano int) error {
 	senadores, err := s.senadorRepo.FindAll(false)
 	if err != nil {
 		return err
 	}
+	autores, err := NewIdentificadorAutores(s.repo, s.senadorRepo)
+	if err != nil {
+		return err
+	}
 	slog.Info("SyncAll Emendas: iniciando", "senadores", len(senadores), "ano", ano)
 
 	sucessos := 0
 	falhas := 0
 	for _, sen := range senadores {
-		if err := s.SyncSenador(ctx, sen, ano); err != nil {
+		if err := s.syncSenador(ctx, sen, ano, autores); err != nil {
 			slog.Warn("falha sync emendas para senador",
 				"senador", sen.Nome,
 				"ano", ano,
@@ -54,13 +70,69 @@ func (s *SyncService) SyncAll(ctx context.Context, ano int) error {
 		"sucessos", sucessos,
 		"falhas", falhas,
 	)
+
+	if _, err := s.ResolverLocalidades(); err != nil {
+		slog.Warn("falha ao resolver localidades das emendas", "erro", err)
+	}
 	return nil
 }
 
+// ResolverLocalidades converte o texto de localidade das emendas pendentes em codigo IBGE.
+// Sem o repositorio de municipios configurado, nao faz nada.
+func (s *SyncService) ResolverLocalidades() (int64, error) {
+	return resolverLocalidades(s.repo, s.municipioRepo)
+}
+
+// resolverLocalidades resolve as localidades pendentes com a referencia de municipios
+func resolverLocalidades(repo *Repository, municipioRepo *municipio.Repository) (int64, error) {
+	if municipioRepo == nil {
+		return 0, nil
+	}
+	resolver, err := municipioRepo.NewResolver()
+	if err != nil {
+		return 0, err
+	}
+	localidades, err := repo.FindLocalidadesPendentes()
+	if err != nil {
+		return 0, err
+	}
+
+	var total, naoIdentificadas int64
+	for _, localidade := range localidades {
+		loc := resolver.Localizar(localidade)
+		atualizadas, err := repo.AtualizarLocalizacao(localidade, loc)
+		if err != nil {
+			return total, err
+		}
+		total += atualizadas
+		if loc.Nivel == municipio.NivelNaoIdentificado {
+			naoIdentificadas += atualizadas
+		}
+	}
+
+	slog.Info("localidades de emendas resolvidas",
+		"localidades", len(localidades),
+		"emendas", total,
+		"nao_identificadas", naoIdentificadas,
+	)
+	return total, nil
+}
+
+// SyncSenador importa as emendas encontradas pelos nomes do senador. A busca da API e por
+// trecho do nome, entao o autor de cada emenda e confirmado pelo IdentificadorAutores.
 func (s *SyncService) SyncSenador(ctx context.Context, sen senador.Senador, ano int) error {
+	autores, err := NewIdentificadorAutores(s.repo, s.senadorRepo)
+	if err != nil {
+		return err
+	}
+	return s.syncSenador(ctx, sen, ano, autores)
+}
+
+func (s *SyncService) syncSenador(ctx context.Context, sen senador.Senador, ano int, autores *IdentificadorAutores) error {
 	totalImportado := 0
+	ignoradas := 0
 
-	nomesBusca := montarNomesBusca(sen)
+	nomesBusca := autores.NomesBusca(sen)
 	for _, nomeBusca := range nomesBusca {
 		pagina := 1
 		for {
@@ -94,14 +166,25 @@ func (s *SyncService) SyncSenador(ctx context.Context, sen senador.Senador, ano
 			}
 
 			for _, dto := range emendasDTO {
+				nomeAutor := dto.NomeAutor
+				if nomeAutor == "" {
+					nomeAutor = dto.Autor
+				}
+				senadorID, ok := autores.Identificar(codigoAutorDaEmenda(dto.CodigoEmenda), nomeAutor)
+				if !ok {
+					ignoradas++
+					continue
+				}
+
 				valorEmp := transparencia.ParseMoney(dto.ValorEmpenhado)
 				valorPago := transparencia.ParseMoney(dto.ValorPago) + transparencia.ParseMoney(dto.ValorRestoPago)
 
 				emenda := Emenda{
-					SenadorID:             uint(sen.ID),
+					SenadorID:             senadorID,
 					Ano:                   dto.Ano,
 					Numero:                dto.CodigoEmenda,
 					Tipo:                  dto.TipoEmenda,
+					Categoria:             ClassificarTipo(dto.TipoEmenda),
 					FuncionalProgramatica: fmt.Sprintf("%s - %s", dto.Funcao, dto.Subfuncao),
 					Localidade:            dto.LocalidadeDoGasto,
 					ValorEmpenhado:        valorEmp,
@@ -127,8 +210,8 @@ func (s *SyncService) SyncSenador(ctx context.Context, sen senador.Senador, ano
 		}
 	}
 
-	if totalImportado > 0 {
-		slog.Info("emendas importadas", "senador", sen.Nome, "ano", ano, "total", totalImportado)
+	if totalImportado > 0 || ignoradas > 0 {
+		slog.Info("emendas importadas", "senador", sen.Nome, "ano", ano, "total", totalImportado, "ignoradas", ignoradas)
 	}
 
 	return nil
@@ -151,15 +234,248 @@ func montarNomesBusca(sen senador.Senador) []string {
 }
 
 func normalizarNomeAutor(nome string) string {
-	nome = strings.TrimSpace(nome)
-	nome = strings.ToUpper(nome)
-	replacer := strings.NewReplacer(
-		"Á", "A", "À", "A", "Â", "A", "Ã", "A", "Ä", "A",
-		"É", "E", "È", "E", "Ê", "E", "Ë", "E",
-		"Í", "I", "Ì", "I", "Î", "I", "Ï", "I",
-		"Ó", "O", "Ò", "O", "Ô", "O", "Õ", "O", "Ö", "O",
-		"Ú", "U", "Ù", "U", "Û", "U", "Ü", "U",
-		"Ç", "C",
-	)
-	return replacer.Replace(nome)
+	return strings.ToUpper(utils.RemoverAcentos(strings.TrimSpace(nome)))
+}
+
+// SyncDocumentos busca os documentos de execucao (empenhos, liquidacoes e pagamentos) das
+// emendas pendentes, com o favorecido de cada documento e os favorecidos finais dos
+// pagamentos. Emendas sincronizadas ha menos de 7 dias sao ignoradas.
+func (s *SyncService) SyncDocumentos(ctx context.Context, limite int) error {
+	numeros, err := s.repo.FindNumerosPendentesDocumentos(limite, time.Now().AddDate(0, 0, -7))
+	if err != nil {
+		return err
+	}
+	slog.Info("iniciando sync de documentos de emendas", "pendentes", len(numeros))
+
+	var atualizadas, totalDocumentos int
+	for _, numero := range numeros {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		documentos, err := s.buscarDocumentos(ctx, numero)
+		if err != nil {
+			slog.Warn("falha ao buscar documentos da emenda", "numero", numero, "erro", err)
+			continue
+		}
+		if err := s.repo.ReplaceDocumentos(numero, documentos); err != nil {
+			slog.Warn("falha ao salvar documentos da emenda", "numero", numero, "erro", err)
+			continue
+		}
+		atualizadas++
+		totalDocumentos += len(documentos)
+	}
+
+	slog.Info("sync de documentos de emendas concluido", "emendas", atualizadas, "documentos", totalDocumentos)
+	return nil
+}
+
+// Limites de paginas por emenda e por pagamento. Atingir o limite interrompe a emenda
+// em vez de gravar uma execucao truncada.
+const (
+	maxPaginasDocumentos  = 50
+	maxPaginasFavorecidos = 20
+)
+
+// buscarDocumentos monta os documentos de uma emenda. Qualquer falha interrompe a emenda
+// para que ela nao seja marcada como sincronizada com dados incompletos.
+func (s *SyncService) buscarDocumentos(ctx context.Context, numero string) ([]Documento, error) {
+	var documentos []Documento
+	vistos := make(map[string]bool)
+
+	for pagina := 1; ; pagina++ {
+		if pagina > maxPaginasDocumentos {
+			return nil, fmt.Errorf("mais de %d paginas de documentos", maxPaginasDocumentos)
+		}
+		lista, err := s.transparencia.GetDocumentosEmenda(ctx, numero, pagina)
+		if err != nil {
+			return nil, err
+		}
+		if len(lista) == 0 {
+			break
+		}
+
+		for _, dto := range lista {
+			if dto.CodigoDocumento == "" || vistos[dto.CodigoDocumento] {
+				continue
+			}
+			vistos[dto.CodigoDocumento] = true
+
+			detalhe, err := s.transparencia.GetDocumentoDespesa(ctx, dto.CodigoDocumento)
+			if err != nil {
+				return nil, fmt.Errorf("documento %s: %w", dto.CodigoDocumento, err)
+			}
+			documento := convertDocumento(numero, dto, *detalhe)
+
+			if documento.Fase == FasePagamento {
+				finais, err := s.buscarFavorecidosFinais(ctx, dto.CodigoDocumento)
+				if err != nil {
+					return nil, fmt.Errorf("favorecidos finais %s: %w", dto.CodigoDocumento, err)
+				}
+				documento.FavorecidosFinais = finais
+			}
+
+			documentos = append(documentos, documento)
+			// Pequeno delay entre documentos (limite de requisicoes da API)
+			if err := retry.Sleep(ctx, 100*time.Millisecond); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return documentos, nil
+}
+
+// buscarFavorecidosFinais percorre as paginas de favorecidos finais de um pagamento
+func (s *SyncService) buscarFavorecidosFinais(ctx context.Context, codigoDocumento string) ([]FavorecidoFinal, error) {
+	var favorecidos []FavorecidoFinal
+	for pagina := 1; ; pagina++ {
+		if pagina > maxPaginasFavorecidos {
+			return nil, fmt.Errorf("mais de %d paginas de favorecidos finais", maxPaginasFavorecidos)
+		}
+		lista, err := s.transparencia.GetFavorecidosFinais(ctx, codigoDocumento, pagina)
+		if err != nil {
+			return nil, err
+		}
+		if len(lista) == 0 {
+			break
+		}
+		for _, dto := range lista {
+			favorecidos = append(favorecidos, FavorecidoFinal{
+				Codigo:    strings.TrimSpace(dto.CodigoFavorecido),
+				Nome:      strings.TrimSpace(dto.NomeFavorecido),
+				UF:        dto.UF,
+				Municipio: dto.Municipio,
+				Valor:     float64(dto.Valor),
+			})
+		}
+	}
+	return favorecidos, nil
+}
+
+// convertDocumento combina o documento listado na emenda com o detalhe da despesa
+func convertDocumento(numero string, dto transparencia.DocumentoEmendaDTO, detalhe transparencia.DespesaDocumentoDTO) Documento {
+	fase := dto.Fase
+	if fase == "" {
+		fase = detalhe.Fase
+	}
+	data := dto.Data
+	if data == "" {
+		data = detalhe.Data
+	}
+	especie := detalhe.Especie
+	if especie == "" {
+		especie = dto.EspecieTipo
+	}
+
+	return Documento{
+		NumeroEmenda:     numero,
+		CodigoDocumento:  dto.CodigoDocumento,
+		CodigoResumido:   dto.CodigoDocumentoResumido,
+		Fase:             normalizarFase(fase),
+		Data:             utils.ParseDataBR(data),
+		Especie:          especie,
+		Valor:            float64(detalhe.Valor),
+		UnidadeGestora:   detalhe.Ug,
+		Orgao:            detalhe.Orgao,
+		FavorecidoCodigo: strings.TrimSpace(detalhe.CodigoFavorecido),
+		FavorecidoNome:   strings.TrimSpace(detalhe.NomeFavorecido),
+		FavorecidoUF:     detalhe.UFFavorecido,
+		Observacao:       detalhe.Observacao,
+	}
+}
+
+// normalizarFase padroniza a fase da despesa ("Liquidação" -> "Liquidacao")
+func normalizarFase(fase string) string {
+	f := utils.RemoverAcentos(strings.ToLower(strings.TrimSpace(fase)))
+	switch {
+	case strings.Contains(f, "empenho"):
+		return FaseEmpenho
+	case strings.Contains(f, "liquidacao"):
+		return FaseLiquidacao
+	case strings.Contains(f, "pagamento"):
+		return FasePagamento
+	}
+	return fase
+}
+
+// SyncTransferenciasEspeciais busca no Transferegov os planos de acao das transferencias
+// especiais ("emendas PIX") de um ano, com o ente beneficiario e o que foi declarado para
+// o recurso. Apenas planos de emendas ja importadas (de senadores) sao gravados.
+func (s *SyncService) SyncTransferenciasEspeciais(ctx context.Context, ano int) error {
+	classificadas, err := s.repo.ClassificarCategorias()
+	if err != nil {
+		return fmt.Errorf("falha ao classificar emendas: %w", err)
+	}
+	if classificadas > 0 {
+		slog.Info("emendas classificadas por categoria", "total", classificadas)
+	}
+
+	numeros, err := s.repo.FindNumerosPorAno(ano)
+	if err != nil {
+		return err
+	}
+	// O Transferegov formata o codigo com pontos; casa pelos digitos
+	porCodigo := make(map[string]string, len(numeros))
+	for _, numero := range numeros {
+		porCodigo[utils.ApenasDigitos(numero)] = numero
+	}
+	slog.Info("iniciando sync de transferencias especiais", "ano", ano, "emendas", len(numeros))
+
+	total := 0
+	for offset := 0; offset < 200*transferegov.TamanhoPagina; offset += transferegov.TamanhoPagina {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		planos, err := s.transferegov.GetPlanosAcao(ctx, ano, offset)
+		if err != nil {
+			return fmt.Errorf("erro API transferegov: %w", err)
+		}
+
+		var transferencias []TransferenciaEspecial
+		for _, p := range planos {
+			numero, ok := porCodigo[utils.ApenasDigitos(p.CodigoEmendaFormatado)]
+			if !ok {
+				continue
+			}
+			transferencias = append(transferencias, convertPlanoAcao(numero, p))
+		}
+		if err := s.repo.UpsertTransferencias(transferencias); err != nil {
+			return fmt.Errorf("falha ao salvar transferencias especiais: %w", err)
+		}
+		total += len(transferencias)
+
+		if len(planos) < transferegov.TamanhoPagina {
+			break
+		}
+		if err := retry.Sleep(ctx, 200*time.Millisecond); err != nil {
+			return err
+		}
+	}
+
+	slog.Info("sync de transferencias especiais concluido", "ano", ano, "planos", total)
+	return nil
+}
+
+// convertPlanoAcao converte o plano de acao da API para o modelo local
+func convertPlanoAcao(numero string, p transferegov.PlanoAcaoAPI) TransferenciaEspecial {
+	nome := strings.TrimSpace(p.NomeBeneficiario)
+	esfera, municipio := identificarEnte(nome)
+	return TransferenciaEspecial{
+		IDPlanoAcao:       p.IDPlanoAcao,
+		CodigoPlanoAcao:   p.CodigoPlanoAcao,
+		NumeroEmenda:      numero,
+		AnoEmenda:         p.AnoEmenda,
+		Situacao:          p.Situacao,
+		BeneficiarioCNPJ:  utils.ApenasDigitos(p.CNPJBeneficiario),
+		BeneficiarioNome:  nome,
+		Esfera:            esfera,
+		Municipio:         municipio,
+		UF:                strings.ToUpper(strings.TrimSpace(p.UFBeneficiario)),
+		AreaPolitica:      strings.TrimSpace(p.AreasPoliticasPublicas),
+		Programacao:       strings.TrimSpace(p.ProgramacaoOrcamentaria),
+		ValorCusteio:      p.ValorCusteio,
+		ValorInvestimento: p.ValorInvestimento,
+	}
 }
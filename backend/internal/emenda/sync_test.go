@@ -0,0 +1,88 @@
+package emenda
+
+import (
+	"testing"
+
+	"github.com/Alzarus/to-de-olho/internal/utils"
+	"github.com/Alzarus/to-de-olho/pkg/transferegov"
+	"github.com/Alzarus/to-de-olho/pkg/transparencia"
+)
+
+func TestConvertDocumento(t *testing.T) {
+	dto := transparencia.DocumentoEmendaDTO{
+		Data:                    "15/03/2024",
+		Fase:                    "Liquidação",
+		CodigoDocumento:         "153978152082024NS000123",
+		CodigoDocumentoResumido: "2024NS000123",
+		EspecieTipo:             "Nota de Sistema",
+	}
+	detalhe := transparencia.DespesaDocumentoDTO{
+		Valor:            1500.5,
+		CodigoFavorecido: " 12345678000199 ",
+		NomeFavorecido:   "MUNICIPIO DE EXEMPLO",
+		UFFavorecido:     "BA",
+		Ug:               "153978",
+	}
+
+	doc := convertDocumento("202427470001", dto, detalhe)
+
+	if doc.Fase != FaseLiquidacao {
+		t.Errorf("fase esperada %s, obtida %s", FaseLiquidacao, doc.Fase)
+	}
+	if doc.Data == nil || doc.Data.Format("2006-01-02") != "2024-03-15" {
+		t.Errorf("data incorreta: %v", doc.Data)
+	}
+	if doc.Valor != 1500.5 || doc.FavorecidoCodigo != "12345678000199" || doc.Especie != "Nota de Sistema" {
+		t.Errorf("documento incorreto: %+v", doc)
+	}
+	if doc.NumeroEmenda != "202427470001" || doc.UnidadeGestora != "153978" {
+		t.Errorf("identificacao incorreta: %+v", doc)
+	}
+}
+
+func TestNormalizarFase(t *testing.T) {
+	casos := map[string]string{
+		"Empenho":    FaseEmpenho,
+		"LIQUIDAÇÃO": FaseLiquidacao,
+		"Pagamento":  FasePagamento,
+		"Outra":      "Outra",
+	}
+	for entrada, esperado := range casos {
+		if got := normalizarFase(entrada); got != esperado {
+			t.Errorf("normalizarFase(%q) = %q, esperado %q", entrada, got, esperado)
+		}
+	}
+}
+
+func TestConvertPlanoAcao(t *testing.T) {
+	plano := transferegov.PlanoAcaoAPI{
+		IDPlanoAcao:           123,
+		CodigoPlanoAcao:       "09032024-000123",
+		AnoEmenda:             2024,
+		Situacao:              "CIENTE",
+		CNPJBeneficiario:      "13.927.801/0001-49",
+		NomeBeneficiario:      " MUNICIPIO DE SALVADOR ",
+		UFBeneficiario:        "ba",
+		CodigoEmendaFormatado: "2024.2747.0001",
+		ValorCusteio:          100000,
+		ValorInvestimento:     250000,
+	}
+
+	if utils.ApenasDigitos(plano.CodigoEmendaFormatado) != "202427470001" {
+		t.Fatalf("codigo normalizado incorreto: %s", utils.ApenasDigitos(plano.CodigoEmendaFormatado))
+	}
+
+	tr := convertPlanoAcao("202427470001", plano)
+	if tr.NumeroEmenda != "202427470001" || tr.IDPlanoAcao != 123 || tr.AnoEmenda != 2024 {
+		t.Errorf("identificacao incorreta: %+v", tr)
+	}
+	if tr.BeneficiarioCNPJ != "13927801000149" || tr.UF != "BA" {
+		t.Errorf("beneficiario incorreto: %+v", tr)
+	}
+	if tr.Esfera != EsferaMunicipal || tr.Municipio != "SALVADOR" {
+		t.Errorf("ente incorreto: esfera=%q municipio=%q", tr.Esfera, tr.Municipio)
+	}
+	if tr.ValorTotal() != 350000 {
+		t.Errorf("valor total = %v, esperado 350000", tr.ValorTotal())
+	}
+}
@@ -0,0 +1,88 @@
+package fornecedor
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Alzarus/to-de-olho/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Handler gerencia endpoints REST de fornecedores
+type Handler struct {
+	repo *Repository
+}
+
+// NewHandler cria um novo handler
+func NewHandler(repo *Repository) *Handler {
+	return &Handler{repo: repo}
+}
+
+// ListRanking godoc
+// @Summary Ranking dos maiores recebedores da CEAPS
+// @Tags fornecedores
+// @Produce json
+// @Param ano query int false "Ano de referencia"
+// @Param tipo query string false "Tipo de despesa"
+// @Param q query string false "Busca por nome ou documento"
+// @Param limit query int false "Limite (default 20)"
+// @Param page query int false "Pagina (default 1)"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/fornecedores [get]
+func (h *Handler) ListRanking(c *gin.Context) {
+	limit := 20
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+	page := 1
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	ano, _ := strconv.Atoi(c.Query("ano"))
+
+	filtro := Filtro{
+		Ano:         ano,
+		TipoDespesa: c.Query("tipo"),
+		Busca:       c.Query("q"),
+		Limit:       limit,
+		Offset:      (page - 1) * limit,
+	}
+
+	ranking, total, err := h.repo.Ranking(filtro)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar fornecedores"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"total":        total,
+		"limit":        limit,
+		"page":         page,
+		"total_pages":  (int(total) + limit - 1) / limit,
+		"fornecedores": ranking,
+	})
+}
+
+// GetByDocumento godoc
+// @Summary Detalhe de um fornecedor com os senadores que o pagaram
+// @Tags fornecedores
+// @Produce json
+// @Param documento path string true "CNPJ/CPF (com ou sem mascara)"
+// @Success 200 {object} FornecedorDetalhe
+// @Failure 404 {object} map[string]string
+// @Router /api/v1/fornecedores/{documento} [get]
+func (h *Handler) GetByDocumento(c *gin.Context) {
+	documento := utils.ApenasDigitos(c.Param("documento"))
+	if documento == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "documento invalido"})
+		return
+	}
+
+	detalhe, err := h.repo.GetDetalhe(documento)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "fornecedor nao encontrado"})
+		return
+	}
+
+	c.JSON(http.StatusOK, detalhe)
+}
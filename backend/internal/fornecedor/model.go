@@ -0,0 +1,87 @@
+package fornecedor
+
+import "time"
+
+// Tipos de documento do fornecedor
+const (
+	TipoCNPJ  = "CNPJ"
+	TipoCPF   = "CPF"
+	TipoOutro = "OUTRO"
+)
+
+// Fornecedor representa um recebedor de despesas CEAPS consolidado pelo CNPJ/CPF limpo
+type Fornecedor struct {
+	ID                 int        `gorm:"primaryKey" json:"id"`
+	Documento          string     `gorm:"uniqueIndex;not null" json:"documento"` // Apenas digitos
+	TipoDocumento      string     `gorm:"index" json:"tipo_documento"`           // CNPJ, CPF, OUTRO
+	Nome               string     `json:"nome"`                                  // Nome mais frequente nas notas
+	TotalRecebido      float64    `json:"total_recebido"`
+	QuantidadeDespesas int        `json:"quantidade_despesas"`
+	TotalSenadores     int        `json:"total_senadores"`
+	PrimeiraDespesa    *time.Time `json:"primeira_despesa,omitempty"`
+	UltimaDespesa      *time.Time `json:"ultima_despesa,omitempty"`
+
+	// Dados cadastrais da Receita (apenas CNPJ), preenchidos por EnriquecerCNPJs
+	DataAbertura      *time.Time `json:"data_abertura,omitempty"`
+	SituacaoCadastral string     `json:"situacao_cadastral,omitempty"`
+	ConsultadoEm      *time.Time `json:"consultado_em,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName define o nome da tabela
+func (Fornecedor) TableName() string {
+	return "fornecedores"
+}
+
+// Filtro define os filtros do ranking de fornecedores
+type Filtro struct {
+	Ano         int
+	TipoDespesa string
+	Busca       string
+	Limit       int
+	Offset      int
+}
+
+// RankingFornecedor representa um fornecedor no ranking de recebedores
+type RankingFornecedor struct {
+	Documento     string  `json:"documento"`
+	TipoDocumento string  `json:"tipo_documento"`
+	Nome          string  `json:"nome"`
+	Total         float64 `json:"total"`
+	Quantidade    int     `json:"quantidade"`
+	Senadores     int     `json:"senadores"`
+}
+
+// PagamentoSenador representa quanto um senador pagou a um fornecedor
+type PagamentoSenador struct {
+	SenadorID  int     `json:"senador_id"`
+	Nome       string  `json:"nome"`
+	Partido    string  `json:"partido"`
+	UF         string  `json:"uf"`
+	Total      float64 `json:"total"`
+	Quantidade int     `json:"quantidade"`
+}
+
+// TotalPorAno representa o total recebido por um fornecedor em um ano
+type TotalPorAno struct {
+	Ano        int     `json:"ano"`
+	Total      float64 `json:"total"`
+	Quantidade int     `json:"quantidade"`
+}
+
+// TotalPorTipo representa o total recebido por um fornecedor em um tipo de despesa
+type TotalPorTipo struct {
+	TipoDespesa string  `json:"tipo_despesa"`
+	Total       float64 `json:"total"`
+	Quantidade  int     `json:"quantidade"`
+}
+
+// FornecedorDetalhe representa a pagina de detalhe de um fornecedor
+type FornecedorDetalhe struct {
+	Fornecedor
+	Senadores []PagamentoSenador `json:"senadores"`
+	PorAno    []TotalPorAno      `json:"por_ano"`
+	PorTipo   []TotalPorTipo     `json:"por_tipo"`
+}
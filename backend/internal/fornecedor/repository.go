@@ -0,0 +1,185 @@
+package fornecedor
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository encapsula operacoes de banco de dados para Fornecedor
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository cria um novo repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// despesaAgrupada representa despesas CEAPS agrupadas pelo documento bruto
+type despesaAgrupada struct {
+	CNPJCPF    string
+	Fornecedor string
+	SenadorID  int
+	Quantidade int
+	Total      float64
+	Primeira   *time.Time
+	Ultima     *time.Time
+}
+
+// agruparDespesas agrupa as despesas CEAPS por documento bruto, nome e senador
+func (r *Repository) agruparDespesas() ([]despesaAgrupada, error) {
+	var rows []despesaAgrupada
+	err := r.db.Table("despesas_ceaps").
+		Select("cnpj_cpf, fornecedor, senador_id, COUNT(*) as quantidade, SUM(valor) as total, MIN(data_emissao) as primeira, MAX(data_emissao) as ultima").
+		Where("cnpj_cpf <> ''").
+		Group("cnpj_cpf, fornecedor, senador_id").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// Ranking retorna os maiores recebedores de CEAPS com filtros opcionais
+func (r *Repository) Ranking(f Filtro) ([]RankingFornecedor, int64, error) {
+	var ranking []RankingFornecedor
+	var total int64
+
+	query := r.db.Table("despesas_ceaps d").
+		Joins("JOIN fornecedores f ON f.documento = d.documento_limpo")
+
+	if f.Ano > 0 {
+		query = query.Where("d.ano = ?", f.Ano)
+	}
+	if f.TipoDespesa != "" && f.TipoDespesa != "todos" {
+		query = query.Where("d.tipo_despesa = ?", f.TipoDespesa)
+	}
+	if f.Busca != "" {
+		search := "%" + f.Busca + "%"
+		query = query.Where("(f.nome ILIKE ? OR f.documento LIKE ?)", search, search)
+	}
+
+	if err := query.Session(&gorm.Session{}).
+		Distinct("f.documento").
+		Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Select("f.documento, f.tipo_documento, f.nome, SUM(d.valor) as total, COUNT(*) as quantidade, COUNT(DISTINCT d.senador_id) as senadores").
+		Group("f.documento, f.tipo_documento, f.nome").
+		Order("total DESC").
+		Limit(f.Limit).
+		Offset(f.Offset).
+		Scan(&ranking).Error
+
+	return ranking, total, err
+}
+
+// FindByDocumento retorna um fornecedor pelo documento limpo
+func (r *Repository) FindByDocumento(documento string) (*Fornecedor, error) {
+	var fornecedor Fornecedor
+	result := r.db.Where("documento = ?", documento).First(&fornecedor)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &fornecedor, nil
+}
+
+// GetDetalhe retorna o fornecedor com os senadores que o pagaram e totais por ano/tipo
+func (r *Repository) GetDetalhe(documento string) (*FornecedorDetalhe, error) {
+	fornecedor, err := r.FindByDocumento(documento)
+	if err != nil {
+		return nil, err
+	}
+
+	detalhe := FornecedorDetalhe{Fornecedor: *fornecedor}
+	base := func() *gorm.DB {
+		return r.db.Table("despesas_ceaps d").Where("d.documento_limpo = ?", documento)
+	}
+
+	if err := base().
+		Select("s.id as senador_id, s.nome, s.partido, s.uf, SUM(d.valor) as total, COUNT(*) as quantidade").
+		Joins("JOIN senadores s ON s.id = d.senador_id").
+		Group("s.id, s.nome, s.partido, s.uf").
+		Order("total DESC").
+		Scan(&detalhe.Senadores).Error; err != nil {
+		return nil, err
+	}
+
+	if err := base().
+		Select("d.ano, SUM(d.valor) as total, COUNT(*) as quantidade").
+		Group("d.ano").
+		Order("d.ano DESC").
+		Scan(&detalhe.PorAno).Error; err != nil {
+		return nil, err
+	}
+
+	if err := base().
+		Select("d.tipo_despesa, SUM(d.valor) as total, COUNT(*) as quantidade").
+		Group("d.tipo_despesa").
+		Order("total DESC").
+		Scan(&detalhe.PorTipo).Error; err != nil {
+		return nil, err
+	}
+
+	return &detalhe, nil
+}
+
+// FindPendentesConsulta retorna CNPJs ainda nao consultados na Receita, maiores recebedores primeiro
+func (r *Repository) FindPendentesConsulta(limite int) ([]Fornecedor, error) {
+	var fornecedores []Fornecedor
+	result := r.db.Where("tipo_documento = ? AND consultado_em IS NULL", TipoCNPJ).
+		Order("total_recebido DESC").
+		Limit(limite).
+		Find(&fornecedores)
+	return fornecedores, result.Error
+}
+
+// AtualizarCadastro grava os dados cadastrais consultados de um CNPJ
+func (r *Repository) AtualizarCadastro(documento string, dataAbertura *time.Time, situacao string) error {
+	return r.db.Model(&Fornecedor{}).
+		Where("documento = ?", documento).
+		Updates(map[string]interface{}{
+			"data_abertura":      dataAbertura,
+			"situacao_cadastral": situacao,
+			"consultado_em":      time.Now(),
+		}).Error
+}
+
+// FindDatasAbertura retorna documento -> data de abertura dos CNPJs ja consultados
+func (r *Repository) FindDatasAbertura() (map[string]time.Time, error) {
+	var rows []struct {
+		Documento    string
+		DataAbertura time.Time
+	}
+	if err := r.db.Model(&Fornecedor{}).
+		Select("documento, data_abertura").
+		Where("data_abertura IS NOT NULL").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	datas := make(map[string]time.Time, len(rows))
+	for _, row := range rows {
+		datas[row.Documento] = row.DataAbertura
+	}
+	return datas, nil
+}
+
+// UpsertBatch insere ou atualiza fornecedores pelo documento
+func (r *Repository) UpsertBatch(fornecedores []Fornecedor) error {
+	if len(fornecedores) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "documento"}},
+		DoUpdates: clause.AssignmentColumns([]string{"tipo_documento", "nome", "total_recebido", "quantidade_despesas", "total_senadores", "primeira_despesa", "ultima_despesa", "updated_at"}),
+	}).CreateInBatches(fornecedores, 200).Error
+}
+
+// Count retorna total de fornecedores
+func (r *Repository) Count() (int64, error) {
+	var count int64
+	result := r.db.Model(&Fornecedor{}).Count(&count)
+	return count, result.Error
+}
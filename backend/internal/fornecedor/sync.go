@@ -0,0 +1,181 @@
+package fornecedor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Alzarus/to-de-olho/internal/utils"
+	"github.com/Alzarus/to-de-olho/pkg/cnpj"
+	"github.com/Alzarus/to-de-olho/pkg/retry"
+)
+
+// SyncService consolida a tabela de fornecedores a partir das despesas CEAPS
+type SyncService struct {
+	repo       *Repository
+	cnpjClient *cnpj.Client
+}
+
+// NewSyncService cria um novo servico de sincronizacao
+func NewSyncService(repo *Repository, cnpjClient *cnpj.Client) *SyncService {
+	return &SyncService{
+		repo:       repo,
+		cnpjClient: cnpjClient,
+	}
+}
+
+// RebuildFromCEAPS recalcula os totais de todos os fornecedores.
+// Deve rodar apos o sync de CEAPS, ja que os dados vem de despesas_ceaps.
+func (s *SyncService) RebuildFromCEAPS(ctx context.Context) error {
+	slog.Info("iniciando consolidacao de fornecedores")
+
+	rows, err := s.repo.agruparDespesas()
+	if err != nil {
+		return err
+	}
+
+	fornecedores := consolidar(rows)
+	if err := s.repo.UpsertBatch(fornecedores); err != nil {
+		return err
+	}
+
+	slog.Info("consolidacao de fornecedores concluida", "grupos", len(rows), "fornecedores", len(fornecedores))
+	return nil
+}
+
+// EnriquecerCNPJs consulta na Receita a data de abertura de ate `limite` CNPJs pendentes.
+// A API publica tem limite de requisicoes, por isso o enriquecimento e incremental.
+func (s *SyncService) EnriquecerCNPJs(ctx context.Context, limite int) error {
+	pendentes, err := s.repo.FindPendentesConsulta(limite)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("iniciando consulta cadastral de CNPJs", "pendentes", len(pendentes))
+
+	var consultados int
+	for _, f := range pendentes {
+		empresa, err := s.cnpjClient.Consultar(ctx, f.Documento)
+		if err != nil && !errors.Is(err, cnpj.ErrNaoEncontrado) {
+			slog.Warn("falha ao consultar CNPJ", "documento", f.Documento, "error", err)
+			continue
+		}
+
+		var dataAbertura *time.Time
+		var situacao string
+		if empresa != nil {
+			if t, err := time.Parse("2006-01-02", empresa.DataInicioAtividade); err == nil {
+				dataAbertura = &t
+			}
+			situacao = empresa.SituacaoCadastral
+		}
+
+		if err := s.repo.AtualizarCadastro(f.Documento, dataAbertura, situacao); err != nil {
+			slog.Warn("falha ao salvar cadastro do CNPJ", "documento", f.Documento, "error", err)
+			continue
+		}
+		consultados++
+
+		// Respeitar o rate limit da API publica
+		if err := retry.Sleep(ctx, 400*time.Millisecond); err != nil {
+			return err
+		}
+	}
+
+	slog.Info("consulta cadastral de CNPJs concluida", "consultados", consultados)
+	return nil
+}
+
+// consolidar une grupos de despesas com o mesmo documento limpo
+// (o mesmo CNPJ aparece com e sem mascara, e com grafias diferentes do nome)
+func consolidar(rows []despesaAgrupada) []Fornecedor {
+	type acumulado struct {
+		fornecedor Fornecedor
+		senadores  map[int]bool
+		nomes      map[string]int
+	}
+
+	porDocumento := make(map[string]*acumulado)
+	var ordem []string
+
+	for _, row := range rows {
+		doc := utils.ApenasDigitos(row.CNPJCPF)
+		if doc == "" {
+			continue
+		}
+
+		acc, ok := porDocumento[doc]
+		if !ok {
+			acc = &acumulado{
+				fornecedor: Fornecedor{
+					Documento:     doc,
+					TipoDocumento: TipoDocumento(doc),
+				},
+				senadores: make(map[int]bool),
+				nomes:     make(map[string]int),
+			}
+			porDocumento[doc] = acc
+			ordem = append(ordem, doc)
+		}
+
+		f := &acc.fornecedor
+		f.TotalRecebido += row.Total
+		f.QuantidadeDespesas += row.Quantidade
+		f.PrimeiraDespesa = menorData(f.PrimeiraDespesa, row.Primeira)
+		f.UltimaDespesa = maiorData(f.UltimaDespesa, row.Ultima)
+		acc.senadores[row.SenadorID] = true
+
+		if nome := strings.TrimSpace(row.Fornecedor); nome != "" {
+			acc.nomes[nome] += row.Quantidade
+		}
+	}
+
+	fornecedores := make([]Fornecedor, 0, len(ordem))
+	for _, doc := range ordem {
+		acc := porDocumento[doc]
+		acc.fornecedor.TotalSenadores = len(acc.senadores)
+		acc.fornecedor.Nome = nomeMaisFrequente(acc.nomes)
+		fornecedores = append(fornecedores, acc.fornecedor)
+	}
+	return fornecedores
+}
+
+// TipoDocumento classifica um documento limpo pelo numero de digitos
+func TipoDocumento(documento string) string {
+	switch len(documento) {
+	case 14:
+		return TipoCNPJ
+	case 11:
+		return TipoCPF
+	}
+	return TipoOutro
+}
+
+// nomeMaisFrequente escolhe o nome com mais notas (empate: ordem alfabetica)
+func nomeMaisFrequente(nomes map[string]int) string {
+	var melhor string
+	var maior int
+	for nome, total := range nomes {
+		if total > maior || (total == maior && nome < melhor) {
+			melhor = nome
+			maior = total
+		}
+	}
+	return melhor
+}
+
+func menorData(a, b *time.Time) *time.Time {
+	if a == nil || (b != nil && b.Before(*a)) {
+		return b
+	}
+	return a
+}
+
+func maiorData(a, b *time.Time) *time.Time {
+	if a == nil || (b != nil && b.After(*a)) {
+		return b
+	}
+	return a
+}
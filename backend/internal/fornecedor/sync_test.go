@@ -0,0 +1,52 @@
+package fornecedor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTipoDocumento(t *testing.T) {
+	testes := []struct {
+		documento string
+		tipo      string
+	}{
+		{"12345678000190", TipoCNPJ},
+		{"12345678901", TipoCPF},
+		{"", TipoOutro},
+		{"123", TipoOutro},
+	}
+
+	for _, teste := range testes {
+		if tipo := TipoDocumento(teste.documento); tipo != teste.tipo {
+			t.Errorf("TipoDocumento(%q) = %q; esperado %q", teste.documento, tipo, teste.tipo)
+		}
+	}
+}
+
+func TestConsolidar(t *testing.T) {
+	jan := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	rows := []despesaAgrupada{
+		{CNPJCPF: "12.345.678/0001-90", Fornecedor: "POSTO ABC LTDA", SenadorID: 1, Quantidade: 3, Total: 300, Primeira: &mar, Ultima: &mar},
+		{CNPJCPF: "12345678000190", Fornecedor: "Posto Abc", SenadorID: 2, Quantidade: 1, Total: 50, Primeira: &jan, Ultima: &jan},
+		{CNPJCPF: "12345678000190", Fornecedor: "POSTO ABC LTDA", SenadorID: 1, Quantidade: 1, Total: 100, Primeira: &jan, Ultima: &mar},
+		{CNPJCPF: "---", Fornecedor: "SEM DOCUMENTO", SenadorID: 3, Quantidade: 1, Total: 10},
+	}
+
+	fornecedores := consolidar(rows)
+	if len(fornecedores) != 1 {
+		t.Fatalf("esperado 1 fornecedor, obtido %d", len(fornecedores))
+	}
+
+	f := fornecedores[0]
+	if f.TotalRecebido != 450 || f.QuantidadeDespesas != 5 || f.TotalSenadores != 2 {
+		t.Errorf("totais inesperados: %+v", f)
+	}
+	if f.Nome != "POSTO ABC LTDA" {
+		t.Errorf("nome esperado POSTO ABC LTDA, obtido %q", f.Nome)
+	}
+	if !f.PrimeiraDespesa.Equal(jan) || !f.UltimaDespesa.Equal(mar) {
+		t.Errorf("datas inesperadas: %v - %v", f.PrimeiraDespesa, f.UltimaDespesa)
+	}
+}
@@ -0,0 +1,44 @@
+package gabinete
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler gerencia endpoints REST do gabinete
+type Handler struct {
+	repo *Repository
+}
+
+// NewHandler cria um novo handler
+func NewHandler(repo *Repository) *Handler {
+	return &Handler{repo: repo}
+}
+
+// GetBySenador godoc
+// @Summary Retorna servidores e folha de pagamento do gabinete de um senador
+// @Tags gabinete
+// @Produce json
+// @Param id path int true "ID do senador"
+// @Param ano query int false "Ano da folha de pagamento"
+// @Success 200 {object} GabineteResumo
+// @Router /api/v1/senadores/{id}/gabinete [get]
+func (h *Handler) GetBySenador(c *gin.Context) {
+	senadorID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalido"})
+		return
+	}
+
+	ano, _ := strconv.Atoi(c.Query("ano"))
+
+	resumo, err := h.repo.GetResumo(senadorID, ano)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar gabinete"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resumo)
+}
@@ -0,0 +1,93 @@
+package gabinete
+
+import "time"
+
+// Servidor representa um servidor lotado no gabinete (ou escritorio de apoio) de um senador
+type Servidor struct {
+	ID           int    `gorm:"primaryKey" json:"id"`
+	SenadorID    int    `gorm:"index;not null" json:"senador_id"`
+	Sequencial   int    `gorm:"uniqueIndex;not null" json:"sequencial"` // Identificador do servidor na API Administrativa
+	Nome         string `json:"nome"`
+	Vinculo      string `json:"vinculo"` // EFETIVO, COMISSIONADO, etc.
+	Situacao     string `json:"situacao,omitempty"`
+	Cargo        string `json:"cargo,omitempty"`
+	Funcao       string `json:"funcao,omitempty"`
+	Lotacao      string `json:"lotacao"`
+	SiglaLotacao string `json:"sigla_lotacao,omitempty"`
+	AnoAdmissao  int    `json:"ano_admissao,omitempty"`
+	Ativo        bool   `gorm:"default:true" json:"ativo"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName define o nome da tabela
+func (Servidor) TableName() string {
+	return "gabinete_servidores"
+}
+
+// Lotacao registra o periodo em que um servidor esteve no gabinete de um senador.
+// Permite atribuir a folha de meses passados ao gabinete da epoca, mesmo depois que
+// o servidor trocou de gabinete ou deixou o Senado. Inicio nil indica que o servidor
+// ja estava no gabinete quando o historico comecou a ser registrado.
+type Lotacao struct {
+	ID         int        `gorm:"primaryKey" json:"id"`
+	Sequencial int        `gorm:"index;not null" json:"sequencial"`
+	SenadorID  int        `gorm:"index;not null" json:"senador_id"`
+	Inicio     *time.Time `json:"inicio"`
+	Fim        *time.Time `json:"fim"` // nil enquanto o servidor continua no gabinete
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName define o nome da tabela
+func (Lotacao) TableName() string {
+	return "gabinete_lotacoes"
+}
+
+// Remuneracao representa o pagamento mensal de um servidor de gabinete
+type Remuneracao struct {
+	ID         int     `gorm:"primaryKey" json:"id"`
+	SenadorID  int     `gorm:"index:idx_remuneracao_senador_periodo,priority:1;not null" json:"senador_id"`
+	Sequencial int     `gorm:"uniqueIndex:idx_remuneracao_unica,priority:1;not null" json:"sequencial"`
+	Ano        int     `gorm:"uniqueIndex:idx_remuneracao_unica,priority:2;index:idx_remuneracao_senador_periodo,priority:2" json:"ano"`
+	Mes        int     `gorm:"uniqueIndex:idx_remuneracao_unica,priority:3;index:idx_remuneracao_senador_periodo,priority:3" json:"mes"`
+	TipoFolha  string  `gorm:"uniqueIndex:idx_remuneracao_unica,priority:4" json:"tipo_folha"`
+	Bruto      float64 `json:"bruto"`
+	Liquido    float64 `json:"liquido"`
+	Indenizado float64 `json:"indenizado"` // Diarias, auxilios e vantagens indenizatorias
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName define o nome da tabela
+func (Remuneracao) TableName() string {
+	return "gabinete_remuneracoes"
+}
+
+// GabineteResumo representa a composicao e o custo do gabinete de um senador
+type GabineteResumo struct {
+	SenadorID       int                  `json:"senador_id"`
+	TotalServidores int                  `json:"total_servidores"`
+	PorCargo        []ServidoresPorGrupo `json:"por_cargo"`
+	PorVinculo      []ServidoresPorGrupo `json:"por_vinculo"`
+	FolhaMensal     []FolhaMensal        `json:"folha_mensal"`
+	Servidores      []Servidor           `json:"servidores"`
+}
+
+// ServidoresPorGrupo representa contagem de servidores por cargo/funcao ou vinculo
+type ServidoresPorGrupo struct {
+	Grupo string `json:"grupo"`
+	Total int    `json:"total"`
+}
+
+// FolhaMensal representa o total pago ao gabinete em um mes
+type FolhaMensal struct {
+	Ano        int     `json:"ano"`
+	Mes        int     `json:"mes"`
+	Servidores int     `json:"servidores"`
+	TotalBruto float64 `json:"total_bruto"`
+	TotalLiq   float64 `json:"total_liquido"`
+}
@@ -0,0 +1,185 @@
+package gabinete
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository encapsula operacoes de banco de dados para o gabinete
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository cria um novo repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// FindServidoresBySenadorID retorna os servidores ativos do gabinete de um senador
+func (r *Repository) FindServidoresBySenadorID(senadorID int) ([]Servidor, error) {
+	var servidores []Servidor
+	result := r.db.Where("senador_id = ? AND ativo = ?", senadorID, true).
+		Order("nome ASC").
+		Find(&servidores)
+	return servidores, result.Error
+}
+
+// FindSenadoresPorSequencial retorna o mapeamento sequencial -> senador valido no mes:
+// a lotacao vigente em (ano, mes) e, para servidores sem historico de lotacao, o ultimo
+// gabinete conhecido (inclusive de servidores inativos)
+func (r *Repository) FindSenadoresPorSequencial(ano, mes int) (map[int]int, error) {
+	inicioMes := time.Date(ano, time.Month(mes), 1, 0, 0, 0, 0, time.UTC)
+	fimMes := inicioMes.AddDate(0, 1, 0)
+
+	var rows []struct {
+		Sequencial int
+		SenadorID  int
+	}
+	if err := r.db.Raw(`
+		SELECT DISTINCT ON (sequencial) sequencial, senador_id
+		FROM gabinete_lotacoes
+		WHERE (inicio IS NULL OR inicio < ?) AND (fim IS NULL OR fim >= ?)
+		ORDER BY sequencial, inicio DESC NULLS LAST`, fimMes, inicioMes).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	mapa := make(map[int]int, len(rows))
+	for _, row := range rows {
+		mapa[row.Sequencial] = row.SenadorID
+	}
+
+	rows = rows[:0]
+	if err := r.db.Model(&Servidor{}).
+		Select("sequencial, senador_id").
+		Where("NOT EXISTS (SELECT 1 FROM gabinete_lotacoes l WHERE l.sequencial = gabinete_servidores.sequencial)").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		mapa[row.Sequencial] = row.SenadorID
+	}
+	return mapa, nil
+}
+
+// FindUltimasLotacoes retorna a lotacao mais recente de cada servidor
+func (r *Repository) FindUltimasLotacoes() (map[int]Lotacao, error) {
+	var lotacoes []Lotacao
+	if err := r.db.Raw(`
+		SELECT DISTINCT ON (sequencial) *
+		FROM gabinete_lotacoes
+		ORDER BY sequencial, inicio DESC NULLS LAST, id DESC`).
+		Scan(&lotacoes).Error; err != nil {
+		return nil, err
+	}
+
+	ultimas := make(map[int]Lotacao, len(lotacoes))
+	for _, l := range lotacoes {
+		ultimas[l.Sequencial] = l
+	}
+	return ultimas, nil
+}
+
+// AtualizarLotacoes encerra as lotacoes informadas e abre as novas em uma transacao
+func (r *Repository) AtualizarLotacoes(encerrar []int, fim time.Time, abrir []Lotacao) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if len(encerrar) > 0 {
+			if err := tx.Model(&Lotacao{}).
+				Where("id IN ? AND fim IS NULL", encerrar).
+				Update("fim", fim).Error; err != nil {
+				return err
+			}
+		}
+		if len(abrir) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(abrir, 100).Error
+	})
+}
+
+// GetResumo retorna headcount, distribuicao por cargo/vinculo e folha mensal de um senador
+// Se ano > 0, a folha e filtrada pelo ano
+func (r *Repository) GetResumo(senadorID int, ano int) (*GabineteResumo, error) {
+	resumo := GabineteResumo{SenadorID: senadorID}
+
+	servidores, err := r.FindServidoresBySenadorID(senadorID)
+	if err != nil {
+		return nil, err
+	}
+	resumo.Servidores = servidores
+	resumo.TotalServidores = len(servidores)
+
+	// Funcao comissionada descreve melhor o papel no gabinete; cargo efetivo como fallback
+	if err := r.db.Model(&Servidor{}).
+		Select("COALESCE(NULLIF(funcao, ''), NULLIF(cargo, ''), 'Nao informado') as grupo, COUNT(*) as total").
+		Where("senador_id = ? AND ativo = ?", senadorID, true).
+		Group("grupo").
+		Order("total DESC").
+		Scan(&resumo.PorCargo).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Model(&Servidor{}).
+		Select("vinculo as grupo, COUNT(*) as total").
+		Where("senador_id = ? AND ativo = ?", senadorID, true).
+		Group("vinculo").
+		Order("total DESC").
+		Scan(&resumo.PorVinculo).Error; err != nil {
+		return nil, err
+	}
+
+	folha := r.db.Model(&Remuneracao{}).
+		Select("ano, mes, COUNT(DISTINCT sequencial) as servidores, SUM(bruto) as total_bruto, SUM(liquido) as total_liq").
+		Where("senador_id = ?", senadorID)
+	if ano > 0 {
+		folha = folha.Where("ano = ?", ano)
+	}
+	if err := folha.Group("ano, mes").
+		Order("ano DESC, mes DESC").
+		Scan(&resumo.FolhaMensal).Error; err != nil {
+		return nil, err
+	}
+
+	return &resumo, nil
+}
+
+// UpsertServidores insere ou atualiza servidores pelo sequencial
+func (r *Repository) UpsertServidores(servidores []Servidor) error {
+	if len(servidores) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "sequencial"}},
+		DoUpdates: clause.AssignmentColumns([]string{"senador_id", "nome", "vinculo", "situacao", "cargo", "funcao", "lotacao", "sigla_lotacao", "ano_admissao", "ativo", "updated_at"}),
+	}).CreateInBatches(servidores, 100).Error
+}
+
+// SetInactive marca como inativos os servidores que nao estao mais lotados em gabinetes
+func (r *Repository) SetInactive(sequenciaisAtivos []int) error {
+	if len(sequenciaisAtivos) == 0 {
+		return nil
+	}
+	return r.db.Model(&Servidor{}).
+		Where("sequencial NOT IN ?", sequenciaisAtivos).
+		Update("ativo", false).Error
+}
+
+// UpsertRemuneracoes insere ou atualiza remuneracoes (sequencial, ano, mes, tipo_folha)
+func (r *Repository) UpsertRemuneracoes(remuneracoes []Remuneracao) error {
+	if len(remuneracoes) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "sequencial"}, {Name: "ano"}, {Name: "mes"}, {Name: "tipo_folha"}},
+		DoUpdates: clause.AssignmentColumns([]string{"senador_id", "bruto", "liquido", "indenizado", "updated_at"}),
+	}).CreateInBatches(remuneracoes, 100).Error
+}
+
+// Count retorna total de servidores ativos em gabinetes
+func (r *Repository) Count() (int64, error) {
+	var count int64
+	result := r.db.Model(&Servidor{}).Where("ativo = ?", true).Count(&count)
+	return count, result.Error
+}
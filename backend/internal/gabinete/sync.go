@@ -0,0 +1,221 @@
+package gabinete
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Alzarus/to-de-olho/internal/senador"
+	"github.com/Alzarus/to-de-olho/internal/utils"
+	"github.com/Alzarus/to-de-olho/pkg/senado"
+)
+
+// SyncService gerencia sincronizacao de servidores e folha dos gabinetes
+type SyncService struct {
+	repo        *Repository
+	senadorRepo *senador.Repository
+	client      *senado.AdmClient
+}
+
+// NewSyncService cria um novo servico de sincronizacao
+func NewSyncService(repo *Repository, senadorRepo *senador.Repository, client *senado.AdmClient) *SyncService {
+	return &SyncService{
+		repo:        repo,
+		senadorRepo: senadorRepo,
+		client:      client,
+	}
+}
+
+// SyncFromAPI atualiza os servidores lotados em gabinetes de senadores em exercicio
+func (s *SyncService) SyncFromAPI(ctx context.Context) error {
+	slog.Info("iniciando sync de servidores de gabinete")
+
+	servidoresAPI, err := s.client.ListarServidores(ctx)
+	if err != nil {
+		return err
+	}
+
+	senadores, err := s.senadorRepo.FindAll(false)
+	if err != nil {
+		return err
+	}
+
+	var servidores []Servidor
+	var sequenciais []int
+	for _, sv := range servidoresAPI {
+		if sv.Lotacao == nil {
+			continue
+		}
+		senadorID := identificarSenador(sv.Lotacao.Nome, senadores)
+		if senadorID == 0 {
+			continue
+		}
+		servidores = append(servidores, convertServidor(sv, senadorID))
+		sequenciais = append(sequenciais, sv.Sequencial)
+	}
+
+	if err := s.repo.UpsertServidores(servidores); err != nil {
+		return err
+	}
+
+	// Servidores que sairam dos gabinetes deixam de contar no headcount
+	if err := s.repo.SetInactive(sequenciais); err != nil {
+		slog.Warn("falha ao marcar servidores inativos", "error", err)
+	}
+
+	// Historico de lotacoes para atribuir a folha de meses passados ao gabinete da epoca
+	ultimas, err := s.repo.FindUltimasLotacoes()
+	if err != nil {
+		slog.Warn("falha ao carregar lotacoes de gabinete", "error", err)
+	} else {
+		hoje := time.Now().Truncate(24 * time.Hour)
+		encerrar, abrir := compararLotacoes(ultimas, servidores, hoje)
+		if err := s.repo.AtualizarLotacoes(encerrar, hoje.AddDate(0, 0, -1), abrir); err != nil {
+			slog.Warn("falha ao atualizar lotacoes de gabinete", "error", err)
+		}
+	}
+
+	slog.Info("sync de servidores de gabinete concluido", "recebidos", len(servidoresAPI), "em_gabinetes", len(servidores))
+	return nil
+}
+
+// SyncRemuneracoes importa a folha de um mes para os servidores de gabinete conhecidos
+func (s *SyncService) SyncRemuneracoes(ctx context.Context, ano, mes int) error {
+	slog.Info("iniciando sync de remuneracoes de gabinete", "ano", ano, "mes", mes)
+
+	sequencialToSenador, err := s.repo.FindSenadoresPorSequencial(ano, mes)
+	if err != nil {
+		return err
+	}
+	if len(sequencialToSenador) == 0 {
+		slog.Warn("nenhum servidor de gabinete cadastrado, pulando remuneracoes")
+		return nil
+	}
+
+	remuneracoesAPI, err := s.client.ListarRemuneracoes(ctx, ano, mes)
+	if err != nil {
+		return err
+	}
+
+	var remuneracoes []Remuneracao
+	for _, r := range remuneracoesAPI {
+		senadorID, ok := sequencialToSenador[r.Sequencial]
+		if !ok {
+			continue
+		}
+		remuneracoes = append(remuneracoes, Remuneracao{
+			SenadorID:  senadorID,
+			Sequencial: r.Sequencial,
+			Ano:        ano,
+			Mes:        mes,
+			TipoFolha:  r.TipoFolha,
+			Bruto:      r.RemuneracaoBruta(),
+			Liquido:    r.RemuneracaoLiquida,
+			Indenizado: r.Diarias + r.Auxilios + r.VantagensIndenizat,
+		})
+	}
+
+	if err := s.repo.UpsertRemuneracoes(remuneracoes); err != nil {
+		return err
+	}
+
+	slog.Info("sync de remuneracoes de gabinete concluido", "ano", ano, "mes", mes, "salvos", len(remuneracoes))
+	return nil
+}
+
+// compararLotacoes confronta a ultima lotacao de cada servidor com os servidores atuais.
+// Retorna os IDs das lotacoes abertas a encerrar (saiu do gabinete ou trocou de gabinete)
+// e as lotacoes a abrir a partir de hoje. Servidor sem nenhum historico recebe uma
+// lotacao sem inicio, que cobre a folha ja publicada.
+func compararLotacoes(ultimas map[int]Lotacao, servidores []Servidor, hoje time.Time) ([]int, []Lotacao) {
+	var encerrar []int
+	var abrir []Lotacao
+	atuais := make(map[int]bool, len(servidores))
+
+	for _, sv := range servidores {
+		atuais[sv.Sequencial] = true
+		ultima, existe := ultimas[sv.Sequencial]
+		if existe && ultima.Fim == nil && ultima.SenadorID == sv.SenadorID {
+			continue
+		}
+		if existe && ultima.Fim == nil {
+			encerrar = append(encerrar, ultima.ID)
+		}
+
+		nova := Lotacao{Sequencial: sv.Sequencial, SenadorID: sv.SenadorID}
+		if existe {
+			inicio := hoje
+			nova.Inicio = &inicio
+		}
+		abrir = append(abrir, nova)
+	}
+
+	for sequencial, ultima := range ultimas {
+		if ultima.Fim == nil && !atuais[sequencial] {
+			encerrar = append(encerrar, ultima.ID)
+		}
+	}
+	sort.Ints(encerrar)
+	return encerrar, abrir
+}
+
+// convertServidor converte um servidor da API para o modelo interno
+func convertServidor(sv senado.ServidorAPI, senadorID int) Servidor {
+	servidor := Servidor{
+		SenadorID:   senadorID,
+		Sequencial:  sv.Sequencial,
+		Nome:        sv.Nome,
+		Vinculo:     sv.Vinculo,
+		Situacao:    sv.Situacao,
+		AnoAdmissao: sv.AnoAdmissao,
+		Ativo:       true,
+	}
+	if sv.Cargo != nil {
+		servidor.Cargo = sv.Cargo.Nome
+	}
+	if sv.Funcao != nil {
+		servidor.Funcao = sv.Funcao.Nome
+	}
+	if sv.Lotacao != nil {
+		servidor.Lotacao = sv.Lotacao.Nome
+		servidor.SiglaLotacao = sv.Lotacao.Sigla
+	}
+	return servidor
+}
+
+// identificarSenador associa uma lotacao ("Gabinete do Senador Fulano",
+// "Escritorio de Apoio da Senadora Fulana") ao senador correspondente.
+// Em caso de nomes parecidos prevalece o nome mais longo encontrado.
+func identificarSenador(lotacao string, senadores []senador.Senador) int {
+	lotacaoNorm := normalizarNome(lotacao)
+	if !strings.Contains(lotacaoNorm, "SENADOR") {
+		return 0
+	}
+
+	var melhorID, melhorTamanho int
+	for _, sen := range senadores {
+		nome := normalizarNome(sen.Nome)
+		if nome == "" || !contemPalavras(lotacaoNorm, nome) {
+			continue
+		}
+		if len(nome) > melhorTamanho {
+			melhorID = sen.ID
+			melhorTamanho = len(nome)
+		}
+	}
+	return melhorID
+}
+
+// contemPalavras verifica se o nome aparece na lotacao respeitando limites de palavra
+func contemPalavras(texto, nome string) bool {
+	return strings.Contains(" "+texto+" ", " "+nome+" ")
+}
+
+// normalizarNome remove acentos, pontuacao e espacos extras, em caixa alta
+func normalizarNome(valor string) string {
+	valor = strings.ToUpper(utils.RemoverAcentos(valor))
+	valor = strings.NewReplacer(".", " ", "-", " ").Replace(valor)
+	return strings.Join(strings.Fields(valor), " ")
+}
@@ -0,0 +1,67 @@
+package gabinete
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Alzarus/to-de-olho/internal/senador"
+)
+
+func TestIdentificarSenador(t *testing.T) {
+	senadores := []senador.Senador{
+		{ID: 1, Nome: "Eduardo Braga"},
+		{ID: 2, Nome: "Braga"},
+		{ID: 3, Nome: "Damares Alves"},
+		{ID: 4, Nome: "Otto Alencar"},
+	}
+
+	testes := []struct {
+		lotacao  string
+		esperado int
+	}{
+		{"Gabinete do Senador Eduardo Braga", 1},
+		{"GABINETE DA SENADORA DAMARES ALVES", 3},
+		{"Escritório de Apoio do Senador Otto Alencar - BA", 4},
+		{"Secretaria de Comissões", 0},
+		{"Gabinete do Senador Ottoni", 0},
+	}
+
+	for _, teste := range testes {
+		if got := identificarSenador(teste.lotacao, senadores); got != teste.esperado {
+			t.Errorf("identificarSenador(%q) = %d; esperado %d", teste.lotacao, got, teste.esperado)
+		}
+	}
+}
+
+func TestCompararLotacoes(t *testing.T) {
+	hoje := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+	fim := hoje.AddDate(0, -2, 0)
+	ultimas := map[int]Lotacao{
+		10: {ID: 1, Sequencial: 10, SenadorID: 1},            // Continua no mesmo gabinete
+		11: {ID: 2, Sequencial: 11, SenadorID: 1},            // Trocou de gabinete
+		12: {ID: 3, Sequencial: 12, SenadorID: 2},            // Saiu
+		13: {ID: 4, Sequencial: 13, SenadorID: 2, Fim: &fim}, // Voltou
+	}
+	servidores := []Servidor{
+		{Sequencial: 10, SenadorID: 1},
+		{Sequencial: 11, SenadorID: 3},
+		{Sequencial: 13, SenadorID: 2},
+		{Sequencial: 14, SenadorID: 4}, // Sem historico
+	}
+
+	encerrar, abrir := compararLotacoes(ultimas, servidores, hoje)
+	if len(encerrar) != 2 || encerrar[0] != 2 || encerrar[1] != 3 {
+		t.Errorf("lotacoes a encerrar = %v; esperado [2 3]", encerrar)
+	}
+
+	esperado := map[int]bool{11: true, 13: true, 14: false} // Sequencial -> tem inicio
+	if len(abrir) != len(esperado) {
+		t.Fatalf("esperadas %d lotacoes novas, obtidas %d: %+v", len(esperado), len(abrir), abrir)
+	}
+	for _, l := range abrir {
+		temInicio, ok := esperado[l.Sequencial]
+		if !ok || (l.Inicio != nil) != temInicio || (temInicio && !l.Inicio.Equal(hoje)) {
+			t.Errorf("lotacao nova inesperada: %+v", l)
+		}
+	}
+}
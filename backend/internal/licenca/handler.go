@@ -0,0 +1,75 @@
+package licenca
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler gerencia endpoints REST de licencas
+type Handler struct {
+	repo *Repository
+}
+
+// NewHandler cria um novo handler
+func NewHandler(repo *Repository) *Handler {
+	return &Handler{repo: repo}
+}
+
+// ListBySenador godoc
+// @Summary Lista licencas e afastamentos oficiais de um senador
+// @Tags licencas
+// @Produce json
+// @Param id path int true "ID do senador"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/senadores/{id}/licencas [get]
+func (h *Handler) ListBySenador(c *gin.Context) {
+	senadorID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalido"})
+		return
+	}
+
+	licencas, err := h.repo.FindBySenadorID(senadorID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar licencas"})
+		return
+	}
+
+	hoje := time.Now()
+	porCategoria := make(map[string]*LicencasPorCategoria)
+	var diasTotal int
+	emCurso := false
+	for i := range licencas {
+		dias := licencas[i].Dias(hoje)
+		diasTotal += dias
+		if licencas[i].Cobre(hoje) {
+			emCurso = true
+		}
+		cat, ok := porCategoria[licencas[i].Categoria]
+		if !ok {
+			cat = &LicencasPorCategoria{Categoria: licencas[i].Categoria}
+			porCategoria[licencas[i].Categoria] = cat
+		}
+		cat.Total++
+		cat.Dias += dias
+	}
+
+	categorias := make([]LicencasPorCategoria, 0, len(porCategoria))
+	for _, cat := range porCategoria {
+		categorias = append(categorias, *cat)
+	}
+	sort.Slice(categorias, func(i, j int) bool { return categorias[i].Dias > categorias[j].Dias })
+
+	c.JSON(http.StatusOK, gin.H{
+		"senador_id":    senadorID,
+		"total":         len(licencas),
+		"dias_afastado": diasTotal,
+		"afastado_hoje": emCurso,
+		"por_categoria": categorias,
+		"licencas":      licencas,
+	})
+}
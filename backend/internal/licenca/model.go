@@ -0,0 +1,81 @@
+package licenca
+
+import (
+	"strings"
+	"time"
+)
+
+// Licenca representa um afastamento oficial de um senador (licenca saude,
+// licenca particular, missao autorizada etc.). Votacoes com NCom dentro do
+// periodo sao tratadas como ausencias justificadas no calculo de presenca.
+type Licenca struct {
+	ID            int        `gorm:"primaryKey" json:"id"`
+	Chave         string     `gorm:"uniqueIndex;not null" json:"-"` // senador + tipo + data de inicio
+	SenadorID     int        `gorm:"index:idx_licenca_senador_periodo,priority:1;not null" json:"senador_id"`
+	Codigo        string     `json:"codigo,omitempty"`
+	SiglaTipo     string     `json:"sigla_tipo"`
+	DescricaoTipo string     `json:"descricao_tipo"`
+	Categoria     string     `json:"categoria"` // saude, particular, missao, outro
+	DataInicio    time.Time  `gorm:"index:idx_licenca_senador_periodo,priority:2;not null" json:"data_inicio"`
+	DataFim       *time.Time `json:"data_fim,omitempty"` // nil = afastamento em curso
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName define o nome da tabela
+func (Licenca) TableName() string {
+	return "licencas"
+}
+
+// Categorias de afastamento
+const (
+	CategoriaSaude      = "saude"
+	CategoriaParticular = "particular"
+	CategoriaMissao     = "missao"
+	CategoriaOutro      = "outro"
+)
+
+// Dias retorna a duracao do afastamento em dias corridos (inclusive),
+// considerando a data de referencia para afastamentos em curso
+func (l *Licenca) Dias(referencia time.Time) int {
+	fim := referencia
+	if l.DataFim != nil {
+		fim = *l.DataFim
+	}
+	if fim.Before(l.DataInicio) {
+		return 0
+	}
+	return int(fim.Sub(l.DataInicio).Hours()/24) + 1
+}
+
+// Cobre indica se a data informada esta dentro do periodo de afastamento
+func (l *Licenca) Cobre(data time.Time) bool {
+	dia := time.Date(data.Year(), data.Month(), data.Day(), 0, 0, 0, 0, time.UTC)
+	if dia.Before(l.DataInicio) {
+		return false
+	}
+	return l.DataFim == nil || !dia.After(*l.DataFim)
+}
+
+// Categorizar classifica o afastamento pela sigla ou descricao da API
+func Categorizar(sigla, descricao string) string {
+	desc := strings.ToLower(descricao)
+	switch {
+	case sigla == "LS" || sigla == "LSAU" || strings.Contains(desc, "saude") || strings.Contains(desc, "saúde"):
+		return CategoriaSaude
+	case sigla == "LP" || sigla == "LIP" || strings.Contains(desc, "particular"):
+		return CategoriaParticular
+	case sigla == "MIS" || strings.Contains(desc, "missao") || strings.Contains(desc, "missão"):
+		return CategoriaMissao
+	default:
+		return CategoriaOutro
+	}
+}
+
+// LicencasPorCategoria agrega dias afastados por categoria
+type LicencasPorCategoria struct {
+	Categoria string `json:"categoria"`
+	Total     int    `json:"total"`
+	Dias      int    `json:"dias"`
+}
@@ -0,0 +1,75 @@
+package licenca
+
+import (
+	"testing"
+	"time"
+
+	senadoapi "github.com/Alzarus/to-de-olho/pkg/senado"
+)
+
+func data(valor string) time.Time {
+	t, _ := time.Parse("2006-01-02", valor)
+	return t
+}
+
+func TestCategorizar(t *testing.T) {
+	casos := []struct {
+		sigla, descricao, esperado string
+	}{
+		{"LS", "", CategoriaSaude},
+		{"", "Licença saúde", CategoriaSaude},
+		{"LP", "", CategoriaParticular},
+		{"", "Licença para tratar de interesses particulares", CategoriaParticular},
+		{"MIS", "", CategoriaMissao},
+		{"", "Missão autorizada no exterior", CategoriaMissao},
+		{"LG", "Licença gestante", CategoriaOutro},
+	}
+	for _, c := range casos {
+		if got := Categorizar(c.sigla, c.descricao); got != c.esperado {
+			t.Errorf("Categorizar(%q, %q) = %q, esperado %q", c.sigla, c.descricao, got, c.esperado)
+		}
+	}
+}
+
+func TestCobreEDias(t *testing.T) {
+	fim := data("2024-03-10")
+	l := Licenca{DataInicio: data("2024-03-01"), DataFim: &fim}
+
+	if !l.Cobre(time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)) {
+		t.Error("ultimo dia (com horario) deveria estar coberto")
+	}
+	if l.Cobre(data("2024-02-29")) || l.Cobre(data("2024-03-11")) {
+		t.Error("datas fora do periodo nao deveriam estar cobertas")
+	}
+	if dias := l.Dias(time.Now()); dias != 10 {
+		t.Errorf("esperado 10 dias, obtido %d", dias)
+	}
+
+	emCurso := Licenca{DataInicio: data("2024-03-01")}
+	if !emCurso.Cobre(data("2030-01-01")) {
+		t.Error("afastamento sem data fim deveria cobrir datas futuras")
+	}
+	if dias := emCurso.Dias(data("2024-03-05")); dias != 5 {
+		t.Errorf("esperado 5 dias ate a referencia, obtido %d", dias)
+	}
+}
+
+func TestConvertToModel(t *testing.T) {
+	l, ok := convertToModel(senadoapi.LicencaAPI{
+		Codigo:                   "99",
+		DataInicio:               "2024-05-02",
+		DataFim:                  "2024-06-30",
+		SiglaTipoAfastamento:     "LS",
+		DescricaoTipoAfastamento: "Licença saúde",
+	}, 7)
+	if !ok {
+		t.Fatal("esperado conversao valida")
+	}
+	if l.Chave != "7:LS:2024-05-02" || l.Categoria != CategoriaSaude || l.DataFim == nil {
+		t.Errorf("conversao inesperada: %+v", l)
+	}
+
+	if _, ok := convertToModel(senadoapi.LicencaAPI{SiglaTipoAfastamento: "LS"}, 7); ok {
+		t.Error("afastamento sem data de inicio deveria ser descartado")
+	}
+}
@@ -0,0 +1,43 @@
+package licenca
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository encapsula operacoes de banco de dados para Licenca
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository cria um novo repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// FindBySenadorID retorna os afastamentos de um senador, mais recentes primeiro
+func (r *Repository) FindBySenadorID(senadorID int) ([]Licenca, error) {
+	var licencas []Licenca
+	err := r.db.Where("senador_id = ?", senadorID).
+		Order("data_inicio DESC").
+		Find(&licencas).Error
+	return licencas, err
+}
+
+// UpsertBatch insere ou atualiza afastamentos pela chave natural
+func (r *Repository) UpsertBatch(licencas []Licenca) error {
+	if len(licencas) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chave"}},
+		DoUpdates: clause.AssignmentColumns([]string{"codigo", "descricao_tipo", "categoria", "data_fim", "updated_at"}),
+	}).CreateInBatches(licencas, 100).Error
+}
+
+// Count retorna total de afastamentos no banco
+func (r *Repository) Count() (int64, error) {
+	var count int64
+	result := r.db.Model(&Licenca{}).Count(&count)
+	return count, result.Error
+}
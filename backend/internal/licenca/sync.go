@@ -0,0 +1,99 @@
+package licenca
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Alzarus/to-de-olho/internal/senador"
+	"github.com/Alzarus/to-de-olho/internal/utils"
+	senadoapi "github.com/Alzarus/to-de-olho/pkg/senado"
+)
+
+// SyncService gerencia sincronizacao de licencas e afastamentos
+type SyncService struct {
+	repo        *Repository
+	senadorRepo *senador.Repository
+	client      *senadoapi.LegisClient
+}
+
+// NewSyncService cria um novo servico de sincronizacao
+func NewSyncService(repo *Repository, senadorRepo *senador.Repository, client *senadoapi.LegisClient) *SyncService {
+	return &SyncService{
+		repo:        repo,
+		senadorRepo: senadorRepo,
+		client:      client,
+	}
+}
+
+// SyncFromAPI busca afastamentos de todos os senadores em exercicio desde o
+// inicio da legislatura anterior (cobre o mandato de 8 anos)
+func (s *SyncService) SyncFromAPI(ctx context.Context) error {
+	slog.Info("iniciando sync de licencas")
+
+	senadores, err := s.senadorRepo.FindAll(false)
+	if err != nil {
+		return err
+	}
+
+	desde := time.Date(utils.GetInicioLegislaturaAtual()-4, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	var totalLicencas, totalSenadores int
+	for _, sen := range senadores {
+		count, err := s.SyncSenador(ctx, sen, desde)
+		if err != nil {
+			slog.Warn("falha ao buscar licencas", "senador", sen.Nome, "error", err)
+			continue
+		}
+		totalLicencas += count
+		totalSenadores++
+	}
+
+	slog.Info("sync de licencas concluido", "senadores", totalSenadores, "licencas", totalLicencas)
+	return nil
+}
+
+// SyncSenador busca afastamentos de um senador a partir da data informada
+func (s *SyncService) SyncSenador(ctx context.Context, sen senador.Senador, desde time.Time) (int, error) {
+	licencasAPI, err := s.client.ListarLicencasParlamentar(ctx, sen.CodigoParlamentar, desde)
+	if err != nil {
+		return 0, err
+	}
+
+	licencas := make([]Licenca, 0, len(licencasAPI))
+	vistas := make(map[string]bool)
+	for _, l := range licencasAPI {
+		licenca, ok := convertToModel(l, sen.ID)
+		if !ok || vistas[licenca.Chave] {
+			continue
+		}
+		vistas[licenca.Chave] = true
+		licencas = append(licencas, licenca)
+	}
+
+	if err := s.repo.UpsertBatch(licencas); err != nil {
+		return 0, err
+	}
+
+	return len(licencas), nil
+}
+
+// convertToModel converte um afastamento da API; retorna false sem data de inicio valida
+func convertToModel(l senadoapi.LicencaAPI, senadorID int) (Licenca, bool) {
+	inicio := utils.ParseDataISO(l.DataInicio)
+	if inicio == nil {
+		return Licenca{}, false
+	}
+
+	return Licenca{
+		Chave:         fmt.Sprintf("%d:%s:%s", senadorID, l.SiglaTipoAfastamento, inicio.Format("2006-01-02")),
+		SenadorID:     senadorID,
+		Codigo:        l.Codigo,
+		SiglaTipo:     l.SiglaTipoAfastamento,
+		DescricaoTipo: l.DescricaoTipoAfastamento,
+		Categoria:     Categorizar(l.SiglaTipoAfastamento, l.DescricaoTipoAfastamento),
+		DataInicio:    *inicio,
+		DataFim:       utils.ParseDataISO(l.DataFim),
+	}, true
+}
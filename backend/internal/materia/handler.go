@@ -0,0 +1,111 @@
+package materia
+
+import (
+	"net/http"
+
+	"github.com/Alzarus/to-de-olho/internal/proposicao"
+	"github.com/Alzarus/to-de-olho/internal/votacao"
+	"github.com/gin-gonic/gin"
+)
+
+// Handler expoe a visao centrada na materia, cruzando proposicoes, votacoes e tramitacao
+type Handler struct {
+	proposicaoRepo *proposicao.Repository
+	votacaoRepo    *votacao.Repository
+}
+
+// NewHandler cria um novo handler de materias
+func NewHandler(proposicaoRepo *proposicao.Repository, votacaoRepo *votacao.Repository) *Handler {
+	return &Handler{
+		proposicaoRepo: proposicaoRepo,
+		votacaoRepo:    votacaoRepo,
+	}
+}
+
+// GetMateria godoc
+// @Summary Retorna uma materia com proposicoes, votacoes nominais, placar por partido e tramitacao
+// @Tags materias
+// @Produce json
+// @Param sigla path string true "Sigla da materia (PEC, PL, PLP...)"
+// @Param numero path string true "Numero da materia"
+// @Param ano path int true "Ano da materia"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/materias/{sigla}/{numero}/{ano} [get]
+func (h *Handler) GetMateria(c *gin.Context) {
+	id, err := NovaIdentificacao(c.Param("sigla"), c.Param("numero"), c.Param("ano"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	proposicoes, err := h.proposicaoRepo.FindByIdentificacao(id.Sigla, id.Numero, id.Ano)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar proposicoes"})
+		return
+	}
+
+	sessoes, err := h.votacaoRepo.FindSessoesPorMateria(id.Sigla, id.Numero, id.Ano)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar votacoes"})
+		return
+	}
+
+	if len(proposicoes) == 0 && len(sessoes) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "materia nao encontrada"})
+		return
+	}
+
+	votacoes := make([]SessaoMateria, 0, len(sessoes))
+	for _, s := range sessoes {
+		votos, err := h.votacaoRepo.FindVotosBySessaoID(s.SessaoID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar votos"})
+			return
+		}
+		votacoes = append(votacoes, NovaSessaoMateria(s, votos))
+	}
+
+	resposta := gin.H{
+		"identificacao":  id.String(),
+		"sigla":          id.Sigla,
+		"numero":         id.Numero,
+		"ano":            id.Ano,
+		"proposicoes":    proposicoes,
+		"votacoes":       votacoes,
+		"total_votacoes": len(votacoes),
+	}
+
+	// Tramitacao: o historico e unico por codigo de materia; usa a primeira proposicao encontrada
+	if len(proposicoes) > 0 {
+		p := proposicoes[0]
+		tramitacao, err := h.proposicaoRepo.FindTramitacao(p.CodigoMateria)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar tramitacao"})
+			return
+		}
+		autorias, err := h.proposicaoRepo.FindAutorias(p.CodigoMateria)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar autorias"})
+			return
+		}
+
+		status := gin.H{
+			"codigo_materia":     p.CodigoMateria,
+			"situacao_atual":     p.SituacaoAtual,
+			"estagio_tramitacao": p.EstagioTramitacao,
+			"norma_gerada":       p.NormaGerada,
+			"atualizada_em":      p.TramitacaoAtualizadaEm,
+			"historico":          tramitacao,
+		}
+		if len(tramitacao) > 0 {
+			ultimo := tramitacao[len(tramitacao)-1]
+			status["ultimo_local"] = ultimo.SiglaLocal
+			status["ultima_acao"] = ultimo.Acao
+			status["ultima_movimentacao"] = ultimo.Data
+		}
+		resposta["tramitacao"] = status
+		resposta["autorias"] = autorias
+	}
+
+	c.JSON(http.StatusOK, resposta)
+}
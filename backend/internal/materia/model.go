@@ -0,0 +1,71 @@
+package materia
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Alzarus/to-de-olho/internal/votacao"
+)
+
+var (
+	siglaValida  = regexp.MustCompile(`^[A-Za-z]{2,6}$`)
+	numeroValido = regexp.MustCompile(`^[0-9]{1,6}$`)
+)
+
+// Identificacao identifica uma materia por sigla, numero e ano (ex: PEC 10/2024)
+type Identificacao struct {
+	Sigla  string `json:"sigla"`
+	Numero string `json:"numero"`
+	Ano    int    `json:"ano"`
+}
+
+// NovaIdentificacao valida e normaliza os parametros da rota /materias/:sigla/:numero/:ano
+func NovaIdentificacao(sigla, numero, ano string) (Identificacao, error) {
+	if !siglaValida.MatchString(sigla) {
+		return Identificacao{}, errors.New("sigla invalida")
+	}
+	numero = strings.TrimLeft(numero, "0")
+	if !numeroValido.MatchString(numero) {
+		return Identificacao{}, errors.New("numero invalido")
+	}
+	a, err := strconv.Atoi(ano)
+	if err != nil || a < 1900 || a > 2100 {
+		return Identificacao{}, errors.New("ano invalido")
+	}
+	return Identificacao{Sigla: strings.ToUpper(sigla), Numero: numero, Ano: a}, nil
+}
+
+// String retorna a identificacao no formato usado pelo Senado ("PEC 10/2024")
+func (i Identificacao) String() string {
+	return fmt.Sprintf("%s %s/%d", i.Sigla, i.Numero, i.Ano)
+}
+
+// SessaoMateria resume uma sessao de votacao da materia
+type SessaoMateria struct {
+	SessaoID  string           `json:"sessao_id"`
+	Data      time.Time        `json:"data"`
+	Descricao string           `json:"descricao,omitempty"`
+	Secreta   bool             `json:"secreta"`
+	Resultado string           `json:"resultado,omitempty"`
+	Placar    votacao.Placar   `json:"placar"`
+	Partidos  []votacao.Placar `json:"partidos"`
+}
+
+// NovaSessaoMateria resume a sessao com o placar total e por partido (na data da
+// votacao), seguindo as regras de votacao.ResumirSessao
+func NovaSessaoMateria(s votacao.Sessao, votos []votacao.Votacao) SessaoMateria {
+	resumo := votacao.ResumirSessao(s, votos)
+	return SessaoMateria{
+		SessaoID:  s.SessaoID,
+		Data:      s.Data,
+		Descricao: s.DescricaoVotacao,
+		Secreta:   s.Secreta,
+		Resultado: resumo.Resultado,
+		Placar:    resumo.Placar,
+		Partidos:  resumo.PorPartido,
+	}
+}
@@ -0,0 +1,79 @@
+package materia
+
+import (
+	"testing"
+
+	"github.com/Alzarus/to-de-olho/internal/votacao"
+)
+
+func TestNovaIdentificacao(t *testing.T) {
+	id, err := NovaIdentificacao("pec", "010", "2024")
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if id.String() != "PEC 10/2024" {
+		t.Errorf("identificacao = %q; esperado %q", id.String(), "PEC 10/2024")
+	}
+
+	invalidos := [][3]string{
+		{"PE.C", "10", "2024"},
+		{"PEC", "abc", "2024"},
+		{"PEC", "000", "2024"},
+		{"PEC", "10", "24x"},
+	}
+	for _, p := range invalidos {
+		if _, err := NovaIdentificacao(p[0], p[1], p[2]); err == nil {
+			t.Errorf("%v: esperado erro", p)
+		}
+	}
+}
+
+func TestNovaSessaoMateria(t *testing.T) {
+	votos := []votacao.Votacao{
+		{SenadorPartido: "PT", Voto: "Sim"},
+		{SenadorPartido: "PT", Voto: "Sim"},
+		{SenadorPartido: "PT", Voto: votacao.VotoNCom},
+		{SenadorPartido: "PL", Voto: "Nao"},
+		{SenadorPartido: "PL", Voto: "Obstrucao"},
+		{SenadorPartido: "MDB", Voto: "Abstencao"},
+		{SenadorPartido: "", Voto: votacao.VotoLicenca},
+	}
+
+	sm := NovaSessaoMateria(votacao.Sessao{SessaoID: "1_2024", Materia: "PL 5/2024"}, votos)
+
+	if sm.Placar.Total != 7 || sm.Placar.Sim != 2 || sm.Placar.Nao != 1 || sm.Placar.Ausentes != 2 {
+		t.Errorf("placar geral inesperado: %+v", sm.Placar)
+	}
+	if sm.Resultado != votacao.ResultadoAprovada {
+		t.Errorf("resultado deduzido do placar = %q; esperado %q", sm.Resultado, votacao.ResultadoAprovada)
+	}
+	if len(sm.Partidos) != 4 {
+		t.Fatalf("partidos = %d; esperado 4", len(sm.Partidos))
+	}
+	if sm.Partidos[0].Grupo != "PT" || sm.Partidos[0].Sim != 2 || sm.Partidos[0].Ausentes != 1 {
+		t.Errorf("maior bancada inesperada: %+v", sm.Partidos[0])
+	}
+	if sm.Partidos[1].Grupo != "PL" || sm.Partidos[1].Obstrucao != 1 {
+		t.Errorf("segunda bancada inesperada: %+v", sm.Partidos[1])
+	}
+	// Empate em tamanho ordena pela sigla
+	if sm.Partidos[2].Grupo != "MDB" || sm.Partidos[3].Grupo != "S/Partido" {
+		t.Errorf("ordem de desempate inesperada: %s, %s", sm.Partidos[2].Grupo, sm.Partidos[3].Grupo)
+	}
+
+	// Votacao secreta: totais oficiais e sem divisao por partido
+	secreta := votacao.Sessao{Materia: "PEC 10/2024", Secreta: true, TotalSim: 45, TotalNao: 20}
+	sm = NovaSessaoMateria(secreta, votos)
+	if sm.Placar.Sim != 45 || sm.Placar.Nao != 20 || len(sm.Partidos) != 0 || sm.Partidos == nil {
+		t.Errorf("votacao secreta inesperada: %+v", sm)
+	}
+	if sm.Resultado != votacao.ResultadoRejeitada {
+		t.Errorf("PEC abaixo do quorum = %q; esperado %q", sm.Resultado, votacao.ResultadoRejeitada)
+	}
+
+	// Resultado informado pela API prevalece
+	secreta.Resultado = votacao.ResultadoAprovada
+	if sm = NovaSessaoMateria(secreta, votos); sm.Resultado != votacao.ResultadoAprovada {
+		t.Errorf("resultado da API ignorado: %q", sm.Resultado)
+	}
+}
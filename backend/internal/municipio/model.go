@@ -0,0 +1,61 @@
+package municipio
+
+import "time"
+
+// Niveis de uma localidade resolvida
+const (
+	NivelMunicipio       = "municipio"
+	NivelUF              = "uf"
+	NivelRegiao          = "regiao"
+	NivelNacional        = "nacional"
+	NivelExterior        = "exterior"
+	NivelNaoIdentificado = "nao_identificado"
+)
+
+// UF e uma unidade da federacao da tabela de referencia do IBGE
+type UF struct {
+	Codigo string `gorm:"primaryKey;size:2" json:"codigo"` // Codigo IBGE (2 digitos)
+	Sigla  string `gorm:"uniqueIndex;size:2;not null" json:"sigla"`
+	Nome   string `json:"nome"`
+	Regiao string `json:"regiao"` // Norte, Nordeste, Sudeste, Sul, Centro-Oeste
+}
+
+// TableName define o nome da tabela
+func (UF) TableName() string {
+	return "ibge_ufs"
+}
+
+// Municipio e um municipio da tabela de referencia do IBGE
+type Municipio struct {
+	CodigoIBGE string    `gorm:"primaryKey;size:7" json:"codigo_ibge"`
+	Nome       string    `gorm:"index" json:"nome"`
+	UF         string    `gorm:"index;size:2;not null" json:"uf"` // Sigla
+	Capital    bool      `json:"capital"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// TableName define o nome da tabela
+func (Municipio) TableName() string {
+	return "ibge_municipios"
+}
+
+// Populacao e a estimativa populacional de um municipio em um ano (IBGE)
+type Populacao struct {
+	CodigoIBGE string `gorm:"primaryKey;size:7" json:"codigo_ibge"`
+	Ano        int    `gorm:"primaryKey" json:"ano"`
+	Populacao  int64  `json:"populacao"`
+}
+
+// TableName define o nome da tabela
+func (Populacao) TableName() string {
+	return "ibge_populacao"
+}
+
+// Localizacao e o resultado da resolucao de um texto de localidade. CodigoIBGE tem
+// 7 digitos para municipios, 2 para UFs e fica vazio nos demais niveis.
+type Localizacao struct {
+	Nivel      string `json:"nivel"`
+	CodigoIBGE string `json:"codigo_ibge,omitempty"`
+	UF         string `json:"uf,omitempty"`
+	Municipio  string `json:"municipio,omitempty"`
+}
@@ -0,0 +1,200 @@
+package municipio
+
+import (
+	"embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// MinimoMunicipiosReferencia e o numero de municipios a partir do qual a tabela de
+// referencia e considerada completa (a DTB do IBGE tem 5.570 municipios)
+const MinimoMunicipiosReferencia = 5500
+
+// dados contem a tabela de referencia distribuida com o binario. O CSV de municipios e
+// gerado a partir da DTB do IBGE com cmd/gerar_municipios; o sync com a API do IBGE
+// (SyncFromIBGE) atualiza a tabela gravada.
+//
+//go:embed dados/*.csv
+var dados embed.FS
+
+// ReferenciaEmbutida le as UFs e os municipios do CSV distribuido com o binario
+func ReferenciaEmbutida() ([]UF, []Municipio, error) {
+	arqUFs, err := dados.Open("dados/ufs.csv")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer arqUFs.Close()
+	ufs, err := LerUFsCSV(arqUFs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ufs.csv: %w", err)
+	}
+
+	arqMunicipios, err := dados.Open("dados/municipios.csv")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer arqMunicipios.Close()
+	municipios, err := LerMunicipiosCSV(arqMunicipios)
+	if err != nil {
+		return nil, nil, fmt.Errorf("municipios.csv: %w", err)
+	}
+
+	return ufs, municipios, nil
+}
+
+// LerUFsCSV le um CSV codigo_uf;sigla;nome;regiao com cabecalho
+func LerUFsCSV(r io.Reader) ([]UF, error) {
+	linhas, err := lerCSV(r, 4)
+	if err != nil {
+		return nil, err
+	}
+	ufs := make([]UF, 0, len(linhas))
+	for _, l := range linhas {
+		ufs = append(ufs, UF{Codigo: l[0], Sigla: strings.ToUpper(l[1]), Nome: l[2], Regiao: l[3]})
+	}
+	return ufs, nil
+}
+
+// LerMunicipiosCSV le um CSV codigo_ibge;nome;uf;capital com cabecalho
+func LerMunicipiosCSV(r io.Reader) ([]Municipio, error) {
+	linhas, err := lerCSV(r, 4)
+	if err != nil {
+		return nil, err
+	}
+	municipios := make([]Municipio, 0, len(linhas))
+	for _, l := range linhas {
+		if len(l[0]) != 7 {
+			return nil, fmt.Errorf("codigo IBGE invalido: %q", l[0])
+		}
+		municipios = append(municipios, Municipio{
+			CodigoIBGE: l[0],
+			Nome:       l[1],
+			UF:         strings.ToUpper(l[2]),
+			Capital:    l[3] == "1",
+		})
+	}
+	return municipios, nil
+}
+
+// EscreverMunicipiosCSV grava os municipios no formato lido por LerMunicipiosCSV
+func EscreverMunicipiosCSV(w io.Writer, municipios []Municipio) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = ';'
+	if err := writer.Write([]string{"codigo_ibge", "nome", "uf", "capital"}); err != nil {
+		return err
+	}
+	for _, m := range municipios {
+		capital := "0"
+		if m.Capital {
+			capital = "1"
+		}
+		if err := writer.Write([]string{m.CodigoIBGE, m.Nome, m.UF, capital}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// LerPopulacaoCSV le as estimativas de populacao de um ano. Aceita o formato simples
+// codigo_ibge;populacao ou a planilha do IBGE exportada em CSV (COD. UF, COD. MUNIC e
+// POPULACAO ESTIMADA), ignorando linhas de titulo e notas de rodape.
+func LerPopulacaoCSV(r io.Reader, ano int) ([]Populacao, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = ';'
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	linhas, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Localiza o cabecalho; a planilha do IBGE tem linhas de titulo antes dele
+	colCodigo, colUF, colMunic, colPopulacao := -1, -1, -1, -1
+	inicio := -1
+	for i, linha := range linhas {
+		for j, valor := range linha {
+			switch chave := normalizar(valor); {
+			case chave == "CODIGO IBGE" || chave == "CODIGO_IBGE" || chave == "COD IBGE":
+				colCodigo = j
+			case chave == "COD UF":
+				colUF = j
+			case chave == "COD MUNIC":
+				colMunic = j
+			case strings.HasPrefix(chave, "POPULACAO"):
+				colPopulacao = j
+			}
+		}
+		if colPopulacao >= 0 && (colCodigo >= 0 || (colUF >= 0 && colMunic >= 0)) {
+			inicio = i + 1
+			break
+		}
+		colCodigo, colUF, colMunic, colPopulacao = -1, -1, -1, -1
+	}
+	if inicio < 0 {
+		return nil, fmt.Errorf("cabecalho de populacao nao encontrado")
+	}
+
+	var populacoes []Populacao
+	for _, linha := range linhas[inicio:] {
+		var codigo string
+		if colCodigo >= 0 {
+			codigo = celula(linha, colCodigo)
+		} else {
+			uf, munic := celula(linha, colUF), celula(linha, colMunic)
+			if len(munic) < 5 {
+				munic = strings.Repeat("0", 5-len(munic)) + munic
+			}
+			codigo = uf + munic
+		}
+		populacao, ok := parsePopulacao(celula(linha, colPopulacao))
+		if len(codigo) != 7 || !ok {
+			continue // Linhas de nota ou totais
+		}
+		populacoes = append(populacoes, Populacao{CodigoIBGE: codigo, Ano: ano, Populacao: populacao})
+	}
+	return populacoes, nil
+}
+
+// celula retorna a coluna da linha sem espacos, ou vazio se nao existir
+func celula(linha []string, indice int) string {
+	if indice < 0 || indice >= len(linha) {
+		return ""
+	}
+	return strings.TrimSpace(linha[indice])
+}
+
+// parsePopulacao le numeros como "1.234.567" ou "12.345(1)", descartando separadores
+// de milhar e marcas de nota de rodape
+func parsePopulacao(valor string) (int64, bool) {
+	if i := strings.Index(valor, "("); i >= 0 {
+		valor = valor[:i]
+	}
+	valor = strings.NewReplacer(".", "", " ", "", "\u00a0", "").Replace(valor)
+	n, err := strconv.ParseInt(valor, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+// lerCSV le as linhas (sem o cabecalho) de um CSV separado por ponto e virgula
+func lerCSV(r io.Reader, colunas int) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = ';'
+	reader.FieldsPerRecord = colunas
+	reader.TrimLeadingSpace = true
+
+	linhas, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(linhas) == 0 {
+		return nil, nil
+	}
+	return linhas[1:], nil
+}
@@ -0,0 +1,83 @@
+package municipio
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLerPopulacaoCSV(t *testing.T) {
+	casos := []struct {
+		nome     string
+		csv      string
+		esperado map[string]int64 // nil quando o CSV deve ser rejeitado
+	}{
+		{
+			nome: "formato IBGE",
+			csv: `ESTIMATIVAS DA POPULAÇÃO RESIDENTE NOS MUNICÍPIOS BRASILEIROS;;;;
+;;;;
+UF;COD. UF;COD. MUNIC;NOME DO MUNICÍPIO;POPULAÇÃO ESTIMADA
+BA;29;27408;Salvador;2.568.928
+BA;29;10800;Feira de Santana;656.000(1)
+RO;11;205;Porto Velho;460.434
+;;;;
+(1) Populacao judicial;;;;
+`,
+			esperado: map[string]int64{"2927408": 2568928, "2910800": 656000, "1100205": 460434},
+		},
+		{
+			nome:     "formato simples",
+			csv:      "codigo_ibge;populacao\n3550308;11451999\n5300108;2817068\n",
+			esperado: map[string]int64{"3550308": 11451999, "5300108": 2817068},
+		},
+		{
+			nome: "sem cabecalho de populacao",
+			csv:  "a;b\n1;2\n",
+		},
+	}
+
+	for _, c := range casos {
+		populacoes, err := LerPopulacaoCSV(strings.NewReader(c.csv), 2024)
+		if c.esperado == nil {
+			if err == nil {
+				t.Errorf("%s: esperado erro", c.nome)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: erro inesperado: %v", c.nome, err)
+			continue
+		}
+		if len(populacoes) != len(c.esperado) {
+			t.Errorf("%s: esperado %d municipios, obtido %d: %+v", c.nome, len(c.esperado), len(populacoes), populacoes)
+			continue
+		}
+		for _, p := range populacoes {
+			if p.Ano != 2024 || c.esperado[p.CodigoIBGE] != p.Populacao {
+				t.Errorf("%s: populacao incorreta: %+v", c.nome, p)
+			}
+		}
+	}
+}
+
+func TestEscreverMunicipiosCSV(t *testing.T) {
+	municipios := []Municipio{
+		{CodigoIBGE: "2927408", Nome: "Salvador", UF: "BA", Capital: true},
+		{CodigoIBGE: "3548906", Nome: "Santa Bárbara d'Oeste", UF: "SP"},
+	}
+	var b strings.Builder
+	if err := EscreverMunicipiosCSV(&b, municipios); err != nil {
+		t.Fatal(err)
+	}
+	lidos, err := LerMunicipiosCSV(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lidos) != len(municipios) {
+		t.Fatalf("esperado %d municipios, obtido %d", len(municipios), len(lidos))
+	}
+	for i := range municipios {
+		if lidos[i] != municipios[i] {
+			t.Errorf("municipio %d: %+v, esperado %+v", i, lidos[i], municipios[i])
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package municipio
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository gerencia a tabela de referencia de UFs e municipios
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository cria um novo repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// UpsertUFs grava as UFs
+func (r *Repository) UpsertUFs(ufs []UF) error {
+	if len(ufs) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "codigo"}},
+		DoUpdates: clause.AssignmentColumns([]string{"sigla", "nome", "regiao"}),
+	}).Create(&ufs).Error
+}
+
+// UpsertMunicipios grava os municipios. A marcacao de capital so e atualizada quando
+// verdadeira, pois a API do IBGE nao informa capitais.
+func (r *Repository) UpsertMunicipios(municipios []Municipio) error {
+	if len(municipios) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "codigo_ibge"}},
+		DoUpdates: clause.Set{
+			{Column: clause.Column{Name: "nome"}, Value: clause.Column{Table: "excluded", Name: "nome"}},
+			{Column: clause.Column{Name: "uf"}, Value: clause.Column{Table: "excluded", Name: "uf"}},
+			{Column: clause.Column{Name: "capital"}, Value: gorm.Expr("ibge_municipios.capital OR excluded.capital")},
+			{Column: clause.Column{Name: "updated_at"}, Value: clause.Column{Table: "excluded", Name: "updated_at"}},
+		},
+	}).CreateInBatches(municipios, 500).Error
+}
+
+// CarregarReferencia grava a tabela distribuida com o binario
+func (r *Repository) CarregarReferencia() (int, error) {
+	ufs, municipios, err := ReferenciaEmbutida()
+	if err != nil {
+		return 0, err
+	}
+	if err := r.UpsertUFs(ufs); err != nil {
+		return 0, err
+	}
+	if err := r.UpsertMunicipios(municipios); err != nil {
+		return 0, err
+	}
+	return len(municipios), nil
+}
+
+// FindAllUFs retorna todas as UFs
+func (r *Repository) FindAllUFs() ([]UF, error) {
+	var ufs []UF
+	err := r.db.Order("sigla").Find(&ufs).Error
+	return ufs, err
+}
+
+// FindAllMunicipios retorna todos os municipios
+func (r *Repository) FindAllMunicipios() ([]Municipio, error) {
+	var municipios []Municipio
+	err := r.db.Order("uf, nome").Find(&municipios).Error
+	return municipios, err
+}
+
+// ContarMunicipios retorna o numero de municipios gravados
+func (r *Repository) ContarMunicipios() (int64, error) {
+	var total int64
+	err := r.db.Model(&Municipio{}).Count(&total).Error
+	return total, err
+}
+
+// FindByCodigo busca um municipio pelo codigo IBGE
+func (r *Repository) FindByCodigo(codigo string) (*Municipio, error) {
+	var m Municipio
+	if err := r.db.First(&m, "codigo_ibge = ?", codigo).Error; err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// NewResolver monta um resolver com a tabela de referencia gravada
+func (r *Repository) NewResolver() (*Resolver, error) {
+	ufs, err := r.FindAllUFs()
+	if err != nil {
+		return nil, err
+	}
+	municipios, err := r.FindAllMunicipios()
+	if err != nil {
+		return nil, err
+	}
+	return NewResolver(ufs, municipios), nil
+}
+
+// FindByCodigos indexa os municipios informados pelo codigo IBGE
+func (r *Repository) FindByCodigos(codigos []string) (map[string]Municipio, error) {
+	porCodigo := make(map[string]Municipio, len(codigos))
+	if len(codigos) == 0 {
+		return porCodigo, nil
+	}
+	var municipios []Municipio
+	if err := r.db.Where("codigo_ibge IN ?", codigos).Find(&municipios).Error; err != nil {
+		return nil, err
+	}
+	for _, m := range municipios {
+		porCodigo[m.CodigoIBGE] = m
+	}
+	return porCodigo, nil
+}
+
+// FindUFBySigla busca uma UF pela sigla
+func (r *Repository) FindUFBySigla(sigla string) (*UF, error) {
+	var uf UF
+	if err := r.db.First(&uf, "sigla = ?", sigla).Error; err != nil {
+		return nil, err
+	}
+	return &uf, nil
+}
+
+// UpsertPopulacao grava estimativas de populacao
+func (r *Repository) UpsertPopulacao(populacoes []Populacao) error {
+	if len(populacoes) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "codigo_ibge"}, {Name: "ano"}},
+		DoUpdates: clause.AssignmentColumns([]string{"populacao"}),
+	}).CreateInBatches(populacoes, 500).Error
+}
+
+// AnoPopulacaoReferencia retorna o ano de estimativa mais recente ate o ano informado
+// (ano 0 = mais recente disponivel). Sem estimativa anterior, usa a mais antiga.
+// Retorna 0 quando nao ha estimativas.
+func (r *Repository) AnoPopulacaoReferencia(ano int) (int, error) {
+	var referencia *int
+	query := r.db.Model(&Populacao{}).Select("MAX(ano)")
+	if ano > 0 {
+		query = query.Where("ano <= ?", ano)
+	}
+	if err := query.Scan(&referencia).Error; err != nil {
+		return 0, err
+	}
+	if referencia != nil {
+		return *referencia, nil
+	}
+	if err := r.db.Model(&Populacao{}).Select("MIN(ano)").Scan(&referencia).Error; err != nil {
+		return 0, err
+	}
+	if referencia == nil {
+		return 0, nil
+	}
+	return *referencia, nil
+}
+
+// FindPopulacao retorna a populacao dos municipios informados no ano de estimativa
+func (r *Repository) FindPopulacao(codigos []string, ano int) (map[string]int64, error) {
+	porCodigo := make(map[string]int64, len(codigos))
+	if len(codigos) == 0 {
+		return porCodigo, nil
+	}
+	var populacoes []Populacao
+	if err := r.db.Where("ano = ? AND codigo_ibge IN ?", ano, codigos).Find(&populacoes).Error; err != nil {
+		return nil, err
+	}
+	for _, p := range populacoes {
+		porCodigo[p.CodigoIBGE] = p.Populacao
+	}
+	return porCodigo, nil
+}
+
+// SomaPopulacaoUF soma a populacao estimada dos municipios de uma UF (codigo IBGE de
+// 2 digitos) no ano de estimativa
+func (r *Repository) SomaPopulacaoUF(codigoUF string, ano int) (int64, error) {
+	var total int64
+	err := r.db.Model(&Populacao{}).
+		Select("COALESCE(SUM(populacao), 0)").
+		Where("ano = ? AND codigo_ibge LIKE ?", ano, codigoUF+"%").
+		Scan(&total).Error
+	return total, err
+}
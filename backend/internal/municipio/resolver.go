@@ -0,0 +1,128 @@
+package municipio
+
+import (
+	"strings"
+
+	"github.com/Alzarus/to-de-olho/internal/utils"
+)
+
+// regioes lista os nomes normalizados das grandes regioes
+var regioes = map[string]string{
+	"NORTE":        "Norte",
+	"NORDESTE":     "Nordeste",
+	"SUDESTE":      "Sudeste",
+	"SUL":          "Sul",
+	"CENTRO OESTE": "Centro-Oeste",
+}
+
+// Resolver converte textos de localidade ("SALVADOR - BA", "BAHIA (UF)", "NACIONAL")
+// em codigos IBGE a partir da tabela de referencia
+type Resolver struct {
+	ufsPorChave map[string]UF          // Sigla e nome normalizado
+	porNomeUF   map[string]Municipio   // "NOME|UF"
+	porNome     map[string][]Municipio // Nome normalizado, em todas as UFs
+}
+
+// NewResolver indexa as UFs e os municipios informados
+func NewResolver(ufs []UF, municipios []Municipio) *Resolver {
+	r := &Resolver{
+		ufsPorChave: make(map[string]UF, len(ufs)*2),
+		porNomeUF:   make(map[string]Municipio, len(municipios)),
+		porNome:     make(map[string][]Municipio, len(municipios)),
+	}
+	for _, uf := range ufs {
+		r.ufsPorChave[uf.Sigla] = uf
+		r.ufsPorChave[normalizar(uf.Nome)] = uf
+	}
+	for _, m := range municipios {
+		nome := normalizar(m.Nome)
+		r.porNomeUF[nome+"|"+m.UF] = m
+		r.porNome[nome] = append(r.porNome[nome], m)
+	}
+	return r
+}
+
+// Localizar identifica o nivel e o codigo IBGE de um texto de localidade. Municipios
+// sem UF so sao resolvidos quando o nome e unico no pais.
+func (r *Resolver) Localizar(localidade string) Localizacao {
+	texto := strings.Join(strings.Fields(strings.ToUpper(utils.RemoverAcentos(localidade))), " ")
+	switch {
+	case texto == "":
+		return Localizacao{Nivel: NivelNaoIdentificado}
+	case strings.Contains(texto, "NACIONAL"):
+		return Localizacao{Nivel: NivelNacional}
+	case strings.Contains(texto, "EXTERIOR"):
+		return Localizacao{Nivel: NivelExterior}
+	}
+
+	// Sufixos explicitos: "BAHIA (UF)", "NORDESTE (REGIAO)"
+	if nome, ok := strings.CutSuffix(texto, "(UF)"); ok {
+		if uf, ok := r.ufsPorChave[normalizar(nome)]; ok {
+			return localizacaoUF(uf)
+		}
+		return Localizacao{Nivel: NivelNaoIdentificado}
+	}
+	if nome, ok := strings.CutSuffix(texto, "(REGIAO)"); ok {
+		if _, ok := regioes[normalizar(nome)]; ok {
+			return Localizacao{Nivel: NivelRegiao}
+		}
+		return Localizacao{Nivel: NivelNaoIdentificado}
+	}
+	if _, ok := regioes[normalizar(texto)]; ok {
+		return Localizacao{Nivel: NivelRegiao}
+	}
+
+	// Municipio com UF: "SALVADOR - BA", "SALVADOR/BA", "SALVADOR (BA)"
+	if nome, sigla, ok := separarUF(texto); ok {
+		if _, valida := r.ufsPorChave[sigla]; valida {
+			if m, ok := r.porNomeUF[normalizar(nome)+"|"+sigla]; ok {
+				return localizacaoMunicipio(m)
+			}
+			return Localizacao{Nivel: NivelNaoIdentificado, UF: sigla}
+		}
+	}
+
+	// Apenas o nome da UF ou a sigla
+	if uf, ok := r.ufsPorChave[normalizar(texto)]; ok {
+		return localizacaoUF(uf)
+	}
+
+	if candidatos := r.porNome[normalizar(texto)]; len(candidatos) == 1 {
+		return localizacaoMunicipio(candidatos[0])
+	}
+	return Localizacao{Nivel: NivelNaoIdentificado}
+}
+
+// separarUF separa o nome do municipio da sigla da UF no final do texto
+func separarUF(texto string) (nome, sigla string, ok bool) {
+	if strings.HasSuffix(texto, ")") {
+		if i := strings.LastIndex(texto, "("); i > 0 && len(texto)-i == 4 {
+			return strings.TrimSpace(texto[:i]), texto[i+1 : i+3], true
+		}
+	}
+	for _, sep := range []string{" - ", "/", "-"} {
+		if i := strings.LastIndex(texto, sep); i > 0 {
+			sigla := strings.TrimSpace(texto[i+len(sep):])
+			if len(sigla) == 2 {
+				return strings.TrimSpace(texto[:i]), sigla, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+func localizacaoUF(uf UF) Localizacao {
+	return Localizacao{Nivel: NivelUF, CodigoIBGE: uf.Codigo, UF: uf.Sigla}
+}
+
+func localizacaoMunicipio(m Municipio) Localizacao {
+	return Localizacao{Nivel: NivelMunicipio, CodigoIBGE: m.CodigoIBGE, UF: m.UF, Municipio: m.Nome}
+}
+
+// normalizar padroniza nomes para comparacao: maiusculas, sem acentos, com apostrofos
+// e hifens trocados por espaco ("Santa Bárbara d'Oeste" -> "SANTA BARBARA D OESTE")
+func normalizar(nome string) string {
+	nome = strings.ToUpper(utils.RemoverAcentos(nome))
+	nome = strings.NewReplacer("'", " ", "`", " ", "-", " ", ".", " ").Replace(nome)
+	return strings.Join(strings.Fields(nome), " ")
+}
@@ -0,0 +1,74 @@
+package municipio
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReferenciaEmbutida(t *testing.T) {
+	ufs, municipios, err := ReferenciaEmbutida()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ufs) != 27 {
+		t.Errorf("esperado 27 UFs, obtido %d", len(ufs))
+	}
+	capitais := 0
+	for _, m := range municipios {
+		if m.Capital {
+			capitais++
+		}
+	}
+	if capitais != 27 {
+		t.Errorf("esperado 27 capitais, obtido %d", capitais)
+	}
+}
+
+func TestLocalizar(t *testing.T) {
+	ufs, municipios, err := ReferenciaEmbutida()
+	if err != nil {
+		t.Fatalf("referencia embutida: %v", err)
+	}
+	extras, err := LerMunicipiosCSV(strings.NewReader(`codigo_ibge;nome;uf;capital
+3547304;Santa Bárbara d'Oeste;SP;0
+3515103;Embu-Guaçu;SP;0
+2910800;Feira de Santana;BA;0
+2927705;Santa Maria da Vitória;BA;0
+4316907;Santa Maria;RS;0
+2408953;Santa Maria;RN;0
+`))
+	if err != nil {
+		t.Fatalf("csv de teste: %v", err)
+	}
+	r := NewResolver(ufs, append(municipios, extras...))
+
+	casos := []struct {
+		localidade string
+		esperado   Localizacao
+	}{
+		{"SALVADOR - BA", Localizacao{Nivel: NivelMunicipio, CodigoIBGE: "2927408", UF: "BA", Municipio: "Salvador"}},
+		{"São Paulo/SP", Localizacao{Nivel: NivelMunicipio, CodigoIBGE: "3550308", UF: "SP", Municipio: "São Paulo"}},
+		{"FEIRA DE SANTANA (BA)", Localizacao{Nivel: NivelMunicipio, CodigoIBGE: "2910800", UF: "BA", Municipio: "Feira de Santana"}},
+		{"SANTA BARBARA D OESTE - SP", Localizacao{Nivel: NivelMunicipio, CodigoIBGE: "3547304", UF: "SP", Municipio: "Santa Bárbara d'Oeste"}},
+		{"EMBU-GUAÇU - SP", Localizacao{Nivel: NivelMunicipio, CodigoIBGE: "3515103", UF: "SP", Municipio: "Embu-Guaçu"}},
+		{"Santa Maria da Vitória", Localizacao{Nivel: NivelMunicipio, CodigoIBGE: "2927705", UF: "BA", Municipio: "Santa Maria da Vitória"}},
+		{"BAHIA (UF)", Localizacao{Nivel: NivelUF, CodigoIBGE: "29", UF: "BA"}},
+		{"ESPIRITO SANTO (UF)", Localizacao{Nivel: NivelUF, CodigoIBGE: "32", UF: "ES"}},
+		{"Distrito Federal", Localizacao{Nivel: NivelUF, CodigoIBGE: "53", UF: "DF"}},
+		{"NACIONAL", Localizacao{Nivel: NivelNacional}},
+		{"NORDESTE (REGIÃO)", Localizacao{Nivel: NivelRegiao}},
+		{"Centro-Oeste", Localizacao{Nivel: NivelRegiao}},
+		{"EXTERIOR", Localizacao{Nivel: NivelExterior}},
+		// Nome repetido em mais de uma UF nao e resolvido sem a sigla
+		{"SANTA MARIA", Localizacao{Nivel: NivelNaoIdentificado}},
+		{"SANTA MARIA - RS", Localizacao{Nivel: NivelMunicipio, CodigoIBGE: "4316907", UF: "RS", Municipio: "Santa Maria"}},
+		{"CIDADE INEXISTENTE - BA", Localizacao{Nivel: NivelNaoIdentificado, UF: "BA"}},
+		{"MÚLTIPLO", Localizacao{Nivel: NivelNaoIdentificado}},
+		{"", Localizacao{Nivel: NivelNaoIdentificado}},
+	}
+	for _, c := range casos {
+		if got := r.Localizar(c.localidade); got != c.esperado {
+			t.Errorf("Localizar(%q) = %+v, esperado %+v", c.localidade, got, c.esperado)
+		}
+	}
+}
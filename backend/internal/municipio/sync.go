@@ -0,0 +1,121 @@
+package municipio
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Alzarus/to-de-olho/pkg/ibge"
+)
+
+// SyncService atualiza a tabela de referencia com a API de localidades do IBGE
+type SyncService struct {
+	repo *Repository
+	ibge *ibge.Client
+}
+
+// NewSyncService cria um novo servico de sincronizacao
+func NewSyncService(repo *Repository, client *ibge.Client) *SyncService {
+	return &SyncService{repo: repo, ibge: client}
+}
+
+// SyncFromIBGE grava a tabela embutida e completa com todas as UFs e municipios do IBGE
+func (s *SyncService) SyncFromIBGE(ctx context.Context) error {
+	if _, err := s.repo.CarregarReferencia(); err != nil {
+		return fmt.Errorf("falha ao carregar referencia embutida: %w", err)
+	}
+
+	ufsAPI, err := s.ibge.GetUFs(ctx)
+	if err != nil {
+		return fmt.Errorf("erro API IBGE (estados): %w", err)
+	}
+	ufs := make([]UF, 0, len(ufsAPI))
+	for _, u := range ufsAPI {
+		ufs = append(ufs, convertUF(u))
+	}
+	if err := s.repo.UpsertUFs(ufs); err != nil {
+		return err
+	}
+
+	municipios, err := s.MunicipiosIBGE(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.UpsertMunicipios(municipios); err != nil {
+		return err
+	}
+
+	slog.Info("referencia de municipios atualizada", "ufs", len(ufs), "municipios", len(municipios))
+	return nil
+}
+
+// MunicipiosIBGE lista todos os municipios da API do IBGE no modelo local
+func (s *SyncService) MunicipiosIBGE(ctx context.Context) ([]Municipio, error) {
+	municipiosAPI, err := s.ibge.GetMunicipios(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erro API IBGE (municipios): %w", err)
+	}
+	municipios := make([]Municipio, 0, len(municipiosAPI))
+	for _, m := range municipiosAPI {
+		municipio, ok := convertMunicipio(m)
+		if !ok {
+			slog.Warn("municipio do IBGE sem UF ignorado", "codigo", m.ID, "nome", m.Nome)
+			continue
+		}
+		municipios = append(municipios, municipio)
+	}
+	return municipios, nil
+}
+
+// ReferenciaCompleta indica se a tabela gravada ja tem todos os municipios do pais
+func (s *SyncService) ReferenciaCompleta() (bool, error) {
+	total, err := s.repo.ContarMunicipios()
+	return total >= MinimoMunicipiosReferencia, err
+}
+
+// convertUF converte a UF da API para o modelo local
+func convertUF(u ibge.UFAPI) UF {
+	return UF{
+		Codigo: strconv.Itoa(u.ID),
+		Sigla:  strings.ToUpper(u.Sigla),
+		Nome:   u.Nome,
+		Regiao: u.Regiao.Nome,
+	}
+}
+
+// convertMunicipio converte o municipio da API para o modelo local
+func convertMunicipio(m ibge.MunicipioAPI) (Municipio, bool) {
+	uf := m.UF()
+	if uf.Sigla == "" || m.ID == 0 {
+		return Municipio{}, false
+	}
+	return Municipio{
+		CodigoIBGE: strconv.Itoa(m.ID),
+		Nome:       strings.TrimSpace(m.Nome),
+		UF:         strings.ToUpper(uf.Sigla),
+	}, true
+}
+
+// ImportarPopulacaoCSV grava as estimativas de populacao de um ano a partir do CSV
+// publicado pelo IBGE (ver LerPopulacaoCSV)
+func (s *SyncService) ImportarPopulacaoCSV(caminho string, ano int) (int, error) {
+	arquivo, err := os.Open(caminho)
+	if err != nil {
+		return 0, fmt.Errorf("falha ao abrir CSV: %w", err)
+	}
+	defer arquivo.Close()
+
+	populacoes, err := LerPopulacaoCSV(arquivo, ano)
+	if err != nil {
+		return 0, fmt.Errorf("falha ao ler CSV de populacao: %w", err)
+	}
+	if err := s.repo.UpsertPopulacao(populacoes); err != nil {
+		return 0, err
+	}
+
+	slog.Info("estimativas de populacao importadas", "ano", ano, "municipios", len(populacoes))
+	return len(populacoes), nil
+}
@@ -136,3 +136,40 @@ func (h *Handler) GetPorTipo(c *gin.Context) {
 		"por_tipo":   tipos,
 	})
 }
+
+// GetTramitacao godoc
+// @Summary Retorna o historico de tramitacao de uma materia
+// @Tags proposicoes
+// @Produce json
+// @Param codigo path string true "Codigo da materia"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/proposicoes/{codigo}/tramitacao [get]
+func (h *Handler) GetTramitacao(c *gin.Context) {
+	codigo := c.Param("codigo")
+	if _, err := strconv.Atoi(codigo); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "codigo invalido"})
+		return
+	}
+
+	tramitacoes, err := h.repo.FindTramitacao(codigo)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar tramitacao"})
+		return
+	}
+
+	resposta := gin.H{
+		"codigo_materia": codigo,
+		"total":          len(tramitacoes),
+		"tramitacao":     tramitacoes,
+	}
+	if proposicao, err := h.repo.FindByCodigoMateria(codigo); err == nil {
+		resposta["identificacao"] = proposicao.DescricaoIdentificacao
+		resposta["estagio_tramitacao"] = proposicao.EstagioTramitacao
+		resposta["atualizada_em"] = proposicao.TramitacaoAtualizadaEm
+	} else if len(tramitacoes) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "materia nao encontrada"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resposta)
+}
@@ -2,7 +2,8 @@ package proposicao
 
 import "time"
 
-// Proposicao representa uma proposicao legislativa de autoria de um senador
+// Proposicao representa uma proposicao legislativa (uma linha por materia).
+// Os autores ficam em Autoria; SenadorID guarda o primeiro autor entre os senadores cadastrados.
 type Proposicao struct {
 	ID                int       `gorm:"primaryKey" json:"id"`
 	SenadorID         int       `gorm:"index:idx_proposicao_senador;not null" json:"senador_id"`
@@ -14,6 +15,10 @@ type Proposicao struct {
 	Ementa            string    `json:"ementa,omitempty"`
 	SituacaoAtual     string    `json:"situacao_atual,omitempty"` // Em tramitacao, Arquivada, Transformada em Lei
 	DataApresentacao  *time.Time `json:"data_apresentacao,omitempty"`
+	NormaGerada       string     `json:"norma_gerada,omitempty"` // Ex: "Lei nº 14.133 de 01/04/2021"
+
+	// Ultima sincronizacao do historico de tramitacao (nil = nunca sincronizado)
+	TramitacaoAtualizadaEm *time.Time `json:"tramitacao_atualizada_em,omitempty"`
 
 	// Para calculo de score
 	EstagioTramitacao string `json:"estagio_tramitacao"` // Apresentado, EmComissao, AprovadoComissao, AprovadoPlenario, TransformadoLei
@@ -21,6 +26,9 @@ type Proposicao struct {
 
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// Papel do senador consultado na autoria (populado via Join, read-only)
+	Papel string `gorm:"->" json:"papel,omitempty"`
 }
 
 // TableName define o nome da tabela
@@ -28,6 +36,40 @@ func (Proposicao) TableName() string {
 	return "proposicoes"
 }
 
+// Autoria relaciona uma materia a cada senador autor, com o seu papel
+type Autoria struct {
+	ID            int       `gorm:"primaryKey" json:"id"`
+	CodigoMateria string    `gorm:"uniqueIndex:idx_autoria_unica,priority:1;not null" json:"codigo_materia"`
+	SenadorID     int       `gorm:"uniqueIndex:idx_autoria_unica,priority:2;index:idx_autoria_senador;not null" json:"senador_id"`
+	Papel         string    `gorm:"not null" json:"papel"` // PrimeiroAutor, Coautor
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// TableName define o nome da tabela
+func (Autoria) TableName() string {
+	return "proposicao_autorias"
+}
+
+// Papeis de autoria e peso de cada um na pontuacao de produtividade.
+// O primeiro autor recebe o credito integral; coautores recebem metade,
+// evitando que projetos coletivos inflem a produtividade de todos os signatarios.
+const (
+	PapelPrimeiroAutor = "PrimeiroAutor"
+	PapelCoautor       = "Coautor"
+
+	PesoPrimeiroAutor = 1.0
+	PesoCoautor       = 0.5
+)
+
+// PesoPapel retorna o peso de credito de um papel de autoria
+func PesoPapel(papel string) float64 {
+	if papel == PapelCoautor {
+		return PesoCoautor
+	}
+	return PesoPrimeiroAutor
+}
+
 // ProposicaoStats representa estatisticas de proposicoes de um senador
 type ProposicaoStats struct {
 	SenadorID           int     `json:"senador_id"`
@@ -39,7 +81,9 @@ type ProposicaoStats struct {
 	TransformadasEmLei  int     `json:"transformadas_em_lei"`
 	AprovadosPlenario   int     `json:"aprovados_plenario"`
 	EmTramitacao        int     `json:"em_tramitacao"`
-	PontuacaoTotal      float64 `json:"pontuacao_total"`      // Score de produtividade
+	ComoPrimeiroAutor   int     `json:"como_primeiro_autor"`
+	ComoCoautor         int     `json:"como_coautor"`
+	PontuacaoTotal      float64 `json:"pontuacao_total"`      // Score de produtividade (ponderado pelo papel de autoria)
 	ScoreNormalizado    float64 `json:"score_normalizado"`    // 0-100
 }
 
@@ -60,23 +104,26 @@ func (p *Proposicao) CalcularPontuacao() float64 {
 		"TransformadoLei":   16,
 	}
 
-	// Multiplicador por tipo de proposicao
-	multiplicador := 1.0
-	switch p.SiglaSubtipoMateria {
-	case "PEC":
-		multiplicador = 3.0
-	case "PLP":
-		multiplicador = 2.0
-	case "RQS", "MOC":
-		multiplicador = 0.5
-	case "REQ":
-		multiplicador = 0.1
-	}
-
 	pontos := pontosBase[p.EstagioTramitacao]
 	if pontos == 0 {
 		pontos = 1 // Default para apresentado
 	}
 
-	return float64(pontos) * multiplicador
+	return float64(pontos) * MultiplicadorTipo(p.SiglaSubtipoMateria)
+}
+
+// MultiplicadorTipo retorna o peso de uma materia pelo seu tipo (PEC x3, PLP x2, ...)
+// Usado tambem pela pontuacao de relatorias
+func MultiplicadorTipo(siglaSubtipo string) float64 {
+	switch siglaSubtipo {
+	case "PEC":
+		return 3.0
+	case "PLP":
+		return 2.0
+	case "RQS", "MOC":
+		return 0.5
+	case "REQ":
+		return 0.1
+	}
+	return 1.0
 }
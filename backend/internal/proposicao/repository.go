@@ -1,7 +1,8 @@
 package proposicao
 
 import (
-	"fmt"
+	"time"
+
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
@@ -23,11 +24,13 @@ func (r *Repository) FindBySenadorID(senadorID int, limit int, offset int, query
 	var proposicoes []Proposicao
 	var total int64
 	
-	dbQuery := r.db.Model(&Proposicao{}).Where("senador_id = ?", senadorID)
+	dbQuery := r.db.Model(&Proposicao{}).
+		Joins("JOIN proposicao_autorias a ON a.codigo_materia = proposicoes.codigo_materia").
+		Where("a.senador_id = ?", senadorID)
 
 	if queryStr != "" {
 		search := "%" + queryStr + "%"
-		dbQuery = dbQuery.Where("(ementa ILIKE ? OR descricao_identificacao ILIKE ? OR codigo_materia ILIKE ?)", search, search, search)
+		dbQuery = dbQuery.Where("(ementa ILIKE ? OR descricao_identificacao ILIKE ? OR proposicoes.codigo_materia ILIKE ?)", search, search, search)
 	}
 
 	if ano > 0 {
@@ -49,10 +52,10 @@ func (r *Repository) FindBySenadorID(senadorID int, limit int, offset int, query
 
 	// Sorting
 	// Default: Data DESC (NULLS LAST to keep invalid dates at bottom), fallback to Ano/Codigo
-	order := "data_apresentacao DESC NULLS LAST, ano_materia DESC, codigo_materia DESC"
+	order := "data_apresentacao DESC NULLS LAST, ano_materia DESC, proposicoes.codigo_materia DESC"
 	
 	if sort == "data_asc" {
-		order = "data_apresentacao ASC NULLS LAST, ano_materia ASC, codigo_materia ASC"
+		order = "data_apresentacao ASC NULLS LAST, ano_materia ASC, proposicoes.codigo_materia ASC"
 	} else if sort == "ano_desc" {
 		order = "ano_materia DESC, data_apresentacao DESC NULLS LAST"
 	}
@@ -66,73 +69,70 @@ func (r *Repository) FindBySenadorID(senadorID int, limit int, offset int, query
 		dbQuery = dbQuery.Offset(offset)
 	}
 
-	result := dbQuery.Find(&proposicoes)
+	result := dbQuery.Select("proposicoes.*, a.papel").Find(&proposicoes)
 	return proposicoes, total, result.Error
 }
 
 // CountBySenadorID retorna total de proposicoes de um senador
 func (r *Repository) CountBySenadorID(senadorID int) (int64, error) {
 	var count int64
-	result := r.db.Model(&Proposicao{}).Where("senador_id = ?", senadorID).Count(&count)
+	result := r.db.Model(&Autoria{}).Where("senador_id = ?", senadorID).Count(&count)
 	return count, result.Error
 }
 
 // GetStats retorna estatisticas de proposicoes de um senador no atual mandato
 func (r *Repository) GetStats(senadorID int) (*ProposicaoStats, error) {
-	var stats ProposicaoStats
-	stats.SenadorID = senadorID
-
-	var total, pecs, plps, pls, leis, plenario, tramitacao int64
-
-	// Filtro mandato atual
-	mandatoFilter := fmt.Sprintf("senador_id = ? AND EXTRACT(YEAR FROM data_apresentacao) >= %d", utils.GetInicioLegislaturaAtual())
-
-	// Total de proposicoes
-	r.db.Model(&Proposicao{}).Where(mandatoFilter, senadorID).Count(&total)
-	stats.TotalProposicoes = int(total)
-
-	// PECs
-	r.db.Model(&Proposicao{}).Where(mandatoFilter+" AND sigla_subtipo_materia = ?", senadorID, "PEC").Count(&pecs)
-	stats.TotalPECs = int(pecs)
-
-	// PLPs
-	r.db.Model(&Proposicao{}).Where(mandatoFilter+" AND sigla_subtipo_materia = ?", senadorID, "PLP").Count(&plps)
-	stats.TotalPLPs = int(plps)
-
-	// PLs
-	r.db.Model(&Proposicao{}).Where(mandatoFilter+" AND sigla_subtipo_materia = ?", senadorID, "PL").Count(&pls)
-	stats.TotalPLs = int(pls)
-
-	// Outros
-	stats.TotalOutros = stats.TotalProposicoes - stats.TotalPECs - stats.TotalPLPs - stats.TotalPLs
-
-	// Transformadas em lei
-	r.db.Model(&Proposicao{}).Where(
-		mandatoFilter+" AND estagio_tramitacao = ?", senadorID, "TransformadoLei",
-	).Count(&leis)
-	stats.TransformadasEmLei = int(leis)
-
-	// Aprovados plenario
-	r.db.Model(&Proposicao{}).Where(
-		mandatoFilter+" AND estagio_tramitacao IN (?, ?)", senadorID, "AprovadoPlenario", "TransformadoLei",
-	).Count(&plenario)
-	stats.AprovadosPlenario = int(plenario)
-
-	// Em tramitacao
-	r.db.Model(&Proposicao{}).Where(
-		mandatoFilter+" AND estagio_tramitacao IN (?, ?, ?)", senadorID, "Apresentado", "EmComissao", "AprovadoComissao",
-	).Count(&tramitacao)
-	stats.EmTramitacao = int(tramitacao)
-
-	// Soma de pontuacao
-	var soma struct {
-		Total float64
+	return r.calcularStats(senadorID, "EXTRACT(YEAR FROM p.data_apresentacao) >= ?", utils.GetInicioLegislaturaAtual())
+}
+
+// calcularStats agrega as proposicoes em que o senador e autor ou coautor.
+// A pontuacao de cada materia e ponderada pelo papel (ver PesoPapel).
+func (r *Repository) calcularStats(senadorID int, periodo string, args ...interface{}) (*ProposicaoStats, error) {
+	stats := ProposicaoStats{SenadorID: senadorID}
+
+	var contagem struct {
+		Total         int
+		Pecs          int
+		Plps          int
+		Pls           int
+		Leis          int
+		Plenario      int
+		Tramitacao    int
+		PrimeiroAutor int
+		Coautor       int
+		Pontuacao     float64
+	}
+	err := r.db.Table("proposicoes p").
+		Joins("JOIN proposicao_autorias a ON a.codigo_materia = p.codigo_materia").
+		Select(`COUNT(*) as total,
+			COUNT(*) FILTER (WHERE p.sigla_subtipo_materia = 'PEC') as pecs,
+			COUNT(*) FILTER (WHERE p.sigla_subtipo_materia = 'PLP') as plps,
+			COUNT(*) FILTER (WHERE p.sigla_subtipo_materia = 'PL') as pls,
+			COUNT(*) FILTER (WHERE p.estagio_tramitacao = 'TransformadoLei') as leis,
+			COUNT(*) FILTER (WHERE p.estagio_tramitacao IN ('AprovadoPlenario', 'TransformadoLei')) as plenario,
+			COUNT(*) FILTER (WHERE p.estagio_tramitacao IN ('Apresentado', 'EmComissao', 'AprovadoComissao')) as tramitacao,
+			COUNT(*) FILTER (WHERE a.papel = ?) as primeiro_autor,
+			COUNT(*) FILTER (WHERE a.papel = ?) as coautor,
+			COALESCE(SUM(p.pontuacao * CASE WHEN a.papel = ? THEN ? ELSE ? END), 0) as pontuacao`,
+			PapelPrimeiroAutor, PapelCoautor, PapelCoautor, PesoCoautor, PesoPrimeiroAutor).
+		Where("a.senador_id = ?", senadorID).
+		Where(periodo, args...).
+		Scan(&contagem).Error
+	if err != nil {
+		return nil, err
 	}
-	r.db.Model(&Proposicao{}).
-		Select("COALESCE(SUM(pontuacao), 0) as total").
-		Where(mandatoFilter, senadorID).
-		Scan(&soma)
-	stats.PontuacaoTotal = soma.Total
+
+	stats.TotalProposicoes = contagem.Total
+	stats.TotalPECs = contagem.Pecs
+	stats.TotalPLPs = contagem.Plps
+	stats.TotalPLs = contagem.Pls
+	stats.TotalOutros = contagem.Total - contagem.Pecs - contagem.Plps - contagem.Pls
+	stats.TransformadasEmLei = contagem.Leis
+	stats.AprovadosPlenario = contagem.Plenario
+	stats.EmTramitacao = contagem.Tramitacao
+	stats.ComoPrimeiroAutor = contagem.PrimeiroAutor
+	stats.ComoCoautor = contagem.Coautor
+	stats.PontuacaoTotal = contagem.Pontuacao
 
 	return &stats, nil
 }
@@ -142,7 +142,8 @@ func (r *Repository) GetProposicoesPorTipo(senadorID int) ([]ProposicaoPorTipo,
 	var result []ProposicaoPorTipo
 	err := r.db.Model(&Proposicao{}).
 		Select("sigla_subtipo_materia as tipo, COUNT(*) as total").
-		Where("senador_id = ?", senadorID).
+		Joins("JOIN proposicao_autorias a ON a.codigo_materia = proposicoes.codigo_materia").
+		Where("a.senador_id = ?", senadorID).
 		Group("sigla_subtipo_materia").
 		Order("total DESC").
 		Scan(&result).Error
@@ -153,7 +154,7 @@ func (r *Repository) GetProposicoesPorTipo(senadorID int) ([]ProposicaoPorTipo,
 func (r *Repository) Upsert(proposicao *Proposicao) error {
 	return r.db.Clauses(clause.OnConflict{
 		Columns:   []clause.Column{{Name: "codigo_materia"}},
-		DoUpdates: clause.AssignmentColumns([]string{"estagio_tramitacao", "situacao_atual", "pontuacao", "updated_at"}),
+		DoUpdates: clause.AssignmentColumns([]string{"estagio_tramitacao", "situacao_atual", "norma_gerada", "pontuacao", "updated_at"}),
 	}).Create(proposicao).Error
 }
 
@@ -161,72 +162,136 @@ func (r *Repository) Upsert(proposicao *Proposicao) error {
 func (r *Repository) UpsertBatch(proposicoes []Proposicao) error {
 	return r.db.Clauses(clause.OnConflict{
 		Columns:   []clause.Column{{Name: "codigo_materia"}},
-		DoUpdates: clause.AssignmentColumns([]string{"estagio_tramitacao", "situacao_atual", "pontuacao", "updated_at"}),
+		DoUpdates: clause.AssignmentColumns([]string{"estagio_tramitacao", "situacao_atual", "norma_gerada", "pontuacao", "updated_at"}),
 	}).CreateInBatches(proposicoes, 100).Error
 }
 
+// FindTramitacao retorna o historico de tramitacao de uma materia em ordem cronologica
+func (r *Repository) FindTramitacao(codigoMateria string) ([]Tramitacao, error) {
+	var tramitacoes []Tramitacao
+	err := r.db.Where("codigo_materia = ?", codigoMateria).
+		Order("data ASC, sequencia ASC").
+		Find(&tramitacoes).Error
+	return tramitacoes, err
+}
+
+// FindTramitacoesPorMaterias retorna historicos de varias materias agrupados por codigo
+func (r *Repository) FindTramitacoesPorMaterias(codigos []string) (map[string][]Tramitacao, error) {
+	historicos := make(map[string][]Tramitacao)
+	if len(codigos) == 0 {
+		return historicos, nil
+	}
+	var tramitacoes []Tramitacao
+	if err := r.db.Where("codigo_materia IN ?", codigos).
+		Order("data ASC, sequencia ASC").
+		Find(&tramitacoes).Error; err != nil {
+		return nil, err
+	}
+	for _, t := range tramitacoes {
+		historicos[t.CodigoMateria] = append(historicos[t.CodigoMateria], t)
+	}
+	return historicos, nil
+}
+
+// FindByCodigoMateria busca uma proposicao pelo codigo da materia
+func (r *Repository) FindByCodigoMateria(codigoMateria string) (*Proposicao, error) {
+	var proposicao Proposicao
+	if err := r.db.Where("codigo_materia = ?", codigoMateria).First(&proposicao).Error; err != nil {
+		return nil, err
+	}
+	return &proposicao, nil
+}
+
+// FindByIdentificacao busca as proposicoes de uma materia pela sigla, numero e ano.
+// O numero e comparado sem zeros a esquerda (a API alterna entre "10" e "00010").
+func (r *Repository) FindByIdentificacao(sigla, numero string, ano int) ([]Proposicao, error) {
+	var proposicoes []Proposicao
+	err := r.db.Where("UPPER(sigla_subtipo_materia) = UPPER(?) AND LTRIM(numero_materia, '0') = LTRIM(?, '0') AND ano_materia = ?",
+		sigla, numero, ano).
+		Order("data_apresentacao ASC").
+		Find(&proposicoes).Error
+	return proposicoes, err
+}
+
+// FindPendentesTramitacao retorna proposicoes cujo historico nunca foi sincronizado
+// ou esta desatualizado, priorizando as nunca sincronizadas. Materias ja
+// transformadas em lei nao mudam mais e sao ignoradas apos a primeira carga.
+func (r *Repository) FindPendentesTramitacao(limite int, atualizadasAntesDe time.Time) ([]Proposicao, error) {
+	var proposicoes []Proposicao
+	err := r.db.Where("tramitacao_atualizada_em IS NULL OR (estagio_tramitacao <> ? AND tramitacao_atualizada_em < ?)",
+		"TransformadoLei", atualizadasAntesDe).
+		Order("tramitacao_atualizada_em ASC NULLS FIRST, data_apresentacao DESC").
+		Limit(limite).
+		Find(&proposicoes).Error
+	return proposicoes, err
+}
+
+// ReplaceTramitacao substitui o historico de uma materia e atualiza o estagio derivado
+func (r *Repository) ReplaceTramitacao(proposicao *Proposicao, tramitacoes []Tramitacao) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("codigo_materia = ?", proposicao.CodigoMateria).Delete(&Tramitacao{}).Error; err != nil {
+			return err
+		}
+		if len(tramitacoes) > 0 {
+			if err := tx.CreateInBatches(tramitacoes, 200).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&Proposicao{}).
+			Where("codigo_materia = ?", proposicao.CodigoMateria).
+			Updates(map[string]interface{}{
+				"estagio_tramitacao":       proposicao.EstagioTramitacao,
+				"pontuacao":                proposicao.Pontuacao,
+				"tramitacao_atualizada_em": proposicao.TramitacaoAtualizadaEm,
+			}).Error
+	})
+}
+
+// UpsertAutoria registra o papel de um senador na autoria de uma materia.
+// Quando o senador e o primeiro autor, a proposicao passa a apontar para ele.
+func (r *Repository) UpsertAutoria(codigoMateria string, senadorID int, papel string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "codigo_materia"}, {Name: "senador_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"papel", "updated_at"}),
+		}).Create(&Autoria{CodigoMateria: codigoMateria, SenadorID: senadorID, Papel: papel}).Error; err != nil {
+			return err
+		}
+		if papel != PapelPrimeiroAutor {
+			return nil
+		}
+		return tx.Model(&Proposicao{}).
+			Where("codigo_materia = ? AND senador_id <> ?", codigoMateria, senadorID).
+			Update("senador_id", senadorID).Error
+	})
+}
+
+// FindAutorias retorna os senadores autores de uma materia
+func (r *Repository) FindAutorias(codigoMateria string) ([]Autoria, error) {
+	var autorias []Autoria
+	err := r.db.Where("codigo_materia = ?", codigoMateria).
+		Order("papel DESC, senador_id").
+		Find(&autorias).Error
+	return autorias, err
+}
+
+// MigrarAutoriasLegadas cria a autoria (primeiro autor) das proposicoes gravadas
+// antes da tabela de autorias existir. Idempotente.
+func (r *Repository) MigrarAutoriasLegadas() (int64, error) {
+	result := r.db.Exec(`INSERT INTO proposicao_autorias (codigo_materia, senador_id, papel, created_at, updated_at)
+		SELECT p.codigo_materia, p.senador_id, ?, NOW(), NOW() FROM proposicoes p
+		WHERE NOT EXISTS (SELECT 1 FROM proposicao_autorias a WHERE a.codigo_materia = p.codigo_materia)
+		ON CONFLICT DO NOTHING`, PapelPrimeiroAutor)
+	return result.RowsAffected, result.Error
+}
+
 // DeleteBySenadorID remove todas as proposicoes de um senador
 func (r *Repository) DeleteBySenadorID(senadorID int) error {
 	return r.db.Where("senador_id = ?", senadorID).Delete(&Proposicao{}).Error
 }
 
 // GetStatsByAno retorna estatisticas de proposicoes filtradas por ano de apresentacao
+// (ano_materia, mais confiavel que data_apresentacao para materias legislativas)
 func (r *Repository) GetStatsByAno(senadorID int, ano int) (*ProposicaoStats, error) {
-	var stats ProposicaoStats
-	stats.SenadorID = senadorID
-
-	var total, pecs, plps, pls, leis, plenario, tramitacao int64
-
-	// Filtro de data: baseado no ano_materia ou data_apresentacao
-	// O mais confiavel para "ano" costuma ser o ano_materia para proposicoes legislativas
-	yearFilter := "ano_materia = ?"
-
-	// Total de proposicoes
-	r.db.Model(&Proposicao{}).Where("senador_id = ? AND "+yearFilter, senadorID, ano).Count(&total)
-	stats.TotalProposicoes = int(total)
-
-	// PECs
-	r.db.Model(&Proposicao{}).Where("senador_id = ? AND sigla_subtipo_materia = ? AND "+yearFilter, senadorID, "PEC", ano).Count(&pecs)
-	stats.TotalPECs = int(pecs)
-
-	// PLPs
-	r.db.Model(&Proposicao{}).Where("senador_id = ? AND sigla_subtipo_materia = ? AND "+yearFilter, senadorID, "PLP", ano).Count(&plps)
-	stats.TotalPLPs = int(plps)
-
-	// PLs
-	r.db.Model(&Proposicao{}).Where("senador_id = ? AND sigla_subtipo_materia = ? AND "+yearFilter, senadorID, "PL", ano).Count(&pls)
-	stats.TotalPLs = int(pls)
-
-	// Outros
-	stats.TotalOutros = stats.TotalProposicoes - stats.TotalPECs - stats.TotalPLPs - stats.TotalPLs
-
-	// Transformadas em lei (filtro yearFilter se aplica a quando foi apresentada, nao quando virou lei, conforme metodologia de produtividade por safra)
-	r.db.Model(&Proposicao{}).Where(
-		"senador_id = ? AND estagio_tramitacao = ? AND "+yearFilter, senadorID, "TransformadoLei", ano,
-	).Count(&leis)
-	stats.TransformadasEmLei = int(leis)
-
-	// Aprovados plenario
-	r.db.Model(&Proposicao{}).Where(
-		"senador_id = ? AND estagio_tramitacao IN (?, ?) AND "+yearFilter, senadorID, "AprovadoPlenario", "TransformadoLei", ano,
-	).Count(&plenario)
-	stats.AprovadosPlenario = int(plenario)
-
-	// Em tramitacao
-	r.db.Model(&Proposicao{}).Where(
-		"senador_id = ? AND estagio_tramitacao IN (?, ?, ?) AND "+yearFilter, senadorID, "Apresentado", "EmComissao", "AprovadoComissao", ano,
-	).Count(&tramitacao)
-	stats.EmTramitacao = int(tramitacao)
-
-	// Soma de pontuacao
-	var soma struct {
-		Total float64
-	}
-	r.db.Model(&Proposicao{}).
-		Select("COALESCE(SUM(pontuacao), 0) as total").
-		Where("senador_id = ? AND "+yearFilter, senadorID, ano).
-		Scan(&soma)
-	stats.PontuacaoTotal = soma.Total
-
-	return &stats, nil
+	return r.calcularStats(senadorID, "p.ano_materia = ?", ano)
 }
@@ -72,9 +72,22 @@ func (s *SyncService) SyncSenador(ctx context.Context, senadorID int) (int, erro
 	// 	slog.Warn("falha ao limpar proposicoes antigas", "senador", senadorID, "error", err)
 	// }
 
+	// Historicos ja sincronizados prevalecem sobre o estagio inferido da listagem
+	codigos := make([]string, 0, len(proposicoesAPI))
+	for _, p := range proposicoesAPI {
+		codigos = append(codigos, strconv.Itoa(p.CodigoMateria))
+	}
+	historicos, err := s.repo.FindTramitacoesPorMaterias(codigos)
+	if err != nil {
+		slog.Warn("falha ao buscar historicos de tramitacao", "senador", senadorID, "error", err)
+	}
+
 	var count int
 	for _, p := range proposicoesAPI {
 		proposicao := s.convertToModel(p, senadorID)
+		if estagio := EstagioPorTramitacao(historicos[proposicao.CodigoMateria], proposicao.NormaGerada); estagio != "" {
+			proposicao.EstagioTramitacao = estagio
+		}
 		
 		// [PERFORMANCE] Se for sync diario (nao backfill), ignore proposicoes velhas 
 		// Assumiremos que coisas apresentadas ha mais de 10 anos nao mudam de estado
@@ -95,12 +108,106 @@ func (s *SyncService) SyncSenador(ctx context.Context, senadorID int) (int, erro
 			slog.Warn("falha ao salvar proposicao", "senador", senadorID, "error", err)
 			continue
 		}
+
+		papel := papelAutoria(p.Autoria, sen.Nome)
+		if err := s.repo.UpsertAutoria(proposicao.CodigoMateria, senadorID, papel); err != nil {
+			slog.Warn("falha ao salvar autoria", "senador", senadorID, "materia", proposicao.CodigoMateria, "error", err)
+			continue
+		}
 		count++
 	}
 
 	return count, nil
 }
 
+// SyncTramitacoes busca o historico de tramitacao das proposicoes pendentes
+// (nunca sincronizadas ou atualizadas ha mais de 7 dias) e rederiva o estagio
+func (s *SyncService) SyncTramitacoes(ctx context.Context, limite int) error {
+	pendentes, err := s.repo.FindPendentesTramitacao(limite, time.Now().AddDate(0, 0, -7))
+	if err != nil {
+		return err
+	}
+
+	slog.Info("iniciando sync de tramitacoes", "pendentes", len(pendentes))
+
+	var atualizadas, alteradas int
+	for i := range pendentes {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		p := &pendentes[i]
+
+		codigo, err := strconv.Atoi(p.CodigoMateria)
+		if err != nil {
+			continue
+		}
+		tramitacoesAPI, err := s.client.ListarTramitacaoMateria(ctx, codigo)
+		if err != nil {
+			slog.Warn("falha ao buscar tramitacao", "materia", p.CodigoMateria, "error", err)
+			continue
+		}
+
+		tramitacoes := make([]Tramitacao, 0, len(tramitacoesAPI))
+		for _, t := range tramitacoesAPI {
+			if tramitacao, ok := convertTramitacao(t, p.CodigoMateria); ok {
+				tramitacoes = append(tramitacoes, tramitacao)
+			}
+		}
+
+		estagioAnterior := p.EstagioTramitacao
+		if estagio := EstagioPorTramitacao(tramitacoes, p.NormaGerada); estagio != "" {
+			p.EstagioTramitacao = estagio
+		}
+		p.Pontuacao = p.CalcularPontuacao()
+		agora := time.Now()
+		p.TramitacaoAtualizadaEm = &agora
+
+		if err := s.repo.ReplaceTramitacao(p, tramitacoes); err != nil {
+			slog.Warn("falha ao salvar tramitacao", "materia", p.CodigoMateria, "error", err)
+			continue
+		}
+		atualizadas++
+		if p.EstagioTramitacao != estagioAnterior {
+			alteradas++
+		}
+	}
+
+	slog.Info("sync de tramitacoes concluido", "materias", atualizadas, "estagios_alterados", alteradas)
+	return nil
+}
+
+// convertTramitacao converte um passo de tramitacao da API; retorna false sem data valida
+func convertTramitacao(api senadoapi.TramitacaoAPI, codigoMateria string) (Tramitacao, bool) {
+	id := api.IdentificacaoTramitacao
+	if len(id.DataTramitacao) < 10 {
+		return Tramitacao{}, false
+	}
+	data, err := time.Parse("2006-01-02", id.DataTramitacao[:10])
+	if err != nil {
+		return Tramitacao{}, false
+	}
+
+	// O passo ocorre no orgao de origem; alguns registros trazem apenas o destino
+	local := id.OrigemTramitacao.Local
+	if local.SiglaLocal == "" {
+		local = id.DestinoTramitacao.Local
+	}
+	sequencia, _ := strconv.Atoi(id.NumeroOrdemTramitacao)
+
+	return Tramitacao{
+		CodigoMateria:     codigoMateria,
+		CodigoTramitacao:  id.CodigoTramitacao,
+		Sequencia:         sequencia,
+		Data:              data,
+		SiglaLocal:        local.SiglaLocal,
+		NomeLocal:         local.NomeLocal,
+		Casa:              local.SiglaCasaLocal,
+		Acao:              strings.TrimSpace(id.TextoTramitacao),
+		SiglaSituacao:     id.Situacao.SiglaSituacao,
+		DescricaoSituacao: id.Situacao.DescricaoSituacao,
+	}, true
+}
+
 // convertToModel converte uma proposicao da API para modelo interno
 func (s *SyncService) convertToModel(api senadoapi.MateriaAPI, senadorID int) Proposicao {
 	var dataApresentacao *time.Time
@@ -113,7 +220,8 @@ func (s *SyncService) convertToModel(api senadoapi.MateriaAPI, senadorID int) Pr
 	// Extrair sigla e numero/ano do campo Identificacao (ex: "PLS 4/2004")
 	sigla, numero, ano := extrairIdentificacao(api.Identificacao)
 
-	// Determinar estagio de tramitacao baseado em NormaGerada e SiglaTipoDeliberacao
+	// Estagio provisorio baseado em NormaGerada e SiglaTipoDeliberacao; substituido
+	// pelo derivado do historico assim que a tramitacao da materia e sincronizada
 	estagio := determinarEstagioV2(api.NormaGerada, api.SiglaTipoDeliberacao, api.Tramitando)
 
 	return Proposicao{
@@ -126,10 +234,36 @@ func (s *SyncService) convertToModel(api senadoapi.MateriaAPI, senadorID int) Pr
 		Ementa:                 api.Ementa,
 		SituacaoAtual:          api.SiglaTipoDeliberacao,
 		DataApresentacao:       dataApresentacao,
+		NormaGerada:            api.NormaGerada,
 		EstagioTramitacao:      estagio,
 	}
 }
 
+// papelAutoria identifica se o senador e o primeiro autor da materia a partir do
+// campo Autoria da API (ex: "Senador Fulano (PT/BA), Senadora Beltrana (MDB/SP)"
+// ou "Senador Fulano e outros"). Sem autoria informada, assume primeiro autor.
+func papelAutoria(autoria, nomeSenador string) string {
+	texto := normalizarTexto(autoria)
+	nome := normalizarTexto(nomeSenador)
+	if texto == "" || nome == "" {
+		return PapelPrimeiroAutor
+	}
+
+	primeiro := texto
+	if i := strings.Index(primeiro, ","); i >= 0 {
+		primeiro = primeiro[:i]
+	}
+	if i := strings.Index(primeiro, " e outros"); i >= 0 {
+		primeiro = primeiro[:i]
+	}
+	primeiro = strings.NewReplacer("(", " ", ")", " ", "/", " ").Replace(primeiro)
+
+	if strings.Contains(" "+primeiro+" ", " "+nome+" ") {
+		return PapelPrimeiroAutor
+	}
+	return PapelCoautor
+}
+
 // extrairIdentificacao extrai sigla, numero e ano do campo Identificacao
 // Ex: "PLS 4/2004" -> ("PLS", "4", 2004)
 func extrairIdentificacao(identificacao string) (sigla, numero string, ano int) {
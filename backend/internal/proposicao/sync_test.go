@@ -0,0 +1,22 @@
+package proposicao
+
+import "testing"
+
+func TestPapelAutoria(t *testing.T) {
+	casos := []struct {
+		autoria, nome, esperado string
+	}{
+		{"", "Fulano Silva", PapelPrimeiroAutor},
+		{"Senador Fulano Silva (PT/BA)", "Fulano Silva", PapelPrimeiroAutor},
+		{"Senador Fulano Silva (PT/BA), Senadora Beltrana Souza (MDB/SP)", "Beltrana Souza", PapelCoautor},
+		{"Senadora Beltrana Souza (MDB/SP), Senador Fulano Silva (PT/BA)", "Beltrana Souza", PapelPrimeiroAutor},
+		{"Senador Fulano Silva e outros", "Beltrana Souza", PapelCoautor},
+		{"Senador José Antônio (PSD/MG) e outros", "Jose Antonio", PapelPrimeiroAutor},
+		{"Senador Fulano Silvano (PL/RJ), Senador Fulano Silva (PT/BA)", "Fulano Silva", PapelCoautor},
+	}
+	for _, c := range casos {
+		if got := papelAutoria(c.autoria, c.nome); got != c.esperado {
+			t.Errorf("papelAutoria(%q, %q) = %q, esperado %q", c.autoria, c.nome, got, c.esperado)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package proposicao
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Alzarus/to-de-olho/internal/utils"
+)
+
+// Tramitacao representa um passo processual de uma materia (data, orgao, acao, situacao)
+type Tramitacao struct {
+	ID                int       `gorm:"primaryKey" json:"id"`
+	CodigoMateria     string    `gorm:"index:idx_tramitacao_materia;not null" json:"codigo_materia"`
+	CodigoTramitacao  string    `json:"codigo_tramitacao,omitempty"`
+	Sequencia         int       `json:"sequencia"`
+	Data              time.Time `gorm:"index" json:"data"`
+	SiglaLocal        string    `json:"sigla_local"` // Ex: CCJ, CAE, PLEN, SEXPE
+	NomeLocal         string    `json:"nome_local"`
+	Casa              string    `json:"casa,omitempty"` // SF, CD
+	Acao              string    `json:"acao"`
+	SiglaSituacao     string    `json:"sigla_situacao,omitempty"`
+	DescricaoSituacao string    `json:"descricao_situacao,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// TableName define o nome da tabela
+func (Tramitacao) TableName() string {
+	return "tramitacoes"
+}
+
+// Estagios de tramitacao em ordem crescente de avanco
+var ordemEstagio = map[string]int{
+	"Apresentado":      1,
+	"EmComissao":       2,
+	"AprovadoComissao": 3,
+	"AprovadoPlenario": 4,
+	"TransformadoLei":  5,
+}
+
+// EstagioPorTramitacao deriva o estagio mais avancado alcancado pela materia
+// a partir do historico de tramitacao. Arquivamentos nao reduzem o estagio.
+// Retorna "" quando nao ha historico (o chamador deve manter o estagio atual).
+func EstagioPorTramitacao(historico []Tramitacao, normaGerada string) string {
+	if normaGerada != "" {
+		return "TransformadoLei"
+	}
+	if len(historico) == 0 {
+		return ""
+	}
+
+	estagio := "Apresentado"
+	for _, t := range historico {
+		if e := classificarPasso(t); ordemEstagio[e] > ordemEstagio[estagio] {
+			estagio = e
+		}
+	}
+	return estagio
+}
+
+// classificarPasso identifica o estagio sinalizado por um unico passo de tramitacao
+func classificarPasso(t Tramitacao) string {
+	texto := normalizarTexto(t.Acao + " " + t.DescricaoSituacao)
+	plenario := t.SiglaLocal == "PLEN" || strings.Contains(normalizarTexto(t.NomeLocal), "plenario")
+	comissao := !plenario && (strings.HasPrefix(t.SiglaLocal, "C") || strings.Contains(normalizarTexto(t.NomeLocal), "comissao"))
+
+	switch {
+	case strings.Contains(texto, "transformada em norma juridica"),
+		strings.Contains(texto, "transformado em norma juridica"),
+		strings.Contains(texto, "sancionad"),
+		strings.Contains(texto, "promulgad"):
+		return "TransformadoLei"
+	case strings.Contains(texto, "requerimento"):
+		// Requerimentos aprovados (audiencias, urgencia) nao indicam avanco do merito
+		if comissao {
+			return "EmComissao"
+		}
+		return "Apresentado"
+	case strings.Contains(texto, "aprovad") && plenario,
+		strings.Contains(texto, "remetida a camara dos deputados"),
+		strings.Contains(texto, "remetido a camara dos deputados"),
+		strings.Contains(texto, "remetida a sancao"),
+		strings.Contains(texto, "enviada a sancao"):
+		return "AprovadoPlenario"
+	case strings.Contains(texto, "aprovad") && comissao,
+		strings.Contains(texto, "pronta para a pauta no plenario"),
+		strings.Contains(texto, "pronto para deliberacao do plenario"):
+		return "AprovadoComissao"
+	case comissao,
+		strings.Contains(texto, "designado relator"),
+		strings.Contains(texto, "aguardando designacao do relator"):
+		return "EmComissao"
+	}
+	return "Apresentado"
+}
+
+// normalizarTexto remove acentos e coloca em caixa baixa
+func normalizarTexto(valor string) string {
+	return strings.Join(strings.Fields(strings.ToLower(utils.RemoverAcentos(valor))), " ")
+}
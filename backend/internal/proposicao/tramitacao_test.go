@@ -0,0 +1,72 @@
+package proposicao
+
+import (
+	"testing"
+	"time"
+
+	senadoapi "github.com/Alzarus/to-de-olho/pkg/senado"
+)
+
+func passo(local, acao, situacao string) Tramitacao {
+	return Tramitacao{SiglaLocal: local, Acao: acao, DescricaoSituacao: situacao, Data: time.Now()}
+}
+
+func TestEstagioPorTramitacao(t *testing.T) {
+	casos := []struct {
+		nome      string
+		historico []Tramitacao
+		norma     string
+		esperado  string
+	}{
+		{"sem historico", nil, "", ""},
+		{"norma gerada prevalece", nil, "Lei nº 14.133 de 01/04/2021", "TransformadoLei"},
+		{"apenas leitura", []Tramitacao{passo("SLSF", "Leitura em Plenário.", "")}, "", "Apresentado"},
+		{"distribuida a comissao", []Tramitacao{
+			passo("SLSF", "Leitura.", ""),
+			passo("CCJ", "Aguardando designação do relator.", "AGUARDANDO DESIGNAÇÃO DO RELATOR"),
+		}, "", "EmComissao"},
+		{"requerimento aprovado em comissao nao conta como merito", []Tramitacao{
+			passo("CAE", "Aprovado o Requerimento nº 12/2024 de audiência pública.", ""),
+		}, "", "EmComissao"},
+		{"parecer aprovado em comissao", []Tramitacao{
+			passo("CAS", "Aprovado o parecer favorável ao projeto.", "APROVADO PARECER NA COMISSÃO"),
+		}, "", "AprovadoComissao"},
+		{"aprovado em plenario e arquivamento nao rebaixa", []Tramitacao{
+			passo("CCJ", "Aprovado o parecer.", ""),
+			passo("PLEN", "Aprovado o projeto.", "APROVADA"),
+			passo("SEXPE", "Remetido Ofício SF nº 100 à Câmara dos Deputados. Remetida à Câmara dos Deputados.", ""),
+			passo("SGM", "Arquivado ao final da legislatura.", "ARQUIVADA AO FINAL DA LEGISLATURA"),
+		}, "", "AprovadoPlenario"},
+		{"transformada em norma", []Tramitacao{
+			passo("PLEN", "Aprovado.", ""),
+			passo("SEXPE", "Transformada na Lei Ordinária nº 14.999.", "TRANSFORMADA EM NORMA JURÍDICA"),
+		}, "", "TransformadoLei"},
+	}
+
+	for _, c := range casos {
+		if got := EstagioPorTramitacao(c.historico, c.norma); got != c.esperado {
+			t.Errorf("%s: esperado %q, obtido %q", c.nome, c.esperado, got)
+		}
+	}
+}
+
+func TestConvertTramitacao(t *testing.T) {
+	var api senadoapi.TramitacaoAPI
+	api.IdentificacaoTramitacao.DataTramitacao = "2024-05-10"
+	api.IdentificacaoTramitacao.NumeroOrdemTramitacao = "7"
+	api.IdentificacaoTramitacao.TextoTramitacao = "  Recebido o relatório.  "
+	api.IdentificacaoTramitacao.DestinoTramitacao.Local.SiglaLocal = "CCJ"
+
+	tramitacao, ok := convertTramitacao(api, "160000")
+	if !ok {
+		t.Fatal("esperado passo valido")
+	}
+	if tramitacao.SiglaLocal != "CCJ" || tramitacao.Sequencia != 7 || tramitacao.Acao != "Recebido o relatório." {
+		t.Errorf("conversao inesperada: %+v", tramitacao)
+	}
+
+	api.IdentificacaoTramitacao.DataTramitacao = ""
+	if _, ok := convertTramitacao(api, "160000"); ok {
+		t.Error("passo sem data deveria ser descartado")
+	}
+}
@@ -3,6 +3,7 @@ package ranking
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -27,7 +28,7 @@ func (h *Handler) GetRanking(c *gin.Context) {
 		}
 	}
 
-	ranking, err := h.service.CalcularRanking(c.Request.Context(), ano)
+	ranking, err := h.service.CalcularRankingComOpcoes(c.Request.Context(), ano, parseOpcoes(c))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -64,7 +65,7 @@ func (h *Handler) GetScoreSenador(c *gin.Context) {
 		}
 	}
 
-	score, err := h.service.CalcularScoreSenador(c.Request.Context(), id, ano)
+	score, err := h.service.CalcularScoreSenador(c.Request.Context(), id, ano, parseOpcoes(c))
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "senador nao encontrado"})
 		return
@@ -85,14 +86,14 @@ func (h *Handler) GetMetodologia(c *gin.Context) {
 			{
 				"nome":        "Produtividade Legislativa",
 				"peso":        "35%",
-				"descricao":   "Capacidade de avancar proposicoes pelo processo legislativo",
+				"descricao":   "Capacidade de avancar proposicoes pelo processo legislativo. Coautorias valem 50% da pontuacao do primeiro autor",
 				"normalizacao": "Pontuacao do senador / Maior pontuacao da casa * 100",
 			},
 			{
 				"nome":        "Presenca em Votacoes",
 				"peso":        "25%",
-				"descricao":   "Participacao em votacoes nominais",
-				"normalizacao": "(Total - Ausencias) / Total * 100",
+				"descricao":   "Participacao em votacoes nominais. Ausencias durante licenca ou missao oficial sao justificadas e nao penalizam",
+				"normalizacao": "(Votos + Obstrucoes) / (Votos + Obstrucoes + Ausencias nao justificadas) * 100",
 			},
 			{
 				"nome":        "Economia na Cota (CEAPS)",
@@ -107,6 +108,22 @@ func (h *Handler) GetMetodologia(c *gin.Context) {
 				"normalizacao": "Pontos do senador / Maior pontuacao da casa * 100",
 			},
 		},
+		"modos_comissoes": gin.H{
+			"uso":    "?comissoes=participacao|presenca (default participacao)",
+			"padrao": ComissoesParticipacao,
+			"detalhes": []gin.H{
+				{
+					"chave":        ComissoesParticipacao,
+					"descricao":    "Titular = 2 pts, Suplente = 1 pt, Ativa = 1 pt bonus",
+					"normalizacao": "Pontos do senador / Maior pontuacao da casa * 100",
+				},
+				{
+					"chave":        ComissoesPresenca,
+					"descricao":    "Presenca nas reunioes realizadas, com lista de presenca publicada, das comissoes em que o senador era titular na data",
+					"normalizacao": "Presencas / Reunioes esperadas * 100",
+				},
+			},
+		},
 		"detalhes_produtividade": []gin.H{
 			{"tipo": "PEC", "peso": "x3.0"},
 			{"tipo": "PLP", "peso": "x2.0"},
@@ -114,8 +131,53 @@ func (h *Handler) GetMetodologia(c *gin.Context) {
 			{"tipo": "Mocoes (RQS/MOC)", "peso": "x0.5"},
 			{"tipo": "Requerimentos (REQ)", "peso": "x0.1"},
 		},
+		"criterios_opcionais": gin.H{
+			"uso":        "?criterios=discursos,relatorias,fidelidade,governismo (separados por virgula)",
+			"peso":       "10% por criterio; a formula base e reescalada para o restante",
+			"disponiveis": h.service.CriteriosDisponiveis(),
+			"detalhes": []gin.H{
+				{
+					"nome":         "Discursos em Plenario",
+					"chave":        CriterioDiscursos,
+					"descricao":    "Pronunciamentos registrados pelo senador",
+					"normalizacao": "log(1 + Discursos) / log(1 + Maior numero da casa) * 100",
+				},
+				{
+					"nome":         "Relatorias",
+					"chave":        CriterioRelatorias,
+					"descricao":    "Materias relatadas em comissoes e plenario, ponderadas pelo tipo (PEC x3, PLP x2, PL x1)",
+					"normalizacao": "log(1 + Pontos) / log(1 + Maior pontuacao da casa) * 100",
+				},
+				{
+					"nome":         "Fidelidade Partidaria",
+					"chave":        CriterioFidelidade,
+					"descricao":    "Votos Sim/Nao iguais a orientacao do partido vigente na data. Sem orientacao da lideranca, vale a maioria da bancada",
+					"normalizacao": "Votos com o partido / Votos com orientacao do partido * 100",
+				},
+				{
+					"nome":         "Governismo",
+					"chave":        CriterioGovernismo,
+					"descricao":    "Votos Sim/Nao iguais a orientacao do lider do governo. Sem orientacao registrada, vale a maioria do partido do governo",
+					"normalizacao": "Votos com o governo / Votos com orientacao do governo * 100",
+				},
+			},
+		},
 		"escala": "Todos os scores sao normalizados para escala 0-100 antes da ponderacao",
 	}
 
 	c.JSON(http.StatusOK, metodologia)
 }
+
+// parseOpcoes le os criterios opcionais e o modo de comissoes da query string
+func parseOpcoes(c *gin.Context) Opcoes {
+	return Opcoes{Criterios: parseCriterios(c), ModoComissoes: c.Query("comissoes")}
+}
+
+// parseCriterios le os criterios opcionais de ?criterios=a,b
+func parseCriterios(c *gin.Context) []string {
+	param := c.Query("criterios")
+	if param == "" {
+		return nil
+	}
+	return strings.Split(param, ",")
+}
@@ -4,13 +4,13 @@ import "time"
 
 // SenadorScore representa o score completo de um senador
 type SenadorScore struct {
-	SenadorID int     `json:"senador_id"`
-	Nome      string  `json:"nome"`
-	Partido   string  `json:"partido"`
-	UF        string  `json:"uf"`
-	FotoURL   string  `json:"foto_url,omitempty"`
-	Cargo     string  `json:"cargo,omitempty"`
-	Titular   string  `json:"titular,omitempty"`
+	SenadorID int    `json:"senador_id"`
+	Nome      string `json:"nome"`
+	Partido   string `json:"partido"`
+	UF        string `json:"uf"`
+	FotoURL   string `json:"foto_url,omitempty"`
+	Cargo     string `json:"cargo,omitempty"`
+	Titular   string `json:"titular,omitempty"`
 
 	// Scores individuais normalizados (0-100)
 	Produtividade float64 `json:"produtividade"`
@@ -18,6 +18,16 @@ type SenadorScore struct {
 	EconomiaCota  float64 `json:"economia_cota"`
 	Comissoes     float64 `json:"comissoes"`
 
+	// Criterios opcionais (0-100), presentes apenas quando habilitados via ?criterios=
+	Discursos  *float64 `json:"discursos,omitempty"`
+	Relatorias *float64 `json:"relatorias,omitempty"`
+	Fidelidade *float64 `json:"fidelidade,omitempty"`
+	Governismo *float64 `json:"governismo,omitempty"`
+
+	// Criterios solicitados sem dados para o senador (ex.: nenhum voto comparavel com a
+	// orientacao); ficam fora do score e o peso volta para a formula base
+	NaoAplicaveis []string `json:"nao_aplicaveis,omitempty"`
+
 	// Score final ponderado (0-100)
 	ScoreFinal float64 `json:"score_final"`
 	Posicao    int     `json:"posicao"`
@@ -30,25 +40,49 @@ type SenadorScore struct {
 // ScoreDetalhes contem os dados brutos utilizados no calculo
 type ScoreDetalhes struct {
 	// Produtividade
-	TotalProposicoes     int `json:"total_proposicoes"`
-	ProposicoesAprovadas int `json:"proposicoes_aprovadas"`
-	TransformadasEmLei   int `json:"transformadas_em_lei"`
-	PontuacaoProposicoes float64 `json:"pontuacao_proposicoes"`
+	TotalProposicoes     int     `json:"total_proposicoes"`
+	ProposicoesAprovadas int     `json:"proposicoes_aprovadas"`
+	TransformadasEmLei   int     `json:"transformadas_em_lei"`
+	PontuacaoProposicoes float64 `json:"pontuacao_proposicoes"` // Ponderada pelo papel de autoria
+	ProposicoesCoautoria int     `json:"proposicoes_coautoria"`
 
 	// Presenca
 	TotalVotacoes        int     `json:"total_votacoes"`
 	VotacoesParticipadas int     `json:"votacoes_participadas"`
-	TaxaPresencaBruta    float64 `json:"taxa_presenca_bruta"`
+	TaxaPresenca         float64 `json:"taxa_presenca"`       // 0-100, desconsidera ausencias justificadas (usada no score)
+	TaxaPresencaBruta    float64 `json:"taxa_presenca_bruta"` // 0-100, conta todas as ausencias
+
+	AusenciasJustificadas    int `json:"ausencias_justificadas"`     // Licencas e missoes oficiais
+	AusenciasNaoJustificadas int `json:"ausencias_nao_justificadas"` // NCom fora de afastamento
 
 	// Economia CEAPS
 	GastoCEAPS float64 `json:"gasto_ceaps"`
 	TetoCEAPS  float64 `json:"teto_ceaps"`
+	// Meses em exercicio efetivo usados para o teto proporcional
+	MesesExercicio float64 `json:"meses_exercicio"`
+	// Sem historico de mandatos: MesesExercicio e o periodo de referencia inteiro
+	SemHistoricoExercicio bool `json:"sem_historico_exercicio,omitempty"`
 
 	// Comissoes
 	ComissoesAtivas   int     `json:"comissoes_ativas"`
 	ComissoesTitular  int     `json:"comissoes_titular"`
 	ComissoesSuplente int     `json:"comissoes_suplente"`
 	PontosComissoes   float64 `json:"pontos_comissoes"`
+
+	// Presenca em reunioes das comissoes em que e titular (modo de comissoes "presenca")
+	ReunioesComissaoEsperadas int     `json:"reunioes_comissao_esperadas"`
+	PresencasReunioesComissao int     `json:"presencas_reunioes_comissao"`
+	TaxaPresencaComissoes     float64 `json:"taxa_presenca_comissoes"`
+	// Calculo aplicado ao senador: no modo presenca, sem reunioes esperadas vale a participacao
+	ModoComissoes string `json:"modo_comissoes"`
+
+	// Criterios opcionais
+	TotalDiscursos         int      `json:"total_discursos"`
+	PontuacaoRelatorias    float64  `json:"pontuacao_relatorias"`
+	VotosComparadosPartido int      `json:"votos_comparados_partido"`
+	VotosComparadosGoverno int      `json:"votos_comparados_governo"`
+	IndiceFidelidade       *float64 `json:"indice_fidelidade"` // % de votos com a orientacao do partido; null sem votos comparaveis
+	IndiceGovernismo       *float64 `json:"indice_governismo"` // % de votos com a orientacao do governo; null sem votos comparaveis
 }
 
 // RankingResponse representa a resposta do endpoint de ranking
@@ -57,6 +91,9 @@ type RankingResponse struct {
 	Total       int            `json:"total"`
 	CalculadoEm time.Time      `json:"calculado_em"`
 	Metodologia string         `json:"metodologia"`
+	Criterios   []string       `json:"criterios,omitempty"` // Criterios opcionais aplicados
+
+	ModoComissoes string `json:"modo_comissoes"` // Calculo usado no criterio de comissoes
 }
 
 // Pesos dos criterios conforme metodologia-ranking.md
@@ -70,15 +107,22 @@ const (
 	TetoCEAPSMedia = 40000.0 * 12
 )
 
-// TetoCEAPSPorUF define o valor mensal do teto por estado (referencia marco 2025, reajuste 12%)
-// Fonte: Senado Federal - Ato da Comissao Diretora (atualizado 10/01/2026)
-// Media nacional: R$ 46.402,62/mes
-var TetoCEAPSPorUF = map[string]float64{
-	"AC": 50426.26, "AL": 44500.00, "AM": 52798.82, "AP": 51103.82,
-	"BA": 45000.00, "CE": 48245.57, "DF": 36582.46, "ES": 42000.00,
-	"GO": 36582.46, "MA": 47500.00, "MG": 40000.00, "MS": 42000.00,
-	"MT": 44500.00, "PA": 48207.30, "PB": 45000.00, "PE": 46000.00,
-	"PI": 49000.00, "PR": 43000.00, "RJ": 42000.00, "RN": 46000.00,
-	"RO": 44000.00, "RR": 51500.00, "RS": 45500.00, "SC": 42000.00,
-	"SE": 53000.00, "SP": 40000.00, "TO": 36582.46,
-}
+// Criterios opcionais, fora da formula padrao e habilitados via ?criterios=a,b
+// Cada criterio habilitado recebe PesoCriterioOpcional e os pesos base
+// sao reduzidos proporcionalmente para que a soma continue sendo 1
+const (
+	CriterioDiscursos  = "discursos"
+	CriterioRelatorias = "relatorias"
+	CriterioFidelidade = "fidelidade"
+	CriterioGovernismo = "governismo"
+
+	PesoCriterioOpcional = 0.10
+)
+
+// Modos de calculo do criterio de comissoes, escolhidos via ?comissoes=
+const (
+	// Pontos por participacao: Titular = 2, Suplente = 1, Ativa = +1 (padrao)
+	ComissoesParticipacao = "participacao"
+	// Taxa de presenca nas reunioes das comissoes em que o senador e titular
+	ComissoesPresenca = "presenca"
+)
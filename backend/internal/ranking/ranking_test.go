@@ -2,6 +2,11 @@ package ranking
 
 import (
 	"testing"
+
+	"github.com/Alzarus/to-de-olho/internal/ceaps"
+	"github.com/Alzarus/to-de-olho/internal/discurso"
+	"github.com/Alzarus/to-de-olho/internal/relatoria"
+	"github.com/Alzarus/to-de-olho/internal/senador"
 )
 
 // TestArredondar verifica se a funcao auxiliar de arredondamento funciona como esperado
@@ -60,3 +65,185 @@ func TestCalculoPesosSimples(t *testing.T) {
 		t.Errorf("Score apenas com presenca esperado 25.0, obtido %f", scoreFinal)
 	}
 }
+
+// TestFiltrarCriterios garante que criterios desconhecidos ou indisponiveis sao descartados
+func TestFiltrarCriterios(t *testing.T) {
+	semRepo := &Service{}
+	if got := semRepo.filtrarCriterios([]string{"discursos"}); len(got) != 0 {
+		t.Errorf("sem repositorio de discursos esperado nenhum criterio, obtido %v", got)
+	}
+
+	comRepo := &Service{discursoRepo: &discurso.Repository{}}
+	got := comRepo.filtrarCriterios([]string{" Discursos", "inexistente", "discursos", "fidelidade"})
+	if len(got) != 1 || got[0] != CriterioDiscursos {
+		t.Errorf("esperado [discursos], obtido %v", got)
+	}
+
+	comRepo.HabilitarAlinhamento()
+	got = comRepo.filtrarCriterios([]string{"governismo", "fidelidade"})
+	if len(got) != 2 || got[0] != CriterioFidelidade || got[1] != CriterioGovernismo {
+		t.Errorf("esperado [fidelidade governismo], obtido %v", got)
+	}
+}
+
+// TestAlinhamentoSemVotosComparaveis verifica que o senador sem votos comparaveis fica
+// sem o criterio (N/A) em vez de receber 0
+func TestAlinhamentoSemVotosComparaveis(t *testing.T) {
+	s := &Service{alinhamentoHabilitado: true}
+	maximos := maximosCasa{pontuacaoProd: 10, pontosComissoes: 4}
+	base := dadosBrutosSenador{pontuacaoProposicoes: 10, taxaPresenca: 100, pontosComissoes: 4}
+	criterios := []string{CriterioFidelidade, CriterioGovernismo}
+
+	fidelidade := 50.0
+	dados := base
+	dados.fidelidade = &fidelidade
+	score := s.calcularScoreNormalizado(senador.Senador{}, &dados, maximos, nil, criterios, ComissoesParticipacao)
+	// Base 100 * 0.90 + fidelidade 50 * 0.10; governismo N/A
+	if score.ScoreFinal != 95 || score.Governismo != nil || score.Fidelidade == nil {
+		t.Errorf("score inesperado: final %.2f fidelidade %v governismo %v", score.ScoreFinal, score.Fidelidade, score.Governismo)
+	}
+	if len(score.NaoAplicaveis) != 1 || score.NaoAplicaveis[0] != CriterioGovernismo {
+		t.Errorf("nao aplicaveis inesperados: %v", score.NaoAplicaveis)
+	}
+
+	dados = base
+	score = s.calcularScoreNormalizado(senador.Senador{}, &dados, maximos, nil, criterios, ComissoesParticipacao)
+	if score.ScoreFinal != 100 || len(score.NaoAplicaveis) != 2 || score.Detalhes.IndiceFidelidade != nil {
+		t.Errorf("sem votos comparaveis esperado score base 100, obtido %.2f (%v)", score.ScoreFinal, score.NaoAplicaveis)
+	}
+}
+
+// TestCriterioOpcionalReescalaPesos verifica que o score maximo continua 100 com criterios opcionais
+func TestCriterioOpcionalReescalaPesos(t *testing.T) {
+	s := &Service{}
+	maximos := maximosCasa{pontuacaoProd: 10, pontosComissoes: 4, discursos: 50}
+	dados := &dadosBrutosSenador{
+		pontuacaoProposicoes: 10,
+		taxaPresenca:         100,
+		pontosComissoes:      4,
+		totalDiscursos:       50,
+	}
+
+	score := s.calcularScoreNormalizado(senador.Senador{}, dados, maximos, nil, []string{CriterioDiscursos}, ComissoesParticipacao)
+	if score.Discursos == nil || *score.Discursos != 100 {
+		t.Fatalf("score de discursos esperado 100, obtido %v", score.Discursos)
+	}
+	if score.ScoreFinal != 100 {
+		t.Errorf("score final esperado 100, obtido %f", score.ScoreFinal)
+	}
+
+	dados.totalDiscursos = 0
+	score = s.calcularScoreNormalizado(senador.Senador{}, dados, maximos, nil, []string{CriterioDiscursos}, ComissoesParticipacao)
+	if score.ScoreFinal != 90 {
+		t.Errorf("score final sem discursos esperado 90, obtido %f", score.ScoreFinal)
+	}
+}
+
+// TestDoisCriteriosOpcionais verifica a reescala com discursos e relatorias habilitados
+func TestDoisCriteriosOpcionais(t *testing.T) {
+	s := &Service{discursoRepo: &discurso.Repository{}, relatoriaRepo: &relatoria.Repository{}}
+	criterios := s.filtrarCriterios([]string{"relatorias", "discursos"})
+	if len(criterios) != 2 || criterios[0] != CriterioDiscursos || criterios[1] != CriterioRelatorias {
+		t.Fatalf("criterios inesperados: %v", criterios)
+	}
+
+	maximos := maximosCasa{pontuacaoProd: 10, pontosComissoes: 4, discursos: 50, relatorias: 12}
+	dados := &dadosBrutosSenador{
+		pontuacaoProposicoes: 10,
+		taxaPresenca:         100,
+		pontosComissoes:      4,
+		pontuacaoRelatorias:  12,
+	}
+
+	score := s.calcularScoreNormalizado(senador.Senador{}, dados, maximos, nil, criterios, ComissoesParticipacao)
+	if score.Relatorias == nil || *score.Relatorias != 100 {
+		t.Fatalf("score de relatorias esperado 100, obtido %v", score.Relatorias)
+	}
+	// Base 100 * 0.80 + discursos 0 * 0.10 + relatorias 100 * 0.10
+	if score.ScoreFinal != 90 {
+		t.Errorf("score final esperado 90, obtido %f", score.ScoreFinal)
+	}
+}
+
+// TestTetoProporcionalAoExercicio verifica que suplentes/afastados tem o teto CEAPS
+// calculado apenas sobre os meses em exercicio efetivo
+func TestTetoProporcionalAoExercicio(t *testing.T) {
+	s := &Service{}
+	ano := 2024
+	maximos := maximosCasa{pontuacaoProd: 1, pontosComissoes: 1}
+	sen := senador.Senador{UF: "BA"}
+
+	// 6 meses em exercicio gastando o equivalente a 3 meses de teto
+	meses := 6.0
+	dados := &dadosBrutosSenador{mesesExercicio: &meses, gastoAnual: ceaps.TetoMensalPorUF["BA"] * 3}
+	score := s.calcularScoreNormalizado(sen, dados, maximos, &ano, nil, ComissoesParticipacao)
+	if score.EconomiaCota != 50 {
+		t.Errorf("economia esperada 50, obtido %.2f", score.EconomiaCota)
+	}
+	if score.Detalhes.MesesExercicio != 6 {
+		t.Errorf("meses de exercicio esperados 6, obtido %.2f", score.Detalhes.MesesExercicio)
+	}
+
+	// Sem historico de mandatos: ano completo
+	dados = &dadosBrutosSenador{gastoAnual: ceaps.TetoMensalPorUF["BA"] * 3}
+	score = s.calcularScoreNormalizado(sen, dados, maximos, &ano, nil, ComissoesParticipacao)
+	if score.EconomiaCota != 75 || score.Detalhes.TetoCEAPS != ceaps.TetoMensalPorUF["BA"]*12 || !score.Detalhes.SemHistoricoExercicio {
+		t.Errorf("esperado teto anual completo, obtido economia %.2f teto %.2f", score.EconomiaCota, score.Detalhes.TetoCEAPS)
+	}
+	if !dados.exerceuNoPeriodo() {
+		t.Error("senador sem historico de mandatos deveria entrar no ranking")
+	}
+
+	// Com historico, mas sem nenhum dia em exercicio no periodo: fora do ranking
+	meses = 0
+	dados = &dadosBrutosSenador{mesesExercicio: &meses}
+	if dados.exerceuNoPeriodo() {
+		t.Error("senador sem exercicio no periodo deveria ficar fora do ranking")
+	}
+}
+
+// TestModoComissoesPresenca verifica que o modo presenca usa a taxa de presenca em
+// reunioes no lugar dos pontos por participacao
+func TestModoComissoesPresenca(t *testing.T) {
+	s := &Service{}
+	maximos := maximosCasa{pontuacaoProd: 1, pontosComissoes: 10}
+	dados := &dadosBrutosSenador{pontosComissoes: 10, reunioesComissaoEsperadas: 20, taxaPresencaComissoes: 40}
+
+	score := s.calcularScoreNormalizado(senador.Senador{}, dados, maximos, nil, nil, ComissoesParticipacao)
+	if score.Comissoes != 100 {
+		t.Errorf("comissoes por participacao esperado 100, obtido %.2f", score.Comissoes)
+	}
+
+	score = s.calcularScoreNormalizado(senador.Senador{}, dados, maximos, nil, nil, ComissoesPresenca)
+	if score.Comissoes != 40 || score.Detalhes.ModoComissoes != ComissoesPresenca {
+		t.Errorf("comissoes por presenca esperado 40, obtido %.2f (%s)", score.Comissoes, score.Detalhes.ModoComissoes)
+	}
+
+	// Sem reunioes esperadas a taxa de presenca nao se aplica: vale a participacao
+	dados.reunioesComissaoEsperadas, dados.taxaPresencaComissoes = 0, 0
+	score = s.calcularScoreNormalizado(senador.Senador{}, dados, maximos, nil, nil, ComissoesPresenca)
+	if score.Comissoes != 100 || score.Detalhes.ModoComissoes != ComissoesParticipacao {
+		t.Errorf("sem reunioes esperado fallback para participacao, obtido %.2f (%s)", score.Comissoes, score.Detalhes.ModoComissoes)
+	}
+
+	if normalizarModoComissoes(" Presenca ") != ComissoesPresenca || normalizarModoComissoes("xyz") != ComissoesParticipacao {
+		t.Error("normalizacao do modo de comissoes incorreta")
+	}
+}
+
+// TestTaxasPresencaNosDetalhes garante que o score usa a taxa que desconsidera ausencias
+// justificadas e que os detalhes expoem as duas taxas com o mesmo sentido de votacao
+func TestTaxasPresencaNosDetalhes(t *testing.T) {
+	s := &Service{}
+	maximos := maximosCasa{pontuacaoProd: 1, pontosComissoes: 1}
+	dados := &dadosBrutosSenador{taxaPresenca: 80, taxaPresencaBruta: 60}
+
+	score := s.calcularScoreNormalizado(senador.Senador{}, dados, maximos, nil, nil, ComissoesParticipacao)
+	if score.Presenca != 80 {
+		t.Errorf("presenca no score esperada 80, obtido %.2f", score.Presenca)
+	}
+	if score.Detalhes.TaxaPresenca != 80 || score.Detalhes.TaxaPresencaBruta != 60 {
+		t.Errorf("taxas nos detalhes: presenca %.2f bruta %.2f; esperado 80 e 60",
+			score.Detalhes.TaxaPresenca, score.Detalhes.TaxaPresencaBruta)
+	}
+}
@@ -6,12 +6,16 @@ import (
 	"log/slog"
 	"math"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/Alzarus/to-de-olho/internal/ceaps"
 	"github.com/Alzarus/to-de-olho/internal/comissao"
+	"github.com/Alzarus/to-de-olho/internal/discurso"
 	"github.com/Alzarus/to-de-olho/internal/proposicao"
+	"github.com/Alzarus/to-de-olho/internal/relatoria"
 	"github.com/Alzarus/to-de-olho/internal/senador"
+	"github.com/Alzarus/to-de-olho/internal/utils"
 	"github.com/Alzarus/to-de-olho/internal/votacao"
 )
 
@@ -22,6 +26,13 @@ type Service struct {
 	votacaoRepo    *votacao.Repository
 	ceapsRepo      *ceaps.Repository
 	comissaoRepo   *comissao.Repository
+
+	// Repositorios de criterios opcionais (nil = criterio indisponivel)
+	discursoRepo  *discurso.Repository
+	relatoriaRepo *relatoria.Repository
+
+	// Fidelidade e governismo dependem das orientacoes de bancada sincronizadas
+	alinhamentoHabilitado bool
 }
 
 // NewService cria um novo servico de ranking
@@ -41,14 +52,72 @@ func NewService(
 	}
 }
 
-// CalcularRanking calcula o ranking de todos os senadores
+// SetDiscursoRepository habilita o criterio opcional de discursos
+func (s *Service) SetDiscursoRepository(repo *discurso.Repository) {
+	s.discursoRepo = repo
+}
+
+// SetRelatoriaRepository habilita o criterio opcional de relatorias
+func (s *Service) SetRelatoriaRepository(repo *relatoria.Repository) {
+	s.relatoriaRepo = repo
+}
+
+// HabilitarAlinhamento habilita os criterios opcionais de fidelidade partidaria e
+// governismo, calculados a partir das orientacoes de bancada das votacoes
+func (s *Service) HabilitarAlinhamento() {
+	s.alinhamentoHabilitado = true
+}
+
+// CriteriosDisponiveis retorna os criterios opcionais que podem ser habilitados
+func (s *Service) CriteriosDisponiveis() []string {
+	var criterios []string
+	if s.discursoRepo != nil {
+		criterios = append(criterios, CriterioDiscursos)
+	}
+	if s.relatoriaRepo != nil {
+		criterios = append(criterios, CriterioRelatorias)
+	}
+	if s.alinhamentoHabilitado {
+		criterios = append(criterios, CriterioFidelidade, CriterioGovernismo)
+	}
+	return criterios
+}
+
+// CalcularRanking calcula o ranking de todos os senadores com a formula padrao
 func (s *Service) CalcularRanking(ctx context.Context, ano *int) (*RankingResponse, error) {
+	return s.CalcularRankingComCriterios(ctx, ano, nil)
+}
+
+// Opcoes define as variacoes do calculo do ranking
+type Opcoes struct {
+	Criterios     []string // Criterios opcionais (?criterios=)
+	ModoComissoes string   // ComissoesParticipacao (padrao) ou ComissoesPresenca
+}
+
+// CalcularRankingComCriterios calcula o ranking incluindo criterios opcionais.
+// Criterios desconhecidos ou indisponiveis sao ignorados.
+func (s *Service) CalcularRankingComCriterios(ctx context.Context, ano *int, criteriosSolicitados []string) (*RankingResponse, error) {
+	return s.CalcularRankingComOpcoes(ctx, ano, Opcoes{Criterios: criteriosSolicitados})
+}
+
+// CalcularRankingComOpcoes calcula o ranking com criterios opcionais e o modo de
+// calculo do criterio de comissoes
+func (s *Service) CalcularRankingComOpcoes(ctx context.Context, ano *int, opcoes Opcoes) (*RankingResponse, error) {
+	criterios := s.filtrarCriterios(opcoes.Criterios)
+	modoComissoes := normalizarModoComissoes(opcoes.ModoComissoes)
+
 	// 1. Tentar buscar do cache (Memória Local)
 	// [COST-SAVING] Substituicao do Redis por cache em memoria local
 	cacheKey := "ranking:v2:geral"
 	if ano != nil {
 		cacheKey = fmt.Sprintf("ranking:v2:%d", *ano)
 	}
+	if len(criterios) > 0 {
+		cacheKey += ":" + strings.Join(criterios, ",")
+	}
+	if modoComissoes != ComissoesParticipacao {
+		cacheKey += ":comissoes=" + modoComissoes
+	}
 
 	// Tenta pegar do cache local
 	if cached := localCache.Get(cacheKey); cached != nil {
@@ -65,38 +134,61 @@ func (s *Service) CalcularRanking(ctx context.Context, ano *int) (*RankingRespon
 	}
 
 	// Primeiro, coletar dados brutos de todos para normalizacao
-	var maxPontuacaoProd float64
-	var maxPontosComissoes float64
+	var maximos maximosCasa
 
 	dadosBrutos := make(map[int]*dadosBrutosSenador)
 
 	for _, sen := range senadores {
-		dados := s.coletarDadosBrutos(sen.ID, ano)
+		dados := s.coletarDadosBrutos(sen.ID, ano, criterios, modoComissoes)
+		if !dados.exerceuNoPeriodo() {
+			// Sem nenhum dia em exercicio no periodo (ex.: suplente que so assumiu depois)
+			continue
+		}
 		dadosBrutos[sen.ID] = dados
 
-		if dados.pontuacaoProposicoes > maxPontuacaoProd {
-			maxPontuacaoProd = dados.pontuacaoProposicoes
+		if dados.pontuacaoProposicoes > maximos.pontuacaoProd {
+			maximos.pontuacaoProd = dados.pontuacaoProposicoes
+		}
+		if dados.pontosComissoes > maximos.pontosComissoes {
+			maximos.pontosComissoes = dados.pontosComissoes
 		}
-		if dados.pontosComissoes > maxPontosComissoes {
-			maxPontosComissoes = dados.pontosComissoes
+		if float64(dados.totalDiscursos) > maximos.discursos {
+			maximos.discursos = float64(dados.totalDiscursos)
+		}
+		if dados.pontuacaoRelatorias > maximos.relatorias {
+			maximos.relatorias = dados.pontuacaoRelatorias
 		}
 	}
 
 	// Garantir minimos para evitar divisao por zero
-	if maxPontuacaoProd == 0 {
-		maxPontuacaoProd = 1
-	}
-	if maxPontosComissoes == 0 {
-		maxPontosComissoes = 1
+	maximos.garantirMinimos()
+
+	// Em rankings anuais, exibir o partido vigente ao fim do ano (ou hoje, no ano corrente)
+	var filiacoes map[int][]senador.Filiacao
+	var dataPartido time.Time
+	if ano != nil {
+		if filiacoes, err = s.senadorRepo.FindAllFiliacoes(); err != nil {
+			slog.Warn("falha ao buscar filiacoes, usando partido atual", "error", err)
+		}
+		_, fim := periodoReferencia(ano, time.Now())
+		dataPartido = fim.AddDate(0, 0, -1)
+		if agora := time.Now(); dataPartido.After(agora) {
+			dataPartido = agora
+		}
 	}
 
 	// Calcular scores normalizados
 	var scores []SenadorScore
 
-
 	for _, sen := range senadores {
-		dados := dadosBrutos[sen.ID]
-		score := s.calcularScoreNormalizado(sen, dados, maxPontuacaoProd, maxPontosComissoes, ano)
+		dados, ok := dadosBrutos[sen.ID]
+		if !ok {
+			continue
+		}
+		if historico, ok := filiacoes[sen.ID]; ok {
+			sen.Partido = senador.PartidoNaData(historico, dataPartido, sen.Partido)
+		}
+		score := s.calcularScoreNormalizado(sen, dados, maximos, ano, criterios, modoComissoes)
 		scores = append(scores, score)
 	}
 
@@ -112,16 +204,14 @@ func (s *Service) CalcularRanking(ctx context.Context, ano *int) (*RankingRespon
 
 	slog.Info("ranking calculado", "total_senadores", len(scores))
 
-	metodologia := "Score = (Produtividade * 0.35) + (Presenca * 0.25) + (Economia * 0.20) + (Comissoes * 0.20)"
-	if ano != nil {
-		metodologia = fmt.Sprintf("Score (Ano %d) = (Produtividade * 0.35) + (Presenca * 0.25) + (Economia * 0.20) + (Comissoes * 0.20)", *ano)
-	}
-
 	response := &RankingResponse{
 		Ranking:     scores,
 		Total:       len(scores),
 		CalculadoEm: time.Now(),
-		Metodologia: metodologia,
+		Metodologia: descreverMetodologia(ano, criterios, modoComissoes),
+		Criterios:   criterios,
+
+		ModoComissoes: modoComissoes,
 	}
 
 	// Salvar no cache local (TTL 24 horas)
@@ -137,10 +227,10 @@ func (s *Service) InvalidateCache() {
 }
 
 // CalcularScoreSenador calcula o score de um senador especifico
-func (s *Service) CalcularScoreSenador(ctx context.Context, senadorID int, ano *int) (*SenadorScore, error) {
+func (s *Service) CalcularScoreSenador(ctx context.Context, senadorID int, ano *int, opcoes Opcoes) (*SenadorScore, error) {
 	// Reutilizar o calculo do ranking completo para garantir consistencia da posicao
 	// Como o ranking tem cache, isso e eficiente
-	ranking, err := s.CalcularRanking(ctx, ano)
+	ranking, err := s.CalcularRankingComOpcoes(ctx, ano, opcoes)
 	if err != nil {
 		return nil, err
 	}
@@ -155,6 +245,107 @@ func (s *Service) CalcularScoreSenador(ctx context.Context, senadorID int, ano *
 	return nil, fmt.Errorf("senador nao encontrado no ranking")
 }
 
+// filtrarCriterios remove criterios desconhecidos/indisponiveis e ordena
+// para que a chave de cache seja estavel
+func (s *Service) filtrarCriterios(solicitados []string) []string {
+	disponiveis := make(map[string]bool)
+	for _, c := range s.CriteriosDisponiveis() {
+		disponiveis[c] = true
+	}
+
+	var criterios []string
+	vistos := make(map[string]bool)
+	for _, c := range solicitados {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if disponiveis[c] && !vistos[c] {
+			criterios = append(criterios, c)
+			vistos[c] = true
+		}
+	}
+	sort.Strings(criterios)
+	return criterios
+}
+
+// normalizarModoComissoes retorna o modo de comissoes informado ou o padrao
+func normalizarModoComissoes(modo string) string {
+	if strings.ToLower(strings.TrimSpace(modo)) == ComissoesPresenca {
+		return ComissoesPresenca
+	}
+	return ComissoesParticipacao
+}
+
+// descreverMetodologia monta a formula aplicada no ranking
+func descreverMetodologia(ano *int, criterios []string, modoComissoes string) string {
+	prefixo := "Score"
+	if ano != nil {
+		prefixo = fmt.Sprintf("Score (Ano %d)", *ano)
+	}
+
+	comissoes := "Comissoes"
+	if modoComissoes == ComissoesPresenca {
+		comissoes = "PresencaComissoes"
+	}
+	base := "(Produtividade * 0.35) + (Presenca * 0.25) + (Economia * 0.20) + (" + comissoes + " * 0.20)"
+	if len(criterios) == 0 {
+		return prefixo + " = " + base
+	}
+
+	pesoBase := 1 - float64(len(criterios))*PesoCriterioOpcional
+	formula := fmt.Sprintf("[%s] * %.2f", base, pesoBase)
+	for _, c := range criterios {
+		formula += fmt.Sprintf(" + (%s * %.2f)", rotuloCriterio(c), PesoCriterioOpcional)
+	}
+	return prefixo + " = " + formula
+}
+
+// rotuloCriterio retorna o nome exibido de um criterio opcional na formula
+func rotuloCriterio(criterio string) string {
+	switch criterio {
+	case CriterioDiscursos:
+		return "Discursos"
+	case CriterioRelatorias:
+		return "Relatorias"
+	case CriterioFidelidade:
+		return "Fidelidade"
+	case CriterioGovernismo:
+		return "Governismo"
+	}
+	return criterio
+}
+
+// maximosCasa guarda os maiores valores brutos da casa usados na normalizacao
+type maximosCasa struct {
+	pontuacaoProd   float64
+	pontosComissoes float64
+	discursos       float64
+	relatorias      float64
+}
+
+// garantirMinimos evita divisao por zero quando ninguem pontuou em um criterio
+func (m *maximosCasa) garantirMinimos() {
+	if m.pontuacaoProd == 0 {
+		m.pontuacaoProd = 1
+	}
+	if m.pontosComissoes == 0 {
+		m.pontosComissoes = 1
+	}
+	if m.discursos == 0 {
+		m.discursos = 1
+	}
+	if m.relatorias == 0 {
+		m.relatorias = 1
+	}
+}
+
+// periodoReferencia retorna o intervalo do ranking: o ano completo informado ou,
+// quando ano e nil, do inicio da legislatura atual (1o de fevereiro) ate agora
+func periodoReferencia(ano *int, agora time.Time) (time.Time, time.Time) {
+	if ano != nil {
+		return time.Date(*ano, time.January, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(*ano+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(utils.GetInicioLegislaturaAtual(), time.February, 1, 0, 0, 0, 0, time.UTC), agora
+}
 
 // dadosBrutosSenador armazena dados brutos antes da normalizacao
 type dadosBrutosSenador struct {
@@ -163,24 +354,48 @@ type dadosBrutosSenador struct {
 	proposicoesAprovadas int
 	transformadasEmLei   int
 	pontuacaoProposicoes float64
+	proposicoesCoautor   int
 
 	// Votacoes
-	totalVotacoes     int
-	votosRegistrados  int
-	taxaPresencaBruta float64
+	totalVotacoes            int
+	votosRegistrados         int
+	ausenciasJustificadas    int
+	ausenciasNaoJustificadas int
+	taxaPresenca             float64 // desconsidera ausencias justificadas (licencas/missoes); entra no score
+	taxaPresencaBruta        float64 // conta todas as ausencias
 
 	// CEAPS
-	gastoAnual float64
+	gastoAnual     float64
+	mesesExercicio *float64 // meses em exercicio no periodo; nil sem historico de mandatos
 
 	// Comissoes
 	comissoesAtivas   int
 	comissoesTitular  int
 	comissoesSuplente int
 	pontosComissoes   float64
+
+	reunioesComissaoEsperadas int
+	presencasReunioesComissao int
+	taxaPresencaComissoes     float64 // 0-100
+
+	// Criterios opcionais
+	totalDiscursos         int
+	pontuacaoRelatorias    float64
+	votosComparadosPartido int
+	votosComparadosGoverno int
+	fidelidade             *float64 // 0-100; nil sem votos comparaveis
+	governismo             *float64 // 0-100; nil sem votos comparaveis
 }
 
-// coletarDadosBrutos busca dados de todos os modulos para um senador
-func (s *Service) coletarDadosBrutos(senadorID int, ano *int) *dadosBrutosSenador {
+// exerceuNoPeriodo indica se o senador esteve em exercicio no periodo de referencia.
+// Sem historico de mandatos, assume que sim.
+func (d *dadosBrutosSenador) exerceuNoPeriodo() bool {
+	return d.mesesExercicio == nil || *d.mesesExercicio > 0
+}
+
+// coletarDadosBrutos busca dados de todos os modulos para um senador. Os criterios
+// opcionais so sao consultados quando solicitados.
+func (s *Service) coletarDadosBrutos(senadorID int, ano *int, criterios []string, modoComissoes string) *dadosBrutosSenador {
 	dados := &dadosBrutosSenador{}
 
 	// Proposicoes
@@ -197,6 +412,7 @@ func (s *Service) coletarDadosBrutos(senadorID int, ano *int) *dadosBrutosSenado
 		dados.proposicoesAprovadas = propStats.AprovadosPlenario
 		dados.transformadasEmLei = propStats.TransformadasEmLei
 		dados.pontuacaoProposicoes = propStats.PontuacaoTotal
+		dados.proposicoesCoautor = propStats.ComoCoautor
 	}
 
 	// Votacoes
@@ -210,7 +426,10 @@ func (s *Service) coletarDadosBrutos(senadorID int, ano *int) *dadosBrutosSenado
 	if err == nil {
 		dados.totalVotacoes = votStats.TotalVotacoes
 		dados.votosRegistrados = votStats.VotosRegistrados
-		dados.taxaPresencaBruta = votStats.TaxaPresenca
+		dados.ausenciasJustificadas = votStats.AusenciasJustificadas
+		dados.ausenciasNaoJustificadas = votStats.AusenciasNaoJustificadas
+		dados.taxaPresenca = votStats.TaxaPresenca
+		dados.taxaPresencaBruta = votStats.TaxaPresencaBruta
 	}
 
 	// CEAPS
@@ -227,6 +446,13 @@ func (s *Service) coletarDadosBrutos(senadorID int, ano *int) *dadosBrutosSenado
 		}
 	}
 
+	// Periodo de exercicio efetivo (define o teto CEAPS proporcional)
+	if exercicios, err := s.senadorRepo.FindExercicios(senadorID); err == nil && len(exercicios) > 0 {
+		inicio, fim := periodoReferencia(ano, time.Now())
+		meses := senador.MesesEmExercicio(exercicios, inicio, fim)
+		dados.mesesExercicio = &meses
+	}
+
 	// Comissoes
 	var comStats *comissao.ComissaoStats
 	if ano != nil {
@@ -243,6 +469,48 @@ func (s *Service) coletarDadosBrutos(senadorID int, ano *int) *dadosBrutosSenado
 		dados.pontosComissoes = float64(comStats.ComissoesTitular*2 + comStats.ComissoesSuplente + comStats.ComissoesAtivas)
 	}
 
+	// Presenca em reunioes de comissoes (modo alternativo do criterio de comissoes)
+	anoReferencia := 0
+	if ano != nil {
+		anoReferencia = *ano
+	}
+	if modoComissoes == ComissoesPresenca {
+		if presenca, err := s.comissaoRepo.GetPresencaStats(senadorID, anoReferencia); err == nil {
+			dados.reunioesComissaoEsperadas = presenca.ReunioesEsperadas
+			dados.presencasReunioesComissao = presenca.Presencas
+			dados.taxaPresencaComissoes = presenca.TaxaPresenca
+		}
+	}
+
+	// Fidelidade partidaria e governismo (criterios opcionais, ja em 0-100); sem votos
+	// comparaveis o indice fica nil (N/A)
+	if contem(criterios, CriterioFidelidade) || contem(criterios, CriterioGovernismo) {
+		if alinhamento, err := s.votacaoRepo.GetAlinhamento(senadorID, anoReferencia); err == nil {
+			dados.votosComparadosPartido = alinhamento.VotosComparadosPartido
+			dados.votosComparadosGoverno = alinhamento.VotosComparadosGoverno
+			if alinhamento.VotosComparadosPartido > 0 {
+				dados.fidelidade = &alinhamento.Fidelidade
+			}
+			if alinhamento.VotosComparadosGoverno > 0 {
+				dados.governismo = &alinhamento.Governismo
+			}
+		}
+	}
+
+	// Discursos (criterio opcional)
+	if s.discursoRepo != nil && contem(criterios, CriterioDiscursos) {
+		if total, err := s.discursoRepo.CountBySenadorID(senadorID, ano); err == nil {
+			dados.totalDiscursos = int(total)
+		}
+	}
+
+	// Relatorias (criterio opcional)
+	if s.relatoriaRepo != nil && contem(criterios, CriterioRelatorias) {
+		if pontos, err := s.relatoriaRepo.GetPontuacao(senadorID, ano); err == nil {
+			dados.pontuacaoRelatorias = pontos
+		}
+	}
+
 	return dados
 }
 
@@ -250,37 +518,42 @@ func (s *Service) coletarDadosBrutos(senadorID int, ano *int) *dadosBrutosSenado
 func (s *Service) calcularScoreNormalizado(
 	sen senador.Senador,
 	dados *dadosBrutosSenador,
-	maxPontuacaoProd float64,
-	maxPontosComissoes float64,
+	maximos maximosCasa,
 	ano *int,
+	criterios []string,
+	modoComissoes string,
 ) SenadorScore {
 	// Normalizar Produtividade (0-100) com Logaritmo para suavizar outliers
-	produtividade := (math.Log1p(dados.pontuacaoProposicoes) / math.Log1p(maxPontuacaoProd)) * 100
+	produtividade := (math.Log1p(dados.pontuacaoProposicoes) / math.Log1p(maximos.pontuacaoProd)) * 100
 
 	// Presenca ja vem normalizada (0-100)
-	presenca := dados.taxaPresencaBruta
+	presenca := dados.taxaPresenca
 
 	// Economia CEAPS (0-100)
 	// Quanto menos gasta, maior o score
 	// Buscar teto da UF, se nao houver usa media
-	tetoMensal, ok := TetoCEAPSPorUF[sen.UF]
+	tetoMensal, ok := ceaps.TetoMensalPorUF[sen.UF]
 	if !ok {
 		tetoMensal = 40000.0 // Fallback seguro
 	}
 	var tetoPeriodo float64
-	
-	if ano != nil {
-		// Teto anual para um ano especifico
-		tetoPeriodo = tetoMensal * 12
+
+	// Teto proporcional aos meses em exercicio efetivo; sem historico de mandatos,
+	// assume exercicio durante todo o periodo de referencia
+	var mesesPeriodo float64
+	if dados.mesesExercicio != nil {
+		mesesPeriodo = *dados.mesesExercicio
 	} else {
-		// Teto acumulado do mandato (desde Fev/2023 ate agora)
-		inicioMandato := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
-		mesesCorridos := time.Since(inicioMandato).Hours() / 24 / 30
-		if mesesCorridos < 1 {
-			mesesCorridos = 1
-		}
-		tetoPeriodo = tetoMensal * mesesCorridos
+		inicio, fim := periodoReferencia(ano, time.Now())
+		mesesPeriodo = fim.Sub(inicio).Hours() / 24 / 30
+	}
+	if ano != nil && mesesPeriodo > 12 {
+		mesesPeriodo = 12
+	}
+	if mesesPeriodo < 1 {
+		mesesPeriodo = 1
 	}
+	tetoPeriodo = tetoMensal * mesesPeriodo
 
 	economia := (1 - (dados.gastoAnual / tetoPeriodo)) * 100
 	if economia < 0 {
@@ -290,8 +563,15 @@ func (s *Service) calcularScoreNormalizado(
 		economia = 100 // Cap em 100
 	}
 
-	// Comissoes (0-100)
-	comissoes := (dados.pontosComissoes / maxPontosComissoes) * 100
+	// Comissoes (0-100): pontos por participacao ou, no modo presenca, a taxa de
+	// presenca nas reunioes (ja em 0-100, sem normalizacao pelo maximo da casa).
+	// Sem reunioes esperadas a taxa nao se aplica e vale a participacao.
+	comissoes := (dados.pontosComissoes / maximos.pontosComissoes) * 100
+	modoAplicado := ComissoesParticipacao
+	if modoComissoes == ComissoesPresenca && dados.reunioesComissaoEsperadas > 0 {
+		comissoes = dados.taxaPresencaComissoes
+		modoAplicado = ComissoesPresenca
+	}
 
 	// Score final ponderado
 	scoreFinal := (produtividade * PesoProdutividade) +
@@ -299,6 +579,52 @@ func (s *Service) calcularScoreNormalizado(
 		(economia * PesoEconomia) +
 		(comissoes * PesoComissoes)
 
+	// Criterios opcionais: cada criterio aplicavel recebe peso fixo e a formula base e
+	// reescalada. Criterios sem dados para o senador (N/A) ficam fora da conta, e o
+	// peso deles volta para a formula base.
+	var discursos, relatorias, fidelidade, governismo *float64
+	var naoAplicaveis []string
+	opcionais := make(map[string]float64, len(criterios))
+	for _, c := range criterios {
+		switch c {
+		case CriterioDiscursos:
+			// Logaritmo pelo mesmo motivo da produtividade (poucos oradores muito frequentes)
+			valor := (math.Log1p(float64(dados.totalDiscursos)) / math.Log1p(maximos.discursos)) * 100
+			opcionais[c] = valor
+			valor = arredondar(valor)
+			discursos = &valor
+		case CriterioRelatorias:
+			// Pontuacao ja ponderada pelo tipo da materia (PEC x3, PLP x2, ...)
+			valor := (math.Log1p(dados.pontuacaoRelatorias) / math.Log1p(maximos.relatorias)) * 100
+			opcionais[c] = valor
+			valor = arredondar(valor)
+			relatorias = &valor
+		case CriterioFidelidade:
+			// Percentual direto, sem normalizacao pelo maximo da casa
+			if dados.fidelidade == nil {
+				naoAplicaveis = append(naoAplicaveis, c)
+				continue
+			}
+			opcionais[c] = *dados.fidelidade
+			valor := arredondar(*dados.fidelidade)
+			fidelidade = &valor
+		case CriterioGovernismo:
+			if dados.governismo == nil {
+				naoAplicaveis = append(naoAplicaveis, c)
+				continue
+			}
+			opcionais[c] = *dados.governismo
+			valor := arredondar(*dados.governismo)
+			governismo = &valor
+		}
+	}
+	if len(opcionais) > 0 {
+		scoreFinal *= 1 - float64(len(opcionais))*PesoCriterioOpcional
+		for _, valor := range opcionais {
+			scoreFinal += valor * PesoCriterioOpcional
+		}
+	}
+
 	return SenadorScore{
 		SenadorID:     sen.ID,
 		Nome:          sen.Nome,
@@ -311,26 +637,66 @@ func (s *Service) calcularScoreNormalizado(
 		Presenca:      arredondar(presenca),
 		EconomiaCota:  arredondar(economia),
 		Comissoes:     arredondar(comissoes),
+		Discursos:     discursos,
+		Relatorias:    relatorias,
+		Fidelidade:    fidelidade,
+		Governismo:    governismo,
+		NaoAplicaveis: naoAplicaveis,
 		ScoreFinal:    arredondar(scoreFinal),
 		CalculadoEm:   time.Now(),
 		Detalhes: ScoreDetalhes{
-			TotalProposicoes:     dados.totalProposicoes,
-			ProposicoesAprovadas: dados.proposicoesAprovadas,
-			TransformadasEmLei:   dados.transformadasEmLei,
-			PontuacaoProposicoes: dados.pontuacaoProposicoes,
-			TotalVotacoes:        dados.totalVotacoes,
-			VotacoesParticipadas: dados.votosRegistrados,
-			TaxaPresencaBruta:    arredondar(dados.taxaPresencaBruta),
-			GastoCEAPS:           arredondar(dados.gastoAnual),
-			TetoCEAPS:            tetoPeriodo,
-			ComissoesAtivas:      dados.comissoesAtivas,
-			ComissoesTitular:     dados.comissoesTitular,
-			ComissoesSuplente:    dados.comissoesSuplente,
-			PontosComissoes:      arredondar(dados.pontosComissoes),
+			TotalProposicoes:          dados.totalProposicoes,
+			ProposicoesAprovadas:      dados.proposicoesAprovadas,
+			TransformadasEmLei:        dados.transformadasEmLei,
+			PontuacaoProposicoes:      dados.pontuacaoProposicoes,
+			ProposicoesCoautoria:      dados.proposicoesCoautor,
+			TotalVotacoes:             dados.totalVotacoes,
+			VotacoesParticipadas:      dados.votosRegistrados,
+			TaxaPresenca:              arredondar(dados.taxaPresenca),
+			TaxaPresencaBruta:         arredondar(dados.taxaPresencaBruta),
+			AusenciasJustificadas:     dados.ausenciasJustificadas,
+			AusenciasNaoJustificadas:  dados.ausenciasNaoJustificadas,
+			GastoCEAPS:                arredondar(dados.gastoAnual),
+			TetoCEAPS:                 tetoPeriodo,
+			MesesExercicio:            arredondar(mesesPeriodo),
+			SemHistoricoExercicio:     dados.mesesExercicio == nil,
+			ComissoesAtivas:           dados.comissoesAtivas,
+			ComissoesTitular:          dados.comissoesTitular,
+			ComissoesSuplente:         dados.comissoesSuplente,
+			PontosComissoes:           arredondar(dados.pontosComissoes),
+			ReunioesComissaoEsperadas: dados.reunioesComissaoEsperadas,
+			PresencasReunioesComissao: dados.presencasReunioesComissao,
+			TaxaPresencaComissoes:     arredondar(dados.taxaPresencaComissoes),
+			ModoComissoes:             modoAplicado,
+			TotalDiscursos:            dados.totalDiscursos,
+			PontuacaoRelatorias:       arredondar(dados.pontuacaoRelatorias),
+			VotosComparadosPartido:    dados.votosComparadosPartido,
+			VotosComparadosGoverno:    dados.votosComparadosGoverno,
+			IndiceFidelidade:          arredondarOpcional(dados.fidelidade),
+			IndiceGovernismo:          arredondarOpcional(dados.governismo),
 		},
 	}
 }
 
+// contem indica se o criterio esta entre os solicitados
+func contem(criterios []string, criterio string) bool {
+	for _, c := range criterios {
+		if c == criterio {
+			return true
+		}
+	}
+	return false
+}
+
+// arredondarOpcional arredonda valores opcionais, preservando nil
+func arredondarOpcional(valor *float64) *float64 {
+	if valor == nil {
+		return nil
+	}
+	v := arredondar(*valor)
+	return &v
+}
+
 // arredondar arredonda para 2 casas decimais
 func arredondar(valor float64) float64 {
 	return float64(int(valor*100+0.5)) / 100
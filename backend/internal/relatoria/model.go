@@ -0,0 +1,70 @@
+package relatoria
+
+import (
+	"time"
+
+	"github.com/Alzarus/to-de-olho/internal/proposicao"
+)
+
+// Relatoria representa a designacao de um senador como relator de uma materia em uma comissao
+type Relatoria struct {
+	ID                     int        `gorm:"primaryKey" json:"id"`
+	Chave                  string     `gorm:"uniqueIndex;not null" json:"-"` // senador + materia + comissao + designacao
+	SenadorID              int        `gorm:"index:idx_relatoria_senador;not null" json:"senador_id"`
+	CodigoMateria          string     `gorm:"index" json:"codigo_materia"`
+	SiglaSubtipoMateria    string     `json:"sigla_subtipo_materia"`
+	NumeroMateria          string     `json:"numero_materia"`
+	AnoMateria             int        `json:"ano_materia"`
+	DescricaoIdentificacao string     `json:"descricao_identificacao"`
+	Ementa                 string     `json:"ementa,omitempty"`
+	CodigoComissao         string     `gorm:"index" json:"codigo_comissao"`
+	SiglaComissao          string     `json:"sigla_comissao"`
+	NomeComissao           string     `json:"nome_comissao"`
+	Casa                   string     `json:"casa"`
+	TipoRelator            string     `json:"tipo_relator"`
+	DataDesignacao         *time.Time `json:"data_designacao,omitempty"`
+	DataDestituicao        *time.Time `json:"data_destituicao,omitempty"`
+	MotivoDestituicao      string     `json:"motivo_destituicao,omitempty"`
+	DataRelatorio          *time.Time `json:"data_relatorio,omitempty"`
+
+	Pontuacao float64 `json:"pontuacao"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName define o nome da tabela
+func (Relatoria) TableName() string {
+	return "relatorias"
+}
+
+// CalcularPontuacao pontua a relatoria pelo resultado e pelo tipo da materia
+// (mesmos multiplicadores de proposicao.CalcularPontuacao)
+func (r *Relatoria) CalcularPontuacao() float64 {
+	pontos := 1.0 // Designado
+	switch {
+	case r.DataRelatorio != nil:
+		pontos = 2.0 // Relatorio apresentado
+	case r.DataDestituicao != nil:
+		pontos = 0.5 // Saiu da relatoria sem relatar
+	}
+	return pontos * proposicao.MultiplicadorTipo(r.SiglaSubtipoMateria)
+}
+
+// RelatoriaStats representa estatisticas de relatorias de um senador
+type RelatoriaStats struct {
+	SenadorID              int                  `json:"senador_id"`
+	TotalRelatorias        int                  `json:"total_relatorias"`
+	RelatoriosApresentados int                  `json:"relatorios_apresentados"`
+	EmAndamento            int                  `json:"em_andamento"` // Sem relatorio e sem destituicao
+	Destituidas            int                  `json:"destituidas"`
+	PontuacaoTotal         float64              `json:"pontuacao_total"`
+	PorComissao            []RelatoriasPorGrupo `json:"por_comissao"`
+	PorTipo                []RelatoriasPorGrupo `json:"por_tipo"`
+}
+
+// RelatoriasPorGrupo representa contagem de relatorias por comissao ou tipo de materia
+type RelatoriasPorGrupo struct {
+	Grupo string `json:"grupo"`
+	Total int    `json:"total"`
+}
@@ -0,0 +1,27 @@
+package relatoria
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalcularPontuacao(t *testing.T) {
+	hoje := time.Now()
+
+	testes := []struct {
+		nome     string
+		rel      Relatoria
+		esperado float64
+	}{
+		{"PL designado", Relatoria{SiglaSubtipoMateria: "PL"}, 1.0},
+		{"PEC relatada", Relatoria{SiglaSubtipoMateria: "PEC", DataRelatorio: &hoje}, 6.0},
+		{"PLP destituido", Relatoria{SiglaSubtipoMateria: "PLP", DataDestituicao: &hoje}, 1.0},
+		{"REQ relatado", Relatoria{SiglaSubtipoMateria: "REQ", DataRelatorio: &hoje}, 0.2},
+	}
+
+	for _, teste := range testes {
+		if got := teste.rel.CalcularPontuacao(); got != teste.esperado {
+			t.Errorf("%s: pontuacao = %f; esperado %f", teste.nome, got, teste.esperado)
+		}
+	}
+}
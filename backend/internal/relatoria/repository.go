@@ -0,0 +1,124 @@
+package relatoria
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/Alzarus/to-de-olho/internal/utils"
+)
+
+// Repository encapsula operacoes de banco de dados para Relatoria
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository cria um novo repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// FindBySenadorID retorna relatorias de um senador com paginacao e filtros
+func (r *Repository) FindBySenadorID(senadorID int, limit, offset int, ano int, comissao, tipo string) ([]Relatoria, int64, error) {
+	var relatorias []Relatoria
+	var total int64
+
+	query := r.db.Model(&Relatoria{}).Where("senador_id = ?", senadorID)
+	if ano > 0 {
+		query = query.Where("EXTRACT(YEAR FROM data_designacao) = ?", ano)
+	}
+	if comissao != "" {
+		query = query.Where("sigla_comissao = ? OR codigo_comissao = ?", comissao, comissao)
+	}
+	if tipo != "" && tipo != "todos" {
+		query = query.Where("sigla_subtipo_materia = ?", tipo)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := query.Order("data_designacao DESC NULLS LAST").
+		Limit(limit).
+		Offset(offset).
+		Find(&relatorias)
+
+	return relatorias, total, result.Error
+}
+
+// GetStats retorna estatisticas de relatorias de um senador
+func (r *Repository) GetStats(senadorID int) (*RelatoriaStats, error) {
+	stats := RelatoriaStats{SenadorID: senadorID}
+
+	var contagem struct {
+		Total        int
+		Apresentados int
+		EmAndamento  int
+		Destituidas  int
+		Pontuacao    float64
+	}
+	if err := r.db.Model(&Relatoria{}).
+		Select(`COUNT(*) as total,
+			COUNT(*) FILTER (WHERE data_relatorio IS NOT NULL) as apresentados,
+			COUNT(*) FILTER (WHERE data_relatorio IS NULL AND data_destituicao IS NULL) as em_andamento,
+			COUNT(*) FILTER (WHERE data_relatorio IS NULL AND data_destituicao IS NOT NULL) as destituidas,
+			COALESCE(SUM(pontuacao), 0) as pontuacao`).
+		Where("senador_id = ?", senadorID).
+		Scan(&contagem).Error; err != nil {
+		return nil, err
+	}
+	stats.TotalRelatorias = contagem.Total
+	stats.RelatoriosApresentados = contagem.Apresentados
+	stats.EmAndamento = contagem.EmAndamento
+	stats.Destituidas = contagem.Destituidas
+	stats.PontuacaoTotal = contagem.Pontuacao
+
+	if err := r.db.Model(&Relatoria{}).
+		Select("sigla_comissao as grupo, COUNT(*) as total").
+		Where("senador_id = ?", senadorID).
+		Group("sigla_comissao").
+		Order("total DESC").
+		Scan(&stats.PorComissao).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Model(&Relatoria{}).
+		Select("sigla_subtipo_materia as grupo, COUNT(*) as total").
+		Where("senador_id = ?", senadorID).
+		Group("sigla_subtipo_materia").
+		Order("total DESC").
+		Scan(&stats.PorTipo).Error; err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// GetPontuacao retorna a pontuacao de relatorias designadas no ano informado
+// ou no mandato atual (legislatura corrente) quando ano e nil
+func (r *Repository) GetPontuacao(senadorID int, ano *int) (float64, error) {
+	var total float64
+	query := r.db.Model(&Relatoria{}).
+		Select("COALESCE(SUM(pontuacao), 0)").
+		Where("senador_id = ?", senadorID)
+	if ano != nil {
+		query = query.Where("data_designacao >= ? AND data_designacao < ?", fmt.Sprintf("%d-01-01", *ano), fmt.Sprintf("%d-01-01", *ano+1))
+	} else {
+		query = query.Where("data_designacao >= ?", fmt.Sprintf("%d-01-01", utils.GetInicioLegislaturaAtual()))
+	}
+	err := query.Scan(&total).Error
+	return total, err
+}
+
+// UpsertBatch insere ou atualiza relatorias pela chave natural
+func (r *Repository) UpsertBatch(relatorias []Relatoria) error {
+	if len(relatorias) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "chave"}},
+		DoUpdates: clause.AssignmentColumns([]string{"sigla_subtipo_materia", "numero_materia", "ano_materia", "descricao_identificacao", "ementa",
+			"sigla_comissao", "nome_comissao", "casa", "tipo_relator", "data_destituicao", "motivo_destituicao", "data_relatorio", "pontuacao", "updated_at"}),
+	}).CreateInBatches(relatorias, 100).Error
+}
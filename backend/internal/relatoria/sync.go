@@ -0,0 +1,110 @@
+package relatoria
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/Alzarus/to-de-olho/internal/senador"
+	"github.com/Alzarus/to-de-olho/internal/utils"
+	senadoapi "github.com/Alzarus/to-de-olho/pkg/senado"
+)
+
+// SyncService gerencia sincronizacao de relatorias
+type SyncService struct {
+	repo        *Repository
+	senadorRepo *senador.Repository
+	client      *senadoapi.LegisClient
+}
+
+// NewSyncService cria um novo servico de sincronizacao
+func NewSyncService(repo *Repository, senadorRepo *senador.Repository, client *senadoapi.LegisClient) *SyncService {
+	return &SyncService{
+		repo:        repo,
+		senadorRepo: senadorRepo,
+		client:      client,
+	}
+}
+
+// SyncFromAPI busca relatorias de todos os senadores em exercicio
+func (s *SyncService) SyncFromAPI(ctx context.Context) error {
+	slog.Info("iniciando sync de relatorias")
+
+	senadores, err := s.senadorRepo.FindAll(false)
+	if err != nil {
+		return err
+	}
+
+	var totalRelatorias, totalSenadores int
+	for _, sen := range senadores {
+		count, err := s.SyncSenador(ctx, sen)
+		if err != nil {
+			slog.Warn("falha ao buscar relatorias", "senador", sen.Nome, "error", err)
+			continue
+		}
+		totalRelatorias += count
+		totalSenadores++
+	}
+
+	slog.Info("sync de relatorias concluido", "senadores", totalSenadores, "relatorias", totalRelatorias)
+	return nil
+}
+
+// SyncSenador busca relatorias de um senador
+func (s *SyncService) SyncSenador(ctx context.Context, sen senador.Senador) (int, error) {
+	relatoriasAPI, err := s.client.ListarRelatoriasParlamentar(ctx, sen.CodigoParlamentar)
+	if err != nil {
+		return 0, err
+	}
+
+	relatorias := make([]Relatoria, 0, len(relatoriasAPI))
+	vistas := make(map[string]bool)
+	for _, r := range relatoriasAPI {
+		if r.IdentificacaoMateria.CodigoMateria == "" {
+			continue
+		}
+		relatoria := convertToModel(r, sen.ID)
+		// A API pode repetir a mesma designacao; o upsert em lote falha com chaves repetidas
+		if vistas[relatoria.Chave] {
+			continue
+		}
+		vistas[relatoria.Chave] = true
+		relatorias = append(relatorias, relatoria)
+	}
+
+	if err := s.repo.UpsertBatch(relatorias); err != nil {
+		return 0, err
+	}
+
+	return len(relatorias), nil
+}
+
+// convertToModel converte uma relatoria da API para modelo interno
+func convertToModel(r senadoapi.RelatoriaAPI, senadorID int) Relatoria {
+	ano, _ := strconv.Atoi(r.IdentificacaoMateria.AnoMateria)
+
+	relatoria := Relatoria{
+		Chave: fmt.Sprintf("%d:%s:%s:%s", senadorID, r.IdentificacaoMateria.CodigoMateria,
+			r.IdentificacaoComissao.CodigoComissao, r.DataDesignacao),
+		SenadorID:              senadorID,
+		CodigoMateria:          r.IdentificacaoMateria.CodigoMateria,
+		SiglaSubtipoMateria:    r.IdentificacaoMateria.SiglaSubtipoMateria,
+		NumeroMateria:          r.IdentificacaoMateria.NumeroMateria,
+		AnoMateria:             ano,
+		DescricaoIdentificacao: r.IdentificacaoMateria.DescricaoIdentificacaoMateria,
+		Ementa:                 r.EmentaMateria,
+		CodigoComissao:         r.IdentificacaoComissao.CodigoComissao,
+		SiglaComissao:          r.IdentificacaoComissao.SiglaComissao,
+		NomeComissao:           r.IdentificacaoComissao.NomeComissao,
+		Casa:                   r.IdentificacaoComissao.SiglaCasaComissao,
+		TipoRelator:            r.DescricaoTipoRelator,
+		DataDesignacao:         utils.ParseDataISO(r.DataDesignacao),
+		DataDestituicao:        utils.ParseDataISO(r.DataDestituicao),
+		MotivoDestituicao:      r.DescricaoMotivoDestituicao,
+		DataRelatorio:          utils.ParseDataISO(r.DataApresentacaoRelatorio),
+	}
+	relatoria.Pontuacao = relatoria.CalcularPontuacao()
+
+	return relatoria
+}
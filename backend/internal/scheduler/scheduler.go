@@ -7,11 +7,22 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/Alzarus/to-de-olho/internal/agenda"
+	"github.com/Alzarus/to-de-olho/internal/alerta"
+	"github.com/Alzarus/to-de-olho/internal/camara/deputado"
+	camaradespesa "github.com/Alzarus/to-de-olho/internal/camara/despesa"
+	camaravotacao "github.com/Alzarus/to-de-olho/internal/camara/votacao"
 	"github.com/Alzarus/to-de-olho/internal/ceaps"
 	"github.com/Alzarus/to-de-olho/internal/comissao"
+	"github.com/Alzarus/to-de-olho/internal/discurso"
 	"github.com/Alzarus/to-de-olho/internal/emenda"
+	"github.com/Alzarus/to-de-olho/internal/fornecedor"
+	"github.com/Alzarus/to-de-olho/internal/gabinete"
+	"github.com/Alzarus/to-de-olho/internal/licenca"
+	"github.com/Alzarus/to-de-olho/internal/municipio"
 	"github.com/Alzarus/to-de-olho/internal/proposicao"
 	"github.com/Alzarus/to-de-olho/internal/ranking"
+	"github.com/Alzarus/to-de-olho/internal/relatoria"
 	"github.com/Alzarus/to-de-olho/internal/senador"
 	"github.com/Alzarus/to-de-olho/internal/votacao"
 	"github.com/Alzarus/to-de-olho/pkg/retry"
@@ -28,6 +39,21 @@ type Scheduler struct {
 	rankingService *ranking.Service
 	senadorRepo    *senador.Repository
 	votacaoRepo    *votacao.Repository
+
+	// Camara dos Deputados
+	deputadoSync      *deputado.SyncService
+	camaraVotacaoSync *camaravotacao.SyncService
+	camaraDespesaSync *camaradespesa.SyncService
+
+	discursoSync *discurso.SyncService
+	agendaSync   *agenda.SyncService
+	gabineteSync *gabinete.SyncService
+
+	fornecedorSync *fornecedor.SyncService
+	relatoriaSync  *relatoria.SyncService
+	licencaSync    *licenca.SyncService
+	municipioSync  *municipio.SyncService
+	alertaService  *alerta.Service
 }
 
 // NewScheduler cria um novo scheduler
@@ -41,6 +67,17 @@ func NewScheduler(
 	rankingService *ranking.Service,
 	senadorRepo *senador.Repository,
 	votacaoRepo *votacao.Repository,
+	deputadoSync *deputado.SyncService,
+	camaraVotacaoSync *camaravotacao.SyncService,
+	camaraDespesaSync *camaradespesa.SyncService,
+	discursoSync *discurso.SyncService,
+	agendaSync *agenda.SyncService,
+	gabineteSync *gabinete.SyncService,
+	fornecedorSync *fornecedor.SyncService,
+	relatoriaSync *relatoria.SyncService,
+	licencaSync *licenca.SyncService,
+	municipioSync *municipio.SyncService,
+	alertaService *alerta.Service,
 ) *Scheduler {
 	return &Scheduler{
 		senadorSync:    senadorSync,
@@ -52,6 +89,20 @@ func NewScheduler(
 		rankingService: rankingService,
 		senadorRepo:    senadorRepo,
 		votacaoRepo:    votacaoRepo,
+
+		deputadoSync:      deputadoSync,
+		camaraVotacaoSync: camaraVotacaoSync,
+		camaraDespesaSync: camaraDespesaSync,
+
+		discursoSync: discursoSync,
+		agendaSync:   agendaSync,
+		gabineteSync: gabineteSync,
+
+		fornecedorSync: fornecedorSync,
+		relatoriaSync:  relatoriaSync,
+		licencaSync:    licencaSync,
+		municipioSync:  municipioSync,
+		alertaService:  alertaService,
 	}
 }
 
@@ -119,29 +170,24 @@ func (s *Scheduler) RunBackfill(ctx context.Context) {
 		return // Sem senadores nao da pra continuar
 	}
 
-	// B. Votacoes - PULAR se ja existem dados (evita timeout de 3600s+)
-	votosCount, _ := s.votacaoRepo.Count()
-	if votosCount > 0 {
-		slog.Info("--- PASSO 2/6: VOTACOES (PULANDO - dados existentes) ---", "votos_existentes", votosCount)
-	} else {
-		slog.Info("--- PASSO 2/6: VOTACOES (LISTA) ---")
-		if err := retry.WithRetry(ctx, 3, "backfill-votacoes", func() error {
-			return s.votacaoSync.SyncFromAPI(ctx)
-		}); err != nil {
-			slog.Error("falha no backfill de votacoes", "error", err)
-		}
+	// Referencia de municipios do IBGE (usada para resolver as localidades das emendas)
+	if err := retry.WithRetry(ctx, 3, "backfill-municipios", func() error {
+		return s.municipioSync.SyncFromIBGE(ctx)
+	}); err != nil {
+		slog.Error("falha ao sincronizar municipios do IBGE", "error", err)
 	}
 
-	// C. Loop por ano para dados periodicos
+	// B/C. Loop por ano para dados periodicos
+	slog.Info("--- PASSO 2/6: DADOS ANUAIS ---")
 	for ano := anoInicio; ano <= anoAtual; ano++ {
 		slog.Info("--- PROCESSANDO ANO ---", "ano", ano)
 
-		// Metadata de Votacoes (Ementas, Datas corretas)
+		// Votacoes: sessoes do ano com todos os votos (uma chamada por ano)
 		anoLoop := ano
-		if err := retry.WithRetry(ctx, 3, "backfill-votacoes-metadata", func() error {
-			return s.votacaoSync.SyncMetadata(ctx, anoLoop)
+		if err := retry.WithRetry(ctx, 3, "backfill-votacoes", func() error {
+			return s.votacaoSync.SyncFromAPI(ctx, anoLoop)
 		}); err != nil {
-			slog.Error("falha ao sincronizar metadata votacoes", "ano", ano, "error", err)
+			slog.Error("falha ao sincronizar votacoes", "ano", ano, "error", err)
 		}
 
 		// CEAPS (Despesas)
@@ -157,6 +203,47 @@ func (s *Scheduler) RunBackfill(ctx context.Context) {
 		}); err != nil {
 			slog.Error("falha ao sincronizar emendas", "ano", ano, "error", err)
 		}
+
+		// Transferencias especiais (planos de acao no Transferegov)
+		if err := retry.WithRetry(ctx, 3, "backfill-transferencias-especiais", func() error {
+			return s.emendaSync.SyncTransferenciasEspeciais(ctx, anoLoop)
+		}); err != nil {
+			slog.Error("falha ao sincronizar transferencias especiais", "ano", ano, "error", err)
+		}
+
+		// Discursos
+		if err := retry.WithRetry(ctx, 3, "backfill-discursos", func() error {
+			return s.discursoSync.SyncFromAPI(ctx, anoLoop)
+		}); err != nil {
+			slog.Error("falha ao sincronizar discursos", "ano", ano, "error", err)
+		}
+
+		// Reunioes de comissoes e listas de presenca
+		if err := retry.WithRetry(ctx, 3, "backfill-comissoes-reunioes", func() error {
+			return s.comissaoSync.SyncReunioes(ctx, anoLoop)
+		}); err != nil {
+			slog.Error("falha ao sincronizar reunioes de comissoes", "ano", ano, "error", err)
+		}
+	}
+
+	// Documentos de execucao das emendas (empenhos, pagamentos e favorecidos)
+	if err := s.emendaSync.SyncDocumentos(ctx, 2000); err != nil {
+		slog.Error("falha ao sincronizar documentos de emendas", "error", err)
+	}
+
+	// Fornecedores (consolidado de todos os anos de CEAPS)
+	if err := s.fornecedorSync.RebuildFromCEAPS(ctx); err != nil {
+		slog.Error("falha ao consolidar fornecedores", "error", err)
+	}
+	if err := s.fornecedorSync.EnriquecerCNPJs(ctx, 200); err != nil {
+		slog.Error("falha ao consultar CNPJs de fornecedores", "error", err)
+	}
+
+	// Alertas CEAPS (dependem dos fornecedores consolidados e dos dados cadastrais)
+	for ano := anoInicio; ano <= anoAtual; ano++ {
+		if err := s.alertaService.AnalisarAno(ctx, ano); err != nil {
+			slog.Error("falha ao analisar alertas", "ano", ano, "error", err)
+		}
 	}
 
 	// D. Comissoes (Estado atual/recente)
@@ -167,6 +254,42 @@ func (s *Scheduler) RunBackfill(ctx context.Context) {
 		slog.Error("falha no backfill de comissoes", "error", err)
 	}
 
+	// Licencas e afastamentos (necessarios para o calculo de presenca)
+	if err := retry.WithRetry(ctx, 3, "backfill-licencas", func() error {
+		return s.licencaSync.SyncFromAPI(ctx)
+	}); err != nil {
+		slog.Error("falha no backfill de licencas", "error", err)
+	}
+
+	// Relatorias (historico completo por senador)
+	if err := retry.WithRetry(ctx, 3, "backfill-relatorias", func() error {
+		return s.relatoriaSync.SyncFromAPI(ctx)
+	}); err != nil {
+		slog.Error("falha no backfill de relatorias", "error", err)
+	}
+
+	// Gabinetes: servidores atuais e folha mensal desde o ano de inicio
+	if err := retry.WithRetry(ctx, 3, "backfill-gabinete", func() error {
+		return s.gabineteSync.SyncFromAPI(ctx)
+	}); err != nil {
+		slog.Error("falha no backfill de gabinetes", "error", err)
+	} else {
+		agora := time.Now()
+		for ano := anoInicio; ano <= anoAtual; ano++ {
+			for mes := 1; mes <= 12; mes++ {
+				if ano == anoAtual && mes >= int(agora.Month()) {
+					break
+				}
+				anoLoop, mesLoop := ano, mes
+				if err := retry.WithRetry(ctx, 3, "backfill-gabinete-folha", func() error {
+					return s.gabineteSync.SyncRemuneracoes(ctx, anoLoop, mesLoop)
+				}); err != nil {
+					slog.Error("falha ao sincronizar folha de gabinetes", "ano", ano, "mes", mes, "error", err)
+				}
+			}
+		}
+	}
+
 	// E. Proposicoes (Historico)
 	slog.Info("--- PASSO 5/6: PROPOSICOES ---")
 	if err := retry.WithRetry(ctx, 3, "backfill-proposicoes", func() error {
@@ -175,6 +298,33 @@ func (s *Scheduler) RunBackfill(ctx context.Context) {
 		slog.Error("falha no backfill de proposicoes", "error", err)
 	}
 
+	// Historico de tramitacao (define o estagio real de cada materia)
+	if err := s.proposicaoSync.SyncTramitacoes(ctx, 5000); err != nil {
+		slog.Error("falha no backfill de tramitacoes", "error", err)
+	}
+
+	// Camara dos Deputados (deputados, votacoes e CEAP por ano)
+	slog.Info("--- CAMARA DOS DEPUTADOS ---")
+	if err := retry.WithRetry(ctx, 3, "backfill-deputados", func() error {
+		return s.deputadoSync.SyncFromAPI(ctx)
+	}); err != nil {
+		slog.Error("falha no backfill de deputados", "error", err)
+	} else {
+		for ano := anoInicio; ano <= anoAtual; ano++ {
+			anoLoop := ano
+			if err := retry.WithRetry(ctx, 3, "backfill-camara-votacoes", func() error {
+				return s.camaraVotacaoSync.SyncFromAPI(ctx, anoLoop)
+			}); err != nil {
+				slog.Error("falha ao sincronizar votacoes da camara", "ano", ano, "error", err)
+			}
+			if err := retry.WithRetry(ctx, 3, "backfill-camara-despesas", func() error {
+				return s.camaraDespesaSync.SyncFromAPI(ctx, anoLoop)
+			}); err != nil {
+				slog.Error("falha ao sincronizar despesas da camara", "ano", ano, "error", err)
+			}
+		}
+	}
+
 	// F. Calculo de Ranking Final
 	slog.Info("--- PASSO 6/6: CALCULANDO RANKING ---")
 	if _, err := s.rankingService.CalcularRanking(ctx, nil); err != nil {
@@ -198,13 +348,11 @@ func (s *Scheduler) RunDailySync(ctx context.Context) {
 		slog.Error("falha sync senadores", "error", err)
 	}
 
-	// 2. Votacoes - apenas metadata do ano atual (ementas, datas)
-	// O sync completo de votos (82 senadores x todas sessoes) leva 3600s+
-	// e fica reservado exclusivamente ao backfill
-	if err := retry.WithRetry(ctx, 3, "sync-votacoes-metadata", func() error {
-		return s.votacaoSync.SyncMetadata(ctx, anoAtual)
+	// 2. Votacoes do ano atual: sessoes e votos de todos os senadores em lote
+	if err := retry.WithRetry(ctx, 3, "sync-votacoes", func() error {
+		return s.votacaoSync.SyncFromAPI(ctx, anoAtual)
 	}); err != nil {
-		slog.Error("falha sync metadata votacoes", "error", err)
+		slog.Error("falha sync votacoes", "error", err)
 	}
 
 	// 4. CEAPS (Despesas)
@@ -214,6 +362,21 @@ func (s *Scheduler) RunDailySync(ctx context.Context) {
 		slog.Error("falha sync ceaps", "error", err)
 	}
 
+	// Fornecedores dependem das despesas recem sincronizadas
+	if err := s.fornecedorSync.RebuildFromCEAPS(ctx); err != nil {
+		slog.Error("falha ao consolidar fornecedores", "error", err)
+	}
+
+	// Dados cadastrais de CNPJs novos (usados pela regra de fornecedor recem-aberto)
+	if err := s.fornecedorSync.EnriquecerCNPJs(ctx, 200); err != nil {
+		slog.Error("falha ao consultar CNPJs de fornecedores", "error", err)
+	}
+
+	// Alertas CEAPS do ano, com fornecedores e CNPJs ja atualizados
+	if err := s.alertaService.AnalisarAno(ctx, anoAtual); err != nil {
+		slog.Error("falha ao analisar alertas", "error", err)
+	}
+
 	// 5. Emendas
 	if err := retry.WithRetry(ctx, 3, "sync-emendas", func() error {
 		return s.emendaSync.SyncAll(ctx, anoAtual)
@@ -221,6 +384,18 @@ func (s *Scheduler) RunDailySync(ctx context.Context) {
 		slog.Error("falha sync emendas", "error", err)
 	}
 
+	// Documentos das emendas novas ou com execucao desatualizada
+	if err := s.emendaSync.SyncDocumentos(ctx, 200); err != nil {
+		slog.Error("falha sync documentos de emendas", "error", err)
+	}
+
+	// Transferencias especiais do ano corrente
+	if err := retry.WithRetry(ctx, 3, "sync-transferencias-especiais", func() error {
+		return s.emendaSync.SyncTransferenciasEspeciais(ctx, anoAtual)
+	}); err != nil {
+		slog.Error("falha sync transferencias especiais", "error", err)
+	}
+
 	// 6. Comissoes (Mudancas de membros)
 	if err := retry.WithRetry(ctx, 3, "sync-comissoes", func() error {
 		return s.comissaoSync.SyncFromAPI(ctx)
@@ -228,6 +403,13 @@ func (s *Scheduler) RunDailySync(ctx context.Context) {
 		slog.Error("falha sync comissoes", "error", err)
 	}
 
+	// Reunioes de comissoes do ano (presencas publicadas apos a reuniao)
+	if err := retry.WithRetry(ctx, 3, "sync-comissoes-reunioes", func() error {
+		return s.comissaoSync.SyncReunioes(ctx, anoAtual)
+	}); err != nil {
+		slog.Error("falha sync reunioes de comissoes", "error", err)
+	}
+
 	// 7. Proposicoes (Novos projetos ou tramitacoes)
 	if err := retry.WithRetry(ctx, 3, "sync-proposicoes", func() error {
 		return s.proposicaoSync.SyncFromAPI(ctx)
@@ -235,6 +417,72 @@ func (s *Scheduler) RunDailySync(ctx context.Context) {
 		slog.Error("falha sync proposicoes", "error", err)
 	}
 
+	// Tramitacoes: materias novas e atualizacao semanal das que seguem tramitando
+	if err := s.proposicaoSync.SyncTramitacoes(ctx, 500); err != nil {
+		slog.Error("falha sync tramitacoes", "error", err)
+	}
+
+	// Discursos do ano corrente
+	if err := retry.WithRetry(ctx, 3, "sync-discursos", func() error {
+		return s.discursoSync.SyncFromAPI(ctx, anoAtual)
+	}); err != nil {
+		slog.Error("falha sync discursos", "error", err)
+	}
+
+	// Licencas e afastamentos (justificam ausencias nas votacoes)
+	if err := retry.WithRetry(ctx, 3, "sync-licencas", func() error {
+		return s.licencaSync.SyncFromAPI(ctx)
+	}); err != nil {
+		slog.Error("falha sync licencas", "error", err)
+	}
+
+	// Relatorias (novas designacoes e relatorios apresentados)
+	if err := retry.WithRetry(ctx, 3, "sync-relatorias", func() error {
+		return s.relatoriaSync.SyncFromAPI(ctx)
+	}); err != nil {
+		slog.Error("falha sync relatorias", "error", err)
+	}
+
+	// Gabinetes: lotacoes atuais e folha do mes anterior (publicada com atraso)
+	if err := retry.WithRetry(ctx, 3, "sync-gabinete", func() error {
+		return s.gabineteSync.SyncFromAPI(ctx)
+	}); err != nil {
+		slog.Error("falha sync gabinetes", "error", err)
+	} else {
+		mesAnterior := time.Now().AddDate(0, -1, 0)
+		if err := retry.WithRetry(ctx, 3, "sync-gabinete-folha", func() error {
+			return s.gabineteSync.SyncRemuneracoes(ctx, mesAnterior.Year(), int(mesAnterior.Month()))
+		}); err != nil {
+			slog.Error("falha sync folha gabinetes", "error", err)
+		}
+	}
+
+	// Agenda do plenario e comissoes (proximas duas semanas)
+	if err := retry.WithRetry(ctx, 3, "sync-agenda", func() error {
+		return s.agendaSync.SyncProximosDias(ctx, 14)
+	}); err != nil {
+		slog.Error("falha sync agenda", "error", err)
+	}
+
+	// Camara dos Deputados: cadastro, votacoes recentes e despesas do ano
+	if err := retry.WithRetry(ctx, 3, "sync-deputados", func() error {
+		return s.deputadoSync.SyncFromAPI(ctx)
+	}); err != nil {
+		slog.Error("falha sync deputados", "error", err)
+	}
+
+	if err := retry.WithRetry(ctx, 3, "sync-camara-votacoes", func() error {
+		return s.camaraVotacaoSync.SyncRecentes(ctx, 7)
+	}); err != nil {
+		slog.Error("falha sync votacoes camara", "error", err)
+	}
+
+	if err := retry.WithRetry(ctx, 3, "sync-camara-despesas", func() error {
+		return s.camaraDespesaSync.SyncFromAPI(ctx, anoAtual)
+	}); err != nil {
+		slog.Error("falha sync despesas camara", "error", err)
+	}
+
 	// 8. Recalcular Ranking
 	s.rankingService.CalcularRanking(ctx, nil)
 
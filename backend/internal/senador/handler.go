@@ -3,6 +3,7 @@ package senador
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -89,3 +90,102 @@ func (h *Handler) GetByCodigo(c *gin.Context) {
 
 	c.JSON(http.StatusOK, senador)
 }
+
+// GetMandatos godoc
+// @Summary Lista o historico de mandatos e periodos de exercicio de um senador
+// @Tags senadores
+// @Produce json
+// @Param id path int true "ID do senador"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/senadores/{id}/mandatos [get]
+func (h *Handler) GetMandatos(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalido"})
+		return
+	}
+
+	mandatos, err := h.repo.FindMandatos(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar mandatos"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"senador_id": id,
+		"total":      len(mandatos),
+		"mandatos":   mandatos,
+	})
+}
+
+// GetFiliacoes godoc
+// @Summary Lista o historico de filiacoes partidarias de um senador
+// @Tags senadores
+// @Produce json
+// @Param id path int true "ID do senador"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/senadores/{id}/filiacoes [get]
+func (h *Handler) GetFiliacoes(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalido"})
+		return
+	}
+
+	filiacoes, err := h.repo.FindFiliacoes(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar filiacoes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"senador_id": id,
+		"total":      len(filiacoes),
+		"filiacoes":  filiacoes,
+	})
+}
+
+// ListTrocas godoc
+// @Summary Feed de trocas de partido recentes
+// @Tags partidos
+// @Produce json
+// @Param desde query string false "Data inicial (YYYY-MM-DD, default: 12 meses atras)"
+// @Param limit query int false "Limite de resultados (default 20)"
+// @Param page query int false "Pagina (default 1)"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/partidos/trocas [get]
+func (h *Handler) ListTrocas(c *gin.Context) {
+	desde := time.Now().AddDate(-1, 0, 0)
+	if v := c.Query("desde"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "data invalida (use YYYY-MM-DD)"})
+			return
+		}
+		desde = t
+	}
+
+	limit := 20
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+	page := 1
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	trocas, total, err := h.repo.FindTrocas(desde, limit, (page-1)*limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao buscar trocas de partido"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"desde":       desde.Format("2006-01-02"),
+		"total":       total,
+		"limit":       limit,
+		"page":        page,
+		"total_pages": (int(total) + limit - 1) / limit,
+		"trocas":      trocas,
+	})
+}
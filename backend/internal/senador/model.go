@@ -1,6 +1,9 @@
 package senador
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // Senador representa um senador da republica
 type Senador struct {
@@ -22,15 +25,128 @@ type Senador struct {
 	Mandatos []Mandato `gorm:"foreignKey:SenadorID" json:"mandatos,omitempty"`
 }
 
-// Mandato representa um mandato de um senador
+// Mandato representa um mandato de um senador (8 anos, duas legislaturas)
 type Mandato struct {
-	ID          int        `gorm:"primaryKey" json:"id"`
-	SenadorID   int        `gorm:"index;not null" json:"senador_id"`
-	Legislatura int        `json:"legislatura"`
-	Inicio      time.Time  `json:"inicio"`
-	Fim         *time.Time `json:"fim,omitempty"`
-	Tipo        string     `json:"tipo"` // Titular, Suplente, etc.
-	CreatedAt   time.Time  `json:"created_at"`
+	ID                 int         `gorm:"primaryKey" json:"id"`
+	SenadorID          int         `gorm:"index;not null" json:"senador_id"`
+	CodigoMandato      string      `json:"codigo_mandato,omitempty"`
+	UF                 string      `gorm:"size:2" json:"uf,omitempty"`
+	Legislatura        int         `json:"legislatura"`                   // Primeira legislatura do mandato
+	SegundaLegislatura int         `json:"segunda_legislatura,omitempty"` // Segunda legislatura (0 se nao se aplica)
+	Inicio             time.Time   `json:"inicio"`
+	Fim                *time.Time  `json:"fim,omitempty"`
+	Tipo               string      `json:"tipo"` // Titular, Suplente, etc.
+	Exercicios         []Exercicio `gorm:"foreignKey:MandatoID" json:"exercicios,omitempty"`
+	CreatedAt          time.Time   `json:"created_at"`
+}
+
+// Exercicio representa um periodo em que o senador efetivamente exerceu o mandato
+// (suplentes em exercicio, titulares afastados para cargos no executivo etc.)
+type Exercicio struct {
+	ID               int        `gorm:"primaryKey" json:"id"`
+	MandatoID        int        `gorm:"index;not null" json:"mandato_id"`
+	SenadorID        int        `gorm:"index;not null" json:"senador_id"`
+	Inicio           time.Time  `json:"inicio"`
+	Fim              *time.Time `json:"fim,omitempty"` // nil = em exercicio
+	CausaAfastamento string     `json:"causa_afastamento,omitempty"`
+}
+
+// Filiacao representa um periodo de filiacao partidaria de um senador
+type Filiacao struct {
+	ID              int        `gorm:"primaryKey" json:"id"`
+	SenadorID       int        `gorm:"uniqueIndex:idx_filiacao_unica,priority:1;not null" json:"senador_id"`
+	SiglaPartido    string     `gorm:"uniqueIndex:idx_filiacao_unica,priority:2;not null" json:"sigla_partido"`
+	NomePartido     string     `json:"nome_partido,omitempty"`
+	DataFiliacao    time.Time  `gorm:"uniqueIndex:idx_filiacao_unica,priority:3;not null" json:"data_filiacao"`
+	DataDesfiliacao *time.Time `json:"data_desfiliacao,omitempty"` // nil = filiacao atual
+	Origem          string     `json:"origem"`                     // api ou sync (troca detectada na sincronizacao)
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// Origens de uma filiacao
+const (
+	OrigemFiliacaoAPI  = "api"
+	OrigemFiliacaoSync = "sync"
+)
+
+// TrocaPartido representa uma mudanca de partido de um senador
+type TrocaPartido struct {
+	SenadorID       int       `json:"senador_id"`
+	Nome            string    `json:"nome"`
+	UF              string    `json:"uf"`
+	FotoURL         string    `json:"foto_url,omitempty"`
+	PartidoAnterior string    `json:"partido_anterior"`
+	PartidoNovo     string    `json:"partido_novo"`
+	Data            time.Time `json:"data"`
+}
+
+// PartidoNaDataSQL retorna uma subconsulta SQL com a sigla do partido do senador
+// na data informada (colunas ou expressoes SQL), caindo para senadores.partido
+// quando nao ha historico de filiacao. Deve ser usada em queries com JOIN em senadores.
+func PartidoNaDataSQL(senadorIDCol, dataCol string) string {
+	return fmt.Sprintf(`COALESCE((SELECT f.sigla_partido FROM filiacoes f
+		WHERE f.senador_id = %[1]s AND f.data_filiacao <= (%[2]s)::date
+		AND (f.data_desfiliacao IS NULL OR f.data_desfiliacao >= (%[2]s)::date)
+		ORDER BY f.data_filiacao DESC LIMIT 1), senadores.partido)`, senadorIDCol, dataCol)
+}
+
+// PartidoNaData retorna a sigla do partido vigente na data a partir do historico
+// de filiacoes; usa padrao quando nenhuma filiacao cobre a data
+func PartidoNaData(filiacoes []Filiacao, data time.Time, padrao string) string {
+	dia := time.Date(data.Year(), data.Month(), data.Day(), 0, 0, 0, 0, time.UTC)
+	var escolhida *Filiacao
+	for i := range filiacoes {
+		f := &filiacoes[i]
+		if f.DataFiliacao.After(dia) {
+			continue
+		}
+		if f.DataDesfiliacao != nil && f.DataDesfiliacao.Before(dia) {
+			continue
+		}
+		if escolhida == nil || f.DataFiliacao.After(escolhida.DataFiliacao) {
+			escolhida = f
+		}
+	}
+	if escolhida == nil {
+		return padrao
+	}
+	return escolhida.SiglaPartido
+}
+
+// MesclarFiliacoes junta o historico da API com as trocas detectadas na sincronizacao.
+// A API cobre o periodo ate a sua filiacao mais recente; detectadas posteriores sao
+// mantidas e encerram a filiacao da API que estava aberta antes delas.
+func MesclarFiliacoes(api, detectadas []Filiacao) []Filiacao {
+	var ultimaAPI time.Time
+	for _, f := range api {
+		if f.DataFiliacao.After(ultimaAPI) {
+			ultimaAPI = f.DataFiliacao
+		}
+	}
+
+	var preservadas []Filiacao
+	var primeira time.Time
+	for _, f := range detectadas {
+		if len(api) > 0 && !f.DataFiliacao.After(ultimaAPI) {
+			continue
+		}
+		f.ID = 0
+		preservadas = append(preservadas, f)
+		if primeira.IsZero() || f.DataFiliacao.Before(primeira) {
+			primeira = f.DataFiliacao
+		}
+	}
+
+	todas := make([]Filiacao, 0, len(api)+len(preservadas))
+	for _, f := range api {
+		if f.DataDesfiliacao == nil && !primeira.IsZero() && f.DataFiliacao.Before(primeira) {
+			fim := primeira.AddDate(0, 0, -1)
+			f.DataDesfiliacao = &fim
+		}
+		todas = append(todas, f)
+	}
+	return append(todas, preservadas...)
 }
 
 // TableName define o nome da tabela para Senador
@@ -42,3 +158,32 @@ func (Senador) TableName() string {
 func (Mandato) TableName() string {
 	return "mandatos"
 }
+
+// TableName define o nome da tabela para Filiacao
+func (Filiacao) TableName() string {
+	return "filiacoes"
+}
+
+// TableName define o nome da tabela para Exercicio
+func (Exercicio) TableName() string {
+	return "mandato_exercicios"
+}
+
+// MesesEmExercicio soma os meses (30 dias) de exercicio efetivo dentro do periodo [inicio, fim]
+func MesesEmExercicio(exercicios []Exercicio, inicio, fim time.Time) float64 {
+	var dias float64
+	for _, e := range exercicios {
+		ini := e.Inicio
+		if ini.Before(inicio) {
+			ini = inicio
+		}
+		ate := fim
+		if e.Fim != nil && e.Fim.Before(fim) {
+			ate = *e.Fim
+		}
+		if ate.After(ini) {
+			dias += ate.Sub(ini).Hours() / 24
+		}
+	}
+	return dias / 30
+}
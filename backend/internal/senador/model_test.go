@@ -0,0 +1,123 @@
+package senador
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Alzarus/to-de-olho/pkg/senado"
+)
+
+func dia(valor string) time.Time {
+	t, _ := time.Parse("2006-01-02", valor)
+	return t
+}
+
+func TestMesesEmExercicio(t *testing.T) {
+	fimPrimeiro := dia("2023-05-02")
+	exercicios := []Exercicio{
+		{Inicio: dia("2019-02-01"), Fim: &fimPrimeiro}, // comeca antes do periodo
+		{Inicio: dia("2023-08-30")},                    // em exercicio
+	}
+
+	meses := MesesEmExercicio(exercicios, dia("2023-02-01"), dia("2023-12-27"))
+	// 90 dias no primeiro periodo + 119 no segundo
+	if math.Abs(meses-209.0/30) > 0.001 {
+		t.Errorf("esperado %.3f meses, obtido %.3f", 209.0/30, meses)
+	}
+
+	if meses := MesesEmExercicio(exercicios, dia("2024-01-01"), dia("2023-12-31")); meses != 0 {
+		t.Errorf("periodo invertido deveria resultar em 0, obtido %.3f", meses)
+	}
+}
+
+func TestConvertMandato(t *testing.T) {
+	var m senado.MandatoAPI
+	m.CodigoMandato = "600"
+	m.UfParlamentar = "BA"
+	m.DescricaoParticipacao = "1º Suplente"
+	m.PrimeiraLegislaturaDoMandato = senado.LegislaturaMandatoAPI{NumeroLegislatura: "57", DataInicio: "2023-02-01", DataFim: "2027-01-31"}
+	m.SegundaLegislaturaDoMandato = senado.LegislaturaMandatoAPI{NumeroLegislatura: "58", DataInicio: "2027-02-01", DataFim: "2031-01-31"}
+	m.Exercicios.Exercicio = senado.Lista[senado.ExercicioAPI]{
+		{DataInicio: "2024-04-10", DataFim: "2024-08-07", DescricaoCausaAfastamento: "Retorno do titular"},
+		{DataInicio: ""},
+	}
+
+	mandato, ok := convertMandato(m, 3)
+	if !ok {
+		t.Fatal("esperado mandato valido")
+	}
+	if mandato.Legislatura != 57 || mandato.SegundaLegislatura != 58 || mandato.Fim == nil || mandato.Fim.Year() != 2031 {
+		t.Errorf("legislaturas/fim inesperados: %+v", mandato)
+	}
+	if len(mandato.Exercicios) != 1 || mandato.Exercicios[0].SenadorID != 3 || mandato.Exercicios[0].Fim == nil {
+		t.Errorf("exercicios inesperados: %+v", mandato.Exercicios)
+	}
+}
+
+func TestPartidoNaData(t *testing.T) {
+	fimPSD := dia("2024-03-31")
+	filiacoes := []Filiacao{
+		{SiglaPartido: "PSD", DataFiliacao: dia("2019-01-10"), DataDesfiliacao: &fimPSD},
+		{SiglaPartido: "PL", DataFiliacao: dia("2024-04-01")},
+	}
+
+	casos := map[string]string{
+		"2023-06-15": "PSD",
+		"2024-03-31": "PSD",
+		"2024-04-01": "PL",
+		"2026-01-01": "PL",
+		"2010-01-01": "MDB", // sem filiacao cobrindo a data: usa o padrao
+	}
+	for data, esperado := range casos {
+		if got := PartidoNaData(filiacoes, dia(data), "MDB"); got != esperado {
+			t.Errorf("PartidoNaData(%s) = %q, esperado %q", data, got, esperado)
+		}
+	}
+}
+
+func TestConvertFiliacoes(t *testing.T) {
+	var a, b, c senado.FiliacaoAPI
+	a.Partido.SiglaPartido, a.DataFiliacao, a.DataDesfiliacao = "PSD", "2019-01-10", "2024-03-31"
+	b.Partido.SiglaPartido, b.DataFiliacao = "PL", "2024-04-01"
+	c.Partido.SiglaPartido = "PT" // sem data de filiacao
+
+	filiacoes := convertFiliacoes([]senado.FiliacaoAPI{a, b, b, c}, 9)
+	if len(filiacoes) != 2 {
+		t.Fatalf("esperado 2 filiacoes, obtido %d", len(filiacoes))
+	}
+	if filiacoes[0].DataDesfiliacao == nil || filiacoes[1].DataDesfiliacao != nil || filiacoes[1].Origem != OrigemFiliacaoAPI {
+		t.Errorf("filiacoes inesperadas: %+v", filiacoes)
+	}
+}
+
+func TestMesclarFiliacoes(t *testing.T) {
+	fimPSDB := dia("2015-04-01")
+	api := []Filiacao{
+		{SiglaPartido: "PSDB", DataFiliacao: dia("2010-03-01"), DataDesfiliacao: &fimPSDB, Origem: OrigemFiliacaoAPI},
+		{SiglaPartido: "PSD", DataFiliacao: dia("2015-04-02"), Origem: OrigemFiliacaoAPI},
+	}
+	detectadas := []Filiacao{
+		{ID: 9, SiglaPartido: "PSD", DataFiliacao: dia("2015-04-02"), Origem: OrigemFiliacaoSync}, // Coberta pela API
+		{ID: 10, SiglaPartido: "PL", DataFiliacao: dia("2024-03-10"), Origem: OrigemFiliacaoSync},
+	}
+
+	todas := MesclarFiliacoes(api, detectadas)
+	if len(todas) != 3 {
+		t.Fatalf("esperado 3 filiacoes, obtido %d: %+v", len(todas), todas)
+	}
+	if psd := todas[1]; psd.DataDesfiliacao == nil || !psd.DataDesfiliacao.Equal(dia("2024-03-09")) {
+		t.Errorf("filiacao aberta da API deveria ser encerrada na vespera da troca: %+v", psd)
+	}
+	if pl := todas[2]; pl.SiglaPartido != "PL" || pl.ID != 0 || pl.Origem != OrigemFiliacaoSync {
+		t.Errorf("troca detectada nao preservada: %+v", pl)
+	}
+	if got := PartidoNaData(todas, dia("2024-06-01"), ""); got != "PL" {
+		t.Errorf("partido apos a troca: %q", got)
+	}
+
+	// Sem troca posterior, a API substitui tudo
+	if todas := MesclarFiliacoes(api, detectadas[:1]); len(todas) != 2 || todas[1].DataDesfiliacao != nil {
+		t.Errorf("historico da API alterado sem troca detectada: %+v", todas)
+	}
+}
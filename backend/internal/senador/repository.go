@@ -1,6 +1,8 @@
 package senador
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -29,7 +31,7 @@ func (r *Repository) FindAll(includeInactive bool) ([]Senador, error) {
 // FindByID busca senador por ID interno
 func (r *Repository) FindByID(id int) (*Senador, error) {
 	var senador Senador
-	result := r.db.Preload("Mandatos").First(&senador, id)
+	result := r.db.Preload("Mandatos.Exercicios").First(&senador, id)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -39,7 +41,7 @@ func (r *Repository) FindByID(id int) (*Senador, error) {
 // FindByCodigo busca senador por codigo parlamentar
 func (r *Repository) FindByCodigo(codigo int) (*Senador, error) {
 	var senador Senador
-	result := r.db.Preload("Mandatos").
+	result := r.db.Preload("Mandatos.Exercicios").
 		Where("codigo_parlamentar = ?", codigo).
 		First(&senador)
 	if result.Error != nil {
@@ -74,6 +76,165 @@ func (r *Repository) SetInactive(activeCodes []int) error {
 		Update("em_exercicio", false).Error
 }
 
+// FindMandatos retorna o historico de mandatos de um senador, mais recentes primeiro
+func (r *Repository) FindMandatos(senadorID int) ([]Mandato, error) {
+	var mandatos []Mandato
+	result := r.db.Preload("Exercicios", func(db *gorm.DB) *gorm.DB {
+		return db.Order("inicio ASC")
+	}).
+		Where("senador_id = ?", senadorID).
+		Order("inicio DESC").
+		Find(&mandatos)
+	return mandatos, result.Error
+}
+
+// FindExercicios retorna todos os periodos de exercicio de um senador
+func (r *Repository) FindExercicios(senadorID int) ([]Exercicio, error) {
+	var exercicios []Exercicio
+	result := r.db.Where("senador_id = ?", senadorID).
+		Order("inicio ASC").
+		Find(&exercicios)
+	return exercicios, result.Error
+}
+
+// ReplaceMandatos substitui o historico de mandatos (e exercicios) de um senador
+func (r *Repository) ReplaceMandatos(senadorID int, mandatos []Mandato) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("senador_id = ?", senadorID).Delete(&Exercicio{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("senador_id = ?", senadorID).Delete(&Mandato{}).Error; err != nil {
+			return err
+		}
+		if len(mandatos) == 0 {
+			return nil
+		}
+		// Create com associacoes insere os exercicios de cada mandato
+		return tx.Create(&mandatos).Error
+	})
+}
+
+// FindFiliacoes retorna o historico de filiacoes de um senador, mais recentes primeiro
+func (r *Repository) FindFiliacoes(senadorID int) ([]Filiacao, error) {
+	var filiacoes []Filiacao
+	result := r.db.Where("senador_id = ?", senadorID).
+		Order("data_filiacao DESC").
+		Find(&filiacoes)
+	return filiacoes, result.Error
+}
+
+// FindAllFiliacoes retorna as filiacoes de todos os senadores agrupadas por senador
+func (r *Repository) FindAllFiliacoes() (map[int][]Filiacao, error) {
+	var filiacoes []Filiacao
+	if err := r.db.Order("senador_id, data_filiacao").Find(&filiacoes).Error; err != nil {
+		return nil, err
+	}
+	porSenador := make(map[int][]Filiacao)
+	for _, f := range filiacoes {
+		porSenador[f.SenadorID] = append(porSenador[f.SenadorID], f)
+	}
+	return porSenador, nil
+}
+
+// ReplaceFiliacoes substitui o historico de filiacoes de um senador pelo retornado pela API,
+// preservando as trocas detectadas na sincronizacao que a API ainda nao cobre
+func (r *Repository) ReplaceFiliacoes(senadorID int, filiacoes []Filiacao) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var detectadas []Filiacao
+		if err := tx.Where("senador_id = ? AND origem = ?", senadorID, OrigemFiliacaoSync).
+			Find(&detectadas).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("senador_id = ?", senadorID).Delete(&Filiacao{}).Error; err != nil {
+			return err
+		}
+		todas := MesclarFiliacoes(filiacoes, detectadas)
+		if len(todas) == 0 {
+			return nil
+		}
+		return tx.Create(&todas).Error
+	})
+}
+
+// RegistrarTroca encerra a filiacao vigente e abre uma nova para o partido informado.
+// Usado quando a sincronizacao detecta mudanca em Senador.Partido. Sem historico do
+// partido anterior, grava tambem a filiacao anterior encerrada (desde o cadastro do
+// senador), para que a troca apareca em FindTrocas.
+func (r *Repository) RegistrarTroca(senadorID int, anterior, partido string, data time.Time) error {
+	dia := time.Date(data.Year(), data.Month(), data.Day(), 0, 0, 0, 0, time.UTC)
+	vespera := dia.AddDate(0, 0, -1)
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Filiacao{}).
+			Where("senador_id = ? AND data_desfiliacao IS NULL AND sigla_partido <> ?", senadorID, partido).
+			Update("data_desfiliacao", vespera).Error; err != nil {
+			return err
+		}
+
+		var historicoAnterior int64
+		if err := tx.Model(&Filiacao{}).
+			Where("senador_id = ? AND sigla_partido = ? AND data_filiacao < ?", senadorID, anterior, dia).
+			Count(&historicoAnterior).Error; err != nil {
+			return err
+		}
+		if historicoAnterior == 0 {
+			inicio := vespera
+			var sen Senador
+			if err := tx.Select("created_at").First(&sen, senadorID).Error; err == nil && sen.CreatedAt.Before(vespera) {
+				inicio = time.Date(sen.CreatedAt.Year(), sen.CreatedAt.Month(), sen.CreatedAt.Day(), 0, 0, 0, 0, time.UTC)
+			}
+			if err := tx.Create(&Filiacao{
+				SenadorID:       senadorID,
+				SiglaPartido:    anterior,
+				DataFiliacao:    inicio,
+				DataDesfiliacao: &vespera,
+				Origem:          OrigemFiliacaoSync,
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		var abertas int64
+		if err := tx.Model(&Filiacao{}).
+			Where("senador_id = ? AND data_desfiliacao IS NULL AND sigla_partido = ?", senadorID, partido).
+			Count(&abertas).Error; err != nil {
+			return err
+		}
+		if abertas > 0 {
+			return nil
+		}
+
+		return tx.Create(&Filiacao{
+			SenadorID:    senadorID,
+			SiglaPartido: partido,
+			DataFiliacao: dia,
+			Origem:       OrigemFiliacaoSync,
+		}).Error
+	})
+}
+
+// FindTrocas retorna mudancas de partido a partir da data informada, mais recentes primeiro
+func (r *Repository) FindTrocas(desde time.Time, limit, offset int) ([]TrocaPartido, int64, error) {
+	historico := r.db.Model(&Filiacao{}).
+		Select("senador_id, sigla_partido, data_filiacao, LAG(sigla_partido) OVER (PARTITION BY senador_id ORDER BY data_filiacao) as partido_anterior")
+
+	query := r.db.Table("(?) as t", historico).
+		Joins("JOIN senadores s ON s.id = t.senador_id").
+		Where("t.partido_anterior IS NOT NULL AND t.partido_anterior <> t.sigla_partido AND t.data_filiacao >= ?", desde)
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var trocas []TrocaPartido
+	err := query.Select("t.senador_id, s.nome, s.uf, s.foto_url, t.partido_anterior, t.sigla_partido as partido_novo, t.data_filiacao as data").
+		Order("t.data_filiacao DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&trocas).Error
+	return trocas, total, err
+}
+
 // Count retorna o total de senadores em exercicio
 func (r *Repository) Count() (int64, error) {
 	var count int64
@@ -4,7 +4,9 @@ import (
 	"context"
 	"log/slog"
 	"strconv"
+	"time"
 
+	"github.com/Alzarus/to-de-olho/internal/utils"
 	"github.com/Alzarus/to-de-olho/pkg/senado"
 )
 
@@ -34,6 +36,14 @@ func (s *SyncService) SyncFromAPI(ctx context.Context) error {
 
 	slog.Info("senadores recebidos da API", "total", len(parlamentares))
 
+	// Partido atual de cada senador ja cadastrado, para detectar trocas
+	partidoAnterior := make(map[int]string)
+	if existentes, err := s.repo.FindAll(true); err == nil {
+		for _, e := range existentes {
+			partidoAnterior[e.CodigoParlamentar] = e.Partido
+		}
+	}
+
 	// Converter e salvar cada senador
 	var successCount int
 	var activeCodes []int
@@ -43,6 +53,12 @@ func (s *SyncService) SyncFromAPI(ctx context.Context) error {
 			slog.Error("falha ao salvar senador", "codigo", senador.CodigoParlamentar, "error", err)
 			continue
 		}
+		if anterior := partidoAnterior[senador.CodigoParlamentar]; anterior != "" && senador.Partido != "" && anterior != senador.Partido {
+			slog.Info("troca de partido detectada", "senador", senador.Nome, "de", anterior, "para", senador.Partido)
+			if err := s.repo.RegistrarTroca(senador.ID, anterior, senador.Partido, time.Now()); err != nil {
+				slog.Warn("falha ao registrar troca de partido", "senador", senador.Nome, "error", err)
+			}
+		}
 		activeCodes = append(activeCodes, senador.CodigoParlamentar)
 		successCount++
 	}
@@ -54,9 +70,149 @@ func (s *SyncService) SyncFromAPI(ctx context.Context) error {
 	}
 
 	slog.Info("sync de senadores concluido", "salvos", successCount, "total", len(parlamentares))
+
+	if err := s.SyncMandatos(ctx); err != nil {
+		slog.Error("falha ao sincronizar mandatos", "error", err)
+	}
+	if err := s.SyncFiliacoes(ctx); err != nil {
+		slog.Error("falha ao sincronizar filiacoes", "error", err)
+	}
+	return nil
+}
+
+// SyncFiliacoes busca o historico de filiacoes partidarias dos senadores em exercicio.
+// Senadores sem historico na API mantem as trocas detectadas pela sincronizacao.
+func (s *SyncService) SyncFiliacoes(ctx context.Context) error {
+	senadores, err := s.repo.FindAll(false)
+	if err != nil {
+		return err
+	}
+
+	var totalFiliacoes int
+	for _, sen := range senadores {
+		filiacoesAPI, err := s.client.ListarFiliacoesParlamentar(ctx, sen.CodigoParlamentar)
+		if err != nil {
+			slog.Warn("falha ao buscar filiacoes", "senador", sen.Nome, "error", err)
+			continue
+		}
+
+		filiacoes := convertFiliacoes(filiacoesAPI, sen.ID)
+		if len(filiacoes) == 0 {
+			continue
+		}
+
+		if err := s.repo.ReplaceFiliacoes(sen.ID, filiacoes); err != nil {
+			slog.Warn("falha ao salvar filiacoes", "senador", sen.Nome, "error", err)
+			continue
+		}
+		totalFiliacoes += len(filiacoes)
+	}
+
+	slog.Info("sync de filiacoes concluido", "senadores", len(senadores), "filiacoes", totalFiliacoes)
+	return nil
+}
+
+// convertFiliacoes converte filiacoes da API descartando registros sem data ou repetidos
+func convertFiliacoes(filiacoesAPI []senado.FiliacaoAPI, senadorID int) []Filiacao {
+	filiacoes := make([]Filiacao, 0, len(filiacoesAPI))
+	vistas := make(map[string]bool)
+	for _, f := range filiacoesAPI {
+		inicio := utils.ParseDataISO(f.DataFiliacao)
+		if inicio == nil || f.Partido.SiglaPartido == "" {
+			continue
+		}
+		chave := f.Partido.SiglaPartido + inicio.Format("2006-01-02")
+		if vistas[chave] {
+			continue
+		}
+		vistas[chave] = true
+		filiacoes = append(filiacoes, Filiacao{
+			SenadorID:       senadorID,
+			SiglaPartido:    f.Partido.SiglaPartido,
+			NomePartido:     f.Partido.NomePartido,
+			DataFiliacao:    *inicio,
+			DataDesfiliacao: utils.ParseDataISO(f.DataDesfiliacao),
+			Origem:          OrigemFiliacaoAPI,
+		})
+	}
+	return filiacoes
+}
+
+// SyncMandatos busca o historico de mandatos e periodos de exercicio dos senadores em exercicio
+func (s *SyncService) SyncMandatos(ctx context.Context) error {
+	senadores, err := s.repo.FindAll(false)
+	if err != nil {
+		return err
+	}
+
+	var totalMandatos int
+	for _, sen := range senadores {
+		mandatosAPI, err := s.client.ListarMandatosParlamentar(ctx, sen.CodigoParlamentar)
+		if err != nil {
+			slog.Warn("falha ao buscar mandatos", "senador", sen.Nome, "error", err)
+			continue
+		}
+
+		mandatos := make([]Mandato, 0, len(mandatosAPI))
+		for _, m := range mandatosAPI {
+			if mandato, ok := convertMandato(m, sen.ID); ok {
+				mandatos = append(mandatos, mandato)
+			}
+		}
+
+		if err := s.repo.ReplaceMandatos(sen.ID, mandatos); err != nil {
+			slog.Warn("falha ao salvar mandatos", "senador", sen.Nome, "error", err)
+			continue
+		}
+		totalMandatos += len(mandatos)
+	}
+
+	slog.Info("sync de mandatos concluido", "senadores", len(senadores), "mandatos", totalMandatos)
 	return nil
 }
 
+// convertMandato converte um mandato da API; retorna false sem data de inicio valida
+func convertMandato(m senado.MandatoAPI, senadorID int) (Mandato, bool) {
+	inicio := utils.ParseDataISO(m.PrimeiraLegislaturaDoMandato.DataInicio)
+	if inicio == nil {
+		return Mandato{}, false
+	}
+
+	fim := utils.ParseDataISO(m.SegundaLegislaturaDoMandato.DataFim)
+	if fim == nil {
+		fim = utils.ParseDataISO(m.PrimeiraLegislaturaDoMandato.DataFim)
+	}
+
+	legislatura, _ := strconv.Atoi(m.PrimeiraLegislaturaDoMandato.NumeroLegislatura)
+	segunda, _ := strconv.Atoi(m.SegundaLegislaturaDoMandato.NumeroLegislatura)
+
+	mandato := Mandato{
+		SenadorID:          senadorID,
+		CodigoMandato:      m.CodigoMandato,
+		UF:                 m.UfParlamentar,
+		Legislatura:        legislatura,
+		SegundaLegislatura: segunda,
+		Inicio:             *inicio,
+		Fim:                fim,
+		Tipo:               m.DescricaoParticipacao,
+	}
+
+	for _, e := range m.Exercicios.Exercicio {
+		ini := utils.ParseDataISO(e.DataInicio)
+		if ini == nil {
+			continue
+		}
+		mandato.Exercicios = append(mandato.Exercicios, Exercicio{
+			SenadorID:        senadorID,
+			Inicio:           *ini,
+			Fim:              utils.ParseDataISO(e.DataFim),
+			CausaAfastamento: e.DescricaoCausaAfastamento,
+		})
+	}
+
+	return mandato, true
+}
+
 // convertToSenador converte dados da API para modelo interno
 func (s *SyncService) convertToSenador(p senado.ParlamentarAPI) Senador {
 	id := p.IdentificacaoParlamentar
@@ -0,0 +1,9 @@
+package utils
+
+import "math"
+
+// Arredondar arredonda para o numero de casas decimais informado
+func Arredondar(valor float64, casas int) float64 {
+	fator := math.Pow(10, float64(casas))
+	return math.Round(valor*fator) / fator
+}
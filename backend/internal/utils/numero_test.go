@@ -0,0 +1,24 @@
+package utils
+
+import "testing"
+
+func TestArredondar(t *testing.T) {
+	testes := []struct {
+		valor    float64
+		casas    int
+		esperado float64
+	}{
+		{10.555, 2, 10.56},
+		{10.554, 2, 10.55},
+		{99.999, 2, 100},
+		{-0.12345, 4, -0.1235},
+		{0.5, 0, 1},
+		{0, 2, 0},
+	}
+
+	for _, teste := range testes {
+		if got := Arredondar(teste.valor, teste.casas); got != teste.esperado {
+			t.Errorf("Arredondar(%v, %d) = %v; esperado %v", teste.valor, teste.casas, got, teste.esperado)
+		}
+	}
+}
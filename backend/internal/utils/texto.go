@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+var acentos = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+	"Á", "A", "À", "A", "Â", "A", "Ã", "A", "Ä", "A",
+	"É", "E", "È", "E", "Ê", "E", "Ë", "E",
+	"Í", "I", "Ì", "I", "Î", "I", "Ï", "I",
+	"Ó", "O", "Ò", "O", "Ô", "O", "Õ", "O", "Ö", "O",
+	"Ú", "U", "Ù", "U", "Û", "U", "Ü", "U",
+	"Ç", "C", "Ñ", "N",
+)
+
+// ApenasDigitos mantem apenas os digitos de um texto (CNPJ/CPF, codigos com mascara)
+func ApenasDigitos(valor string) string {
+	var b strings.Builder
+	for _, r := range valor {
+		if unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// RemoverAcentos troca letras acentuadas pela versao sem acento, preservando a caixa
+func RemoverAcentos(texto string) string {
+	return acentos.Replace(texto)
+}
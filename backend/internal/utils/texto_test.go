@@ -0,0 +1,42 @@
+package utils
+
+import "testing"
+
+func TestApenasDigitos(t *testing.T) {
+	testes := []struct {
+		entrada  string
+		esperado string
+	}{
+		{"12.345.678/0001-90", "12345678000190"},
+		{"123.456.789-01", "12345678901"},
+		{"2024.2747.0001", "202427470001"},
+		{"EXTERIOR-123", "123"},
+		{"  ", ""},
+	}
+
+	for _, teste := range testes {
+		if got := ApenasDigitos(teste.entrada); got != teste.esperado {
+			t.Errorf("ApenasDigitos(%q) = %q; esperado %q", teste.entrada, got, teste.esperado)
+		}
+	}
+}
+
+func TestRemoverAcentos(t *testing.T) {
+	testes := []struct {
+		entrada  string
+		esperado string
+	}{
+		{"Liquidação", "Liquidacao"},
+		{"SÃO JOÃO DO PIAUÍ", "SAO JOAO DO PIAUI"},
+		{"Santa Bárbara d'Oeste", "Santa Barbara d'Oeste"},
+		{"Embu-Guaçu", "Embu-Guacu"},
+		{"Piñón Ü", "Pinon U"},
+		{"sem acento", "sem acento"},
+	}
+
+	for _, teste := range testes {
+		if got := RemoverAcentos(teste.entrada); got != teste.esperado {
+			t.Errorf("RemoverAcentos(%q) = %q; esperado %q", teste.entrada, got, teste.esperado)
+		}
+	}
+}
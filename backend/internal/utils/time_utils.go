@@ -1,6 +1,9 @@
 package utils
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // GetInicioLegislaturaAtual retorna o ano de inicio da legislatura atual (ex: 2023 para 2023-2026)
 func GetInicioLegislaturaAtual() int {
@@ -8,3 +11,27 @@ func GetInicioLegislaturaAtual() int {
 	// As legislaturas no Senado comecam nos anos seguintes as eleicoes (ex: 2015, 2019, 2023, 2027)
 	return year - ((year - 3) % 4)
 }
+
+// ParseDataBR le datas no formato DD/MM/AAAA (ou AAAA-MM-DD); nil se vazia ou invalida
+func ParseDataBR(valor string) *time.Time {
+	valor = strings.TrimSpace(valor)
+	for _, layout := range []string{"02/01/2006", "2006-01-02"} {
+		if t, err := time.Parse(layout, valor); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
+
+// ParseDataISO le datas da API no formato AAAA-MM-DD, com ou sem horario; nil se vazia ou invalida
+func ParseDataISO(valor string) *time.Time {
+	valor = strings.TrimSpace(valor)
+	if len(valor) < 10 {
+		return nil
+	}
+	t, err := time.Parse("2006-01-02", valor[:10])
+	if err != nil {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,57 @@
+package utils
+
+import "testing"
+
+func TestParseDataBR(t *testing.T) {
+	testes := []struct {
+		entrada  string
+		esperado string // vazio quando a data e invalida
+	}{
+		{"15/03/2024", "2024-03-15"},
+		{" 01/12/2023 ", "2023-12-01"},
+		{"2024-02-29", "2024-02-29"},
+		{"31/02/2024", ""},
+		{"2024-03-15T10:00:00", ""},
+		{"", ""},
+	}
+
+	for _, teste := range testes {
+		got := ParseDataBR(teste.entrada)
+		if teste.esperado == "" {
+			if got != nil {
+				t.Errorf("ParseDataBR(%q) = %v; esperado nil", teste.entrada, got)
+			}
+			continue
+		}
+		if got == nil || got.Format("2006-01-02") != teste.esperado {
+			t.Errorf("ParseDataBR(%q) = %v; esperado %s", teste.entrada, got, teste.esperado)
+		}
+	}
+}
+
+func TestParseDataISO(t *testing.T) {
+	testes := []struct {
+		entrada  string
+		esperado string // vazio quando a data e invalida
+	}{
+		{"2024-03-15", "2024-03-15"},
+		{"2024-03-15T10:30:00", "2024-03-15"},
+		{"  2024-03-15  ", "2024-03-15"},
+		{"   2024-1-1", ""}, // curta depois do trim: nao pode estourar o indice
+		{"15/03/2024", ""},
+		{"", ""},
+	}
+
+	for _, teste := range testes {
+		got := ParseDataISO(teste.entrada)
+		if teste.esperado == "" {
+			if got != nil {
+				t.Errorf("ParseDataISO(%q) = %v; esperado nil", teste.entrada, got)
+			}
+			continue
+		}
+		if got == nil || got.Format("2006-01-02") != teste.esperado {
+			t.Errorf("ParseDataISO(%q) = %v; esperado %s", teste.entrada, got, teste.esperado)
+		}
+	}
+}
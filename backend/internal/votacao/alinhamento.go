@@ -0,0 +1,187 @@
+package votacao
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Orientacao registra como uma bancada (partido ou governo) orientou o voto em uma sessao.
+// Nas orientacoes deduzidas pela maioria, VotosSim e VotosNao guardam a contagem do partido
+// de referencia, para que o voto do proprio senador seja descontado no alinhamento.
+type Orientacao struct {
+	ID             int       `gorm:"primaryKey" json:"id"`
+	SessaoID       string    `gorm:"uniqueIndex:idx_orientacao_unica,priority:1;not null" json:"sessao_id"`
+	Bancada        string    `gorm:"uniqueIndex:idx_orientacao_unica,priority:2;not null" json:"bancada"` // Sigla do partido ou "Governo"
+	Orientacao     string    `json:"orientacao"`                                                          // Sim, Nao, Liberado
+	Origem         string    `json:"origem"`                                                              // lideranca, maioria
+	PartidoMaioria string    `json:"partido_maioria,omitempty"`                                           // Partido contado (origem maioria)
+	VotosSim       int       `json:"votos_sim,omitempty"`
+	VotosNao       int       `json:"votos_nao,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// TableName define o nome da tabela
+func (Orientacao) TableName() string {
+	return "votacao_orientacoes"
+}
+
+const (
+	BancadaGoverno     = "Governo"
+	OrientacaoLiberado = "Liberado"
+
+	// Orientacao registrada pela lideranca na propria votacao
+	OrigemLideranca = "lideranca"
+	// Orientacao deduzida pelo voto da maioria da bancada
+	OrigemMaioria = "maioria"
+)
+
+// PeriodoGoverno e o intervalo [Inicio, Fim) em que os Partidos eram os do Presidente
+// da Republica. Mais de uma sigla cobre renomeacoes (PMDB -> MDB).
+type PeriodoGoverno struct {
+	Inicio   time.Time
+	Fim      time.Time
+	Partidos []string
+}
+
+func dia(ano int, mes time.Month, d int) time.Time {
+	return time.Date(ano, mes, d, 0, 0, 0, 0, time.UTC)
+}
+
+// PeriodosGoverno lista os partidos do Presidente da Republica. A maioria do partido serve
+// de referencia de governismo quando a votacao nao traz a orientacao do lider do governo.
+// Periodos sem partido (Bolsonaro entre a saida do PSL e a filiacao ao PL) e datas fora
+// da tabela ficam sem essa referencia.
+var PeriodosGoverno = []PeriodoGoverno{
+	{Inicio: dia(1995, time.January, 1), Fim: dia(2003, time.January, 1), Partidos: []string{"PSDB"}},     // FHC
+	{Inicio: dia(2003, time.January, 1), Fim: dia(2016, time.May, 12), Partidos: []string{"PT"}},          // Lula e Dilma ate o afastamento
+	{Inicio: dia(2016, time.May, 12), Fim: dia(2019, time.January, 1), Partidos: []string{"PMDB", "MDB"}}, // Temer
+	{Inicio: dia(2019, time.January, 1), Fim: dia(2019, time.November, 19), Partidos: []string{"PSL"}},    // Bolsonaro
+	{Inicio: dia(2021, time.November, 30), Fim: dia(2023, time.January, 1), Partidos: []string{"PL"}},     // Bolsonaro
+	{Inicio: dia(2023, time.January, 1), Fim: dia(2027, time.January, 5), Partidos: []string{"PT"}},       // Lula
+}
+
+// PartidosGoverno retorna as siglas do partido do Presidente na data, ou nil se nao houver
+func PartidosGoverno(data time.Time) []string {
+	for _, p := range PeriodosGoverno {
+		if !data.Before(p.Inicio) && data.Before(p.Fim) {
+			return p.Partidos
+		}
+	}
+	return nil
+}
+
+// Alinhamento resume quanto um senador acompanha a orientacao do partido e do governo.
+// Apenas votos Sim/Nao em sessoes com orientacao Sim/Nao entram no calculo.
+type Alinhamento struct {
+	SenadorID              int     `json:"senador_id"`
+	VotosComparadosPartido int     `json:"votos_comparados_partido"`
+	VotosComPartido        int     `json:"votos_com_partido"`
+	Fidelidade             float64 `json:"fidelidade"` // 0-100
+	VotosComparadosGoverno int     `json:"votos_comparados_governo"`
+	VotosComGoverno        int     `json:"votos_com_governo"`
+	Governismo             float64 `json:"governismo"` // 0-100
+}
+
+// calcularIndices preenche os percentuais de fidelidade e governismo
+func (a *Alinhamento) calcularIndices() {
+	if a.VotosComparadosPartido > 0 {
+		a.Fidelidade = float64(a.VotosComPartido) / float64(a.VotosComparadosPartido) * 100
+	}
+	if a.VotosComparadosGoverno > 0 {
+		a.Governismo = float64(a.VotosComGoverno) / float64(a.VotosComparadosGoverno) * 100
+	}
+}
+
+// DefinirOrientacoes combina as orientacoes de lideranca de uma sessao com a maioria de cada
+// bancada: partidos sem orientacao registrada recebem o voto majoritario (Sim ou Nao) dos
+// seus senadores; empates ficam sem orientacao. O governo, sem orientacao do lider, segue
+// a maioria do partido do Presidente na data da sessao (PartidosGoverno), se houver.
+// As contagens ficam gravadas para o calculo leave-one-out do alinhamento.
+func DefinirOrientacoes(sessaoID string, data time.Time, liderancas []Orientacao, votos []Votacao) []Orientacao {
+	orientacoes := make([]Orientacao, 0, len(liderancas))
+	definidas := make(map[string]bool, len(liderancas))
+	for _, o := range liderancas {
+		if o.Bancada == "" || definidas[o.Bancada] {
+			continue
+		}
+		o.SessaoID = sessaoID
+		o.Origem = OrigemLideranca
+		orientacoes = append(orientacoes, o)
+		definidas[o.Bancada] = true
+	}
+
+	type contagem struct{ sim, nao int }
+	porPartido := make(map[string]*contagem)
+	var partidos []string
+	for _, v := range votos {
+		if v.SenadorPartido == "" || (v.Voto != "Sim" && v.Voto != "Nao") {
+			continue
+		}
+		c, ok := porPartido[v.SenadorPartido]
+		if !ok {
+			c = &contagem{}
+			porPartido[v.SenadorPartido] = c
+			partidos = append(partidos, v.SenadorPartido)
+		}
+		if v.Voto == "Sim" {
+			c.sim++
+		} else {
+			c.nao++
+		}
+	}
+
+	maioria := func(bancada, partido string) (Orientacao, bool) {
+		c, ok := porPartido[partido]
+		if !ok || c.sim == c.nao {
+			return Orientacao{}, false
+		}
+		o := Orientacao{SessaoID: sessaoID, Bancada: bancada, Orientacao: "Nao", Origem: OrigemMaioria,
+			PartidoMaioria: partido, VotosSim: c.sim, VotosNao: c.nao}
+		if c.sim > c.nao {
+			o.Orientacao = "Sim"
+		}
+		return o, true
+	}
+
+	for _, partido := range partidos {
+		if definidas[partido] {
+			continue
+		}
+		if o, ok := maioria(partido, partido); ok {
+			orientacoes = append(orientacoes, o)
+		}
+	}
+
+	if !definidas[BancadaGoverno] {
+		for _, partido := range PartidosGoverno(data) {
+			if o, ok := maioria(BancadaGoverno, partido); ok {
+				orientacoes = append(orientacoes, o)
+				break
+			}
+		}
+	}
+
+	return orientacoes
+}
+
+// orientacaoSemProprioVotoSQL e a orientacao da bancada (alias da tabela de orientacoes)
+// para o voto da linha de votacoes. Nas orientacoes deduzidas pela maioria, o voto do
+// proprio senador e descontado quando ele e do partido contado (leave-one-out): bancadas
+// de um senador, ou empatadas sem ele, ficam sem orientacao.
+func orientacaoSemProprioVotoSQL(alias, partido string) string {
+	return fmt.Sprintf(`CASE WHEN %[1]s.id IS NULL THEN NULL
+		WHEN %[1]s.origem <> '%[3]s' OR %[1]s.partido_maioria <> %[2]s THEN %[1]s.orientacao
+		WHEN %[1]s.votos_sim - (votacoes.voto = 'Sim')::int > %[1]s.votos_nao - (votacoes.voto = 'Nao')::int THEN 'Sim'
+		WHEN %[1]s.votos_sim - (votacoes.voto = 'Sim')::int < %[1]s.votos_nao - (votacoes.voto = 'Nao')::int THEN 'Nao'
+		END`, alias, partido, OrigemMaioria)
+}
+
+// normalizeBancada padroniza a bancada informada pela API ("PT", "Governo", "Lider do Governo"...)
+func normalizeBancada(bancada string) string {
+	bancada = strings.TrimSpace(bancada)
+	if strings.Contains(strings.ToLower(bancada), "governo") {
+		return BancadaGoverno
+	}
+	return strings.ToUpper(bancada)
+}
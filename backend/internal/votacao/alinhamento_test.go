@@ -0,0 +1,141 @@
+package votacao
+
+import (
+	"testing"
+	"time"
+
+	senadoapi "github.com/Alzarus/to-de-olho/pkg/senado"
+)
+
+func TestDefinirOrientacoes(t *testing.T) {
+	votos := []Votacao{
+		{SenadorPartido: "PT", Voto: "Sim"},
+		{SenadorPartido: "PT", Voto: "Sim"},
+		{SenadorPartido: "PT", Voto: "Nao"},
+		{SenadorPartido: "PL", Voto: "Nao"},
+		{SenadorPartido: "PL", Voto: "Sim"},
+		{SenadorPartido: "PL", Voto: "Nao"},
+		{SenadorPartido: "MDB", Voto: "Sim"},
+		{SenadorPartido: "MDB", Voto: "Nao"},
+		{SenadorPartido: "PSD", Voto: VotoNCom},
+	}
+	liderancas := []Orientacao{{Bancada: "PL", Orientacao: "Sim"}}
+
+	orientacoes := DefinirOrientacoes("1_2025", time.Date(2025, 5, 6, 0, 0, 0, 0, time.UTC), liderancas, votos)
+
+	porBancada := make(map[string]Orientacao)
+	for _, o := range orientacoes {
+		if o.SessaoID != "1_2025" {
+			t.Errorf("sessao nao preenchida: %+v", o)
+		}
+		porBancada[o.Bancada] = o
+	}
+
+	// Lideranca prevalece sobre a maioria da bancada
+	if o := porBancada["PL"]; o.Orientacao != "Sim" || o.Origem != OrigemLideranca {
+		t.Errorf("PL: %+v", o)
+	}
+	// Maioria guarda a contagem para descontar o voto do proprio senador
+	if o := porBancada["PT"]; o.Orientacao != "Sim" || o.Origem != OrigemMaioria || o.VotosSim != 2 || o.VotosNao != 1 || o.PartidoMaioria != "PT" {
+		t.Errorf("PT: %+v", o)
+	}
+	// Empate e bancada sem votos Sim/Nao ficam sem orientacao
+	if _, ok := porBancada["MDB"]; ok {
+		t.Error("MDB empatado nao deveria ter orientacao")
+	}
+	if _, ok := porBancada["PSD"]; ok {
+		t.Error("PSD sem votos nominais nao deveria ter orientacao")
+	}
+	// Governo segue a maioria do partido do Presidente na data
+	if o := porBancada[BancadaGoverno]; o.Orientacao != "Sim" || o.Origem != OrigemMaioria || o.PartidoMaioria != "PT" {
+		t.Errorf("Governo: %+v", o)
+	}
+}
+
+func TestDefinirOrientacoesGovernoSemPartido(t *testing.T) {
+	votos := []Votacao{
+		{SenadorPartido: "PT", Voto: "Sim"},
+		{SenadorPartido: "PT", Voto: "Sim"},
+		{SenadorPartido: "PL", Voto: "Nao"},
+	}
+	casos := []struct {
+		nome    string
+		data    time.Time
+		governo string // "" = sem orientacao do governo
+	}{
+		{"Bolsonaro pelo PL", time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), "Nao"},
+		{"Bolsonaro sem partido", time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC), ""},
+		{"Lula", time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC), "Sim"},
+		{"fora da tabela", time.Date(2027, 2, 1, 0, 0, 0, 0, time.UTC), ""},
+	}
+	for _, c := range casos {
+		var governo string
+		for _, o := range DefinirOrientacoes("3_2020", c.data, nil, votos) {
+			if o.Bancada == BancadaGoverno {
+				governo = o.Orientacao
+			}
+		}
+		if governo != c.governo {
+			t.Errorf("%s: orientacao do governo %q, esperado %q", c.nome, governo, c.governo)
+		}
+	}
+}
+
+func TestPartidosGoverno(t *testing.T) {
+	casos := []struct {
+		data     time.Time
+		partidos []string
+	}{
+		{time.Date(2016, 5, 11, 12, 0, 0, 0, time.UTC), []string{"PT"}},
+		{time.Date(2016, 5, 12, 0, 0, 0, 0, time.UTC), []string{"PMDB", "MDB"}},
+		{time.Date(2019, 11, 19, 0, 0, 0, 0, time.UTC), nil},
+		{time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC), []string{"PT"}},
+		{time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), nil},
+	}
+	for _, c := range casos {
+		got := PartidosGoverno(c.data)
+		if len(got) != len(c.partidos) || (len(got) > 0 && got[0] != c.partidos[0]) {
+			t.Errorf("PartidosGoverno(%s) = %v, esperado %v", c.data.Format("2006-01-02"), got, c.partidos)
+		}
+	}
+}
+
+func TestDefinirOrientacoesGovernoPelaLideranca(t *testing.T) {
+	votos := []Votacao{{SenadorPartido: "PT", Voto: "Sim"}}
+	liderancas := convertOrientacoes([]senadoapi.OrientacaoLiderancaAPI{
+		{Partido: "Lider do Governo", Voto: "Não"},
+		{Partido: "pl", Voto: "Liberado"},
+	})
+
+	orientacoes := DefinirOrientacoes("2_2025", time.Date(2025, 5, 6, 0, 0, 0, 0, time.UTC), liderancas, votos)
+
+	var governo, pl *Orientacao
+	for i := range orientacoes {
+		switch orientacoes[i].Bancada {
+		case BancadaGoverno:
+			governo = &orientacoes[i]
+		case "PL":
+			pl = &orientacoes[i]
+		}
+	}
+	if governo == nil || governo.Orientacao != "Nao" || governo.Origem != OrigemLideranca {
+		t.Errorf("orientacao do governo inesperada: %+v", governo)
+	}
+	if pl == nil || pl.Orientacao != OrientacaoLiberado {
+		t.Errorf("orientacao do PL inesperada: %+v", pl)
+	}
+}
+
+func TestCalcularIndicesAlinhamento(t *testing.T) {
+	a := Alinhamento{VotosComparadosPartido: 80, VotosComPartido: 72, VotosComparadosGoverno: 50, VotosComGoverno: 20}
+	a.calcularIndices()
+	if a.Fidelidade != 90 || a.Governismo != 40 {
+		t.Errorf("indices inesperados: fidelidade %.2f, governismo %.2f", a.Fidelidade, a.Governismo)
+	}
+
+	vazio := Alinhamento{}
+	vazio.calcularIndices()
+	if vazio.Fidelidade != 0 || vazio.Governismo != 0 {
+		t.Errorf("sem votos comparados os indices devem ser 0: %+v", vazio)
+	}
+}
@@ -89,6 +89,33 @@ func (h *Handler) GetStats(c *gin.Context) {
 	c.JSON(http.StatusOK, stats)
 }
 
+// GetAlinhamento godoc
+// @Summary Retorna fidelidade partidaria e alinhamento ao governo de um senador
+// @Description Percentual de votos Sim/Nao iguais a orientacao do partido (vigente na data) e do governo.
+// @Description Sem orientacao da lideranca, vale o voto da maioria da bancada.
+// @Tags votacoes
+// @Produce json
+// @Param id path int true "ID do senador"
+// @Param ano query int false "Ano (default: legislatura atual)"
+// @Success 200 {object} Alinhamento
+// @Router /api/v1/senadores/{id}/votacoes/alinhamento [get]
+func (h *Handler) GetAlinhamento(c *gin.Context) {
+	senadorID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalido"})
+		return
+	}
+	ano, _ := strconv.Atoi(c.Query("ano"))
+
+	alinhamento, err := h.repo.GetAlinhamento(senadorID, ano)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao calcular alinhamento"})
+		return
+	}
+
+	c.JSON(http.StatusOK, alinhamento)
+}
+
 // GetVotosPorTipo godoc
 // @Summary Retorna contagem de votos por tipo
 // @Tags votacoes
@@ -156,7 +183,7 @@ func (h *Handler) GetAll(c *gin.Context) {
 }
 
 // GetByID godoc
-// @Summary Retorna detalhes de uma votacao e lista de votos
+// @Summary Retorna uma votacao com placar, resultado, votos e consolidacao por partido e UF
 // @Tags votacoes
 // @Produce json
 // @Param id path string true "ID da Sessao de Votacao"
@@ -166,7 +193,7 @@ func (h *Handler) GetByID(c *gin.Context) {
 	id := c.Param("id")
 
 	// Metadata da votacao
-	votacao, err := h.repo.FindByID(id)
+	sessao, err := h.repo.FindByID(id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "votacao nao encontrada"})
 		return
@@ -180,8 +207,24 @@ func (h *Handler) GetByID(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"votacao": votacao,
-		"votos":   votos,
-	})
+	resumo := ResumirSessao(*sessao, votos)
+
+	orientacoes, err := h.repo.FindOrientacoes(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "erro ao buscar orientacoes"})
+		return
+	}
+
+	resposta := gin.H{
+		"votacao":     sessao,
+		"orientacoes": orientacoes,
+		"resultado":   resumo.Resultado,
+		"secreta":     sessao.Secreta,
+		"placar":      resumo.Placar,
+		"por_partido": resumo.PorPartido,
+		"por_uf":      resumo.PorUF,
+		"votos":       votos,
+	}
+
+	c.JSON(http.StatusOK, resposta)
 }
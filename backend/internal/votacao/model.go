@@ -1,26 +1,30 @@
 package votacao
 
-import "time"
+import (
+	"sort"
+	"strings"
+	"time"
+)
 
 // Votacao representa um voto de um senador em uma sessao
 type Votacao struct {
-	ID              int       `gorm:"primaryKey" json:"id"`
-	SenadorID       int       `gorm:"uniqueIndex:idx_votacao_unica,priority:1;index:idx_votacao_senador;not null" json:"senador_id"`
-	SessaoID        string    `gorm:"uniqueIndex:idx_votacao_unica,priority:2;index:idx_votacao_sessao;not null" json:"sessao_id"`
-	CodigoSessao    string    `json:"codigo_sessao"`
-	Data            time.Time `gorm:"index" json:"data"`
-	Voto            string    `json:"voto"` // Sim, Nao, Abstencao, Obstrucao, NCom
-	DescricaoVotacao string   `json:"descricao_votacao,omitempty"`
-	Materia         string    `json:"materia,omitempty"`
+	ID           int       `gorm:"primaryKey" json:"id"`
+	SenadorID    int       `gorm:"uniqueIndex:idx_votacao_unica,priority:1;index:idx_votacao_senador;not null" json:"senador_id"`
+	SessaoID     string    `gorm:"uniqueIndex:idx_votacao_unica,priority:2;index:idx_votacao_sessao;not null" json:"sessao_id"`
+	CodigoSessao string    `json:"codigo_sessao"`
+	Data         time.Time `gorm:"index" json:"data"`
+	Voto         string    `json:"voto"` // Sim, Nao, Abstencao, Obstrucao, NCom, Licenca, Missao
 
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
 	// Campos populados via Join (read-only)
-	SenadorNome    string `gorm:"->" json:"senador_nome,omitempty"`
-	SenadorPartido string `gorm:"->" json:"senador_partido,omitempty"`
-	SenadorUF      string `gorm:"->" json:"senador_uf,omitempty"`
-	SenadorFoto    string `gorm:"->" json:"senador_foto,omitempty"`
+	DescricaoVotacao string `gorm:"->;-:migration" json:"descricao_votacao,omitempty"` // votacao_sessoes
+	Materia          string `gorm:"->;-:migration" json:"materia,omitempty"`           // votacao_sessoes
+	SenadorNome      string `gorm:"->" json:"senador_nome,omitempty"`
+	SenadorPartido   string `gorm:"->" json:"senador_partido,omitempty"` // Partido na data da votacao
+	SenadorUF        string `gorm:"->" json:"senador_uf,omitempty"`
+	SenadorFoto      string `gorm:"->" json:"senador_foto,omitempty"`
 }
 
 // TableName define o nome da tabela
@@ -28,15 +32,208 @@ func (Votacao) TableName() string {
 	return "votacoes"
 }
 
+// Sessao representa uma votacao nominal do plenario (uma linha por sessao_id).
+// Materia, descricao e resultado ficam aqui em vez de repetidos em cada voto.
+type Sessao struct {
+	ID               int       `gorm:"primaryKey" json:"id"`
+	SessaoID         string    `gorm:"uniqueIndex;not null" json:"sessao_id"` // "CODIGO_ANO"
+	CodigoSessao     string    `json:"codigo_sessao"`
+	Data             time.Time `gorm:"index" json:"data"`
+	DescricaoVotacao string    `json:"descricao_votacao,omitempty"`
+	Materia          string    `gorm:"index" json:"materia,omitempty"` // Ex: "PEC 10/2024"
+	Secreta          bool      `json:"secreta"`
+	TotalSim         int       `json:"total_sim"` // Totais oficiais; unica fonte do placar em votacao secreta
+	TotalNao         int       `json:"total_nao"`
+	Resultado        string    `json:"resultado,omitempty"` // Aprovada, Rejeitada
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName define o nome da tabela
+func (Sessao) TableName() string {
+	return "votacao_sessoes"
+}
+
+// Resultados possiveis de uma votacao
+const (
+	ResultadoAprovada  = "Aprovada"
+	ResultadoRejeitada = "Rejeitada"
+)
+
+// Quorum minimo de votos Sim no Senado (81 cadeiras)
+const (
+	QuorumPEC = 49 // 3/5 dos membros
+	QuorumPLP = 41 // Maioria absoluta
+)
+
+// ResultadoPorPlacar deduz o resultado quando a API nao o informa.
+// PEC exige 3/5 dos senadores, PLP maioria absoluta e as demais materias maioria simples.
+func ResultadoPorPlacar(materia string, sim, nao int) string {
+	if sim+nao == 0 {
+		return ""
+	}
+	sigla, _, _ := strings.Cut(strings.TrimSpace(materia), " ")
+	aprovada := sim > nao
+	switch strings.ToUpper(sigla) {
+	case "PEC":
+		aprovada = sim >= QuorumPEC
+	case "PLP":
+		aprovada = sim >= QuorumPLP
+	}
+	if aprovada {
+		return ResultadoAprovada
+	}
+	return ResultadoRejeitada
+}
+
+// Placar consolida os votos de uma sessao, no total ou por grupo (partido, UF)
+type Placar struct {
+	Grupo     string `json:"grupo,omitempty"`
+	Sim       int    `json:"sim"`
+	Nao       int    `json:"nao"`
+	Abstencao int    `json:"abstencao"`
+	Obstrucao int    `json:"obstrucao"`
+	Ausentes  int    `json:"ausentes"` // NCom, licencas, missoes e demais registros sem voto
+	Total     int    `json:"total"`
+}
+
+// NovoPlacar consolida uma lista de votos
+func NovoPlacar(votos []Votacao) Placar {
+	var p Placar
+	for _, v := range votos {
+		p.contar(v.Voto)
+	}
+	return p
+}
+
+// PlacarPorGrupo consolida os votos agrupados por chave. Grupos sao ordenados
+// pelo numero de votos e, em empate, pelo nome.
+func PlacarPorGrupo(votos []Votacao, chave func(Votacao) string) []Placar {
+	grupos := make(map[string]*Placar)
+	for _, v := range votos {
+		g := chave(v)
+		p, ok := grupos[g]
+		if !ok {
+			p = &Placar{Grupo: g}
+			grupos[g] = p
+		}
+		p.contar(v.Voto)
+	}
+
+	placares := make([]Placar, 0, len(grupos))
+	for _, p := range grupos {
+		placares = append(placares, *p)
+	}
+	sort.Slice(placares, func(i, j int) bool {
+		if placares[i].Total != placares[j].Total {
+			return placares[i].Total > placares[j].Total
+		}
+		return placares[i].Grupo < placares[j].Grupo
+	})
+	return placares
+}
+
+// PorPartido agrupa pelo partido do senador na data da votacao
+func PorPartido(v Votacao) string {
+	if v.SenadorPartido == "" {
+		return "S/Partido"
+	}
+	return v.SenadorPartido
+}
+
+// PorUF agrupa pela UF do senador
+func PorUF(v Votacao) string {
+	if v.SenadorUF == "" {
+		return "N/D"
+	}
+	return v.SenadorUF
+}
+
+// ResumoSessao e o placar consolidado de uma sessao, no total e por partido e UF
+type ResumoSessao struct {
+	Resultado  string
+	Placar     Placar
+	PorPartido []Placar
+	PorUF      []Placar
+}
+
+// ResumirSessao consolida os votos de uma sessao. Em votacao secreta os votos individuais
+// vem apenas como presenca: vale o placar oficial da sessao e nao ha consolidacao por grupo.
+// Sem resultado informado pela API, ele e deduzido do placar.
+func ResumirSessao(sessao Sessao, votos []Votacao) ResumoSessao {
+	r := ResumoSessao{
+		Resultado:  sessao.Resultado,
+		Placar:     NovoPlacar(votos),
+		PorPartido: PlacarPorGrupo(votos, PorPartido),
+		PorUF:      PlacarPorGrupo(votos, PorUF),
+	}
+	if sessao.Secreta {
+		r.Placar.Sim, r.Placar.Nao = sessao.TotalSim, sessao.TotalNao
+		r.PorPartido, r.PorUF = []Placar{}, []Placar{}
+	}
+	if r.Resultado == "" {
+		r.Resultado = ResultadoPorPlacar(sessao.Materia, r.Placar.Sim, r.Placar.Nao)
+	}
+	return r
+}
+
+func (p *Placar) contar(voto string) {
+	p.Total++
+	switch voto {
+	case "Sim":
+		p.Sim++
+	case "Nao":
+		p.Nao++
+	case "Abstencao":
+		p.Abstencao++
+	case "Obstrucao":
+		p.Obstrucao++
+	case VotoSecreto:
+		// Presente; o sentido vem dos totais oficiais da sessao
+	default:
+		p.Ausentes++
+	}
+}
+
 // VotacaoStats representa estatisticas de votacao de um senador
 type VotacaoStats struct {
-	SenadorID           int     `json:"senador_id"`
-	TotalVotacoes       int     `json:"total_votacoes"`
-	VotosRegistrados    int     `json:"votos_registrados"`    // Sim + Nao + Abstencao
-	Ausencias           int     `json:"ausencias"`            // NCom (Nao Compareceu)
-	Obstrucoes          int     `json:"obstrucoes"`
-	TaxaPresenca        float64 `json:"taxa_presenca"`        // 0-100
-	TaxaParticipacao    float64 `json:"taxa_participacao"`    // Votos efetivos / Total
+	SenadorID                int     `json:"senador_id"`
+	TotalVotacoes            int     `json:"total_votacoes"`
+	VotosRegistrados         int     `json:"votos_registrados"`          // Sim + Nao + Abstencao
+	VotosSecretos            int     `json:"votos_secretos"`             // Presenca em votacao secreta (sem sentido do voto)
+	Ausencias                int     `json:"ausencias"`                  // Justificadas + nao justificadas
+	AusenciasJustificadas    int     `json:"ausencias_justificadas"`     // Licenca/Missao ou NCom durante afastamento oficial
+	AusenciasNaoJustificadas int     `json:"ausencias_nao_justificadas"` // NCom fora de afastamento oficial
+	Obstrucoes               int     `json:"obstrucoes"`
+	TaxaPresenca             float64 `json:"taxa_presenca"`       // 0-100, desconsidera ausencias justificadas
+	TaxaPresencaBruta        float64 `json:"taxa_presenca_bruta"` // 0-100, conta todas as ausencias
+	TaxaParticipacao         float64 `json:"taxa_participacao"`   // Votos efetivos / Total
+}
+
+// Votos normalizados que representam afastamento oficial registrado na propria sessao
+const (
+	VotoLicenca = "Licenca"
+	VotoMissao  = "Missao"
+	VotoNCom    = "NCom"
+	VotoSecreto = "Votou" // Presenca em votacao secreta, sem o sentido do voto
+)
+
+// calcularTaxas preenche as taxas de presenca e participacao a partir das contagens.
+// A presenca e calculada sobre as sessoes em que o senador deveria estar:
+// registrados + votos secretos + obstrucoes + ausencias nao justificadas.
+func (s *VotacaoStats) calcularTaxas() {
+	s.Ausencias = s.AusenciasJustificadas + s.AusenciasNaoJustificadas
+	votou := s.VotosRegistrados + s.VotosSecretos
+	presentes := votou + s.Obstrucoes
+
+	if base := presentes + s.AusenciasNaoJustificadas; base > 0 {
+		s.TaxaPresenca = float64(presentes) / float64(base) * 100
+		s.TaxaParticipacao = float64(votou) / float64(base) * 100
+	}
+	if base := presentes + s.Ausencias; base > 0 {
+		s.TaxaPresencaBruta = float64(presentes) / float64(base) * 100
+	}
 }
 
 // VotosPorTipo representa contagem de votos por tipo
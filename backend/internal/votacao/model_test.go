@@ -0,0 +1,152 @@
+package votacao
+
+import "testing"
+
+func TestCalcularTaxasDesconsideraAusenciasJustificadas(t *testing.T) {
+	stats := VotacaoStats{
+		VotosRegistrados:         70,
+		Obstrucoes:               5,
+		AusenciasJustificadas:    20, // licenca saude
+		AusenciasNaoJustificadas: 5,
+	}
+	stats.calcularTaxas()
+
+	if stats.Ausencias != 25 {
+		t.Errorf("ausencias esperadas 25, obtido %d", stats.Ausencias)
+	}
+	if stats.TaxaPresenca != 75.0/80.0*100 {
+		t.Errorf("taxa de presenca esperada %.2f, obtido %.2f", 75.0/80.0*100, stats.TaxaPresenca)
+	}
+	if stats.TaxaPresencaBruta != 75 {
+		t.Errorf("taxa bruta esperada 75, obtido %.2f", stats.TaxaPresencaBruta)
+	}
+	if stats.TaxaParticipacao != 70.0/80.0*100 {
+		t.Errorf("taxa de participacao esperada %.2f, obtido %.2f", 70.0/80.0*100, stats.TaxaParticipacao)
+	}
+}
+
+func TestCalcularTaxasSomenteLicenca(t *testing.T) {
+	stats := VotacaoStats{AusenciasJustificadas: 10}
+	stats.calcularTaxas()
+
+	if stats.TaxaPresenca != 0 || stats.TaxaPresencaBruta != 0 {
+		t.Errorf("sem sessoes obrigatorias as taxas devem ser zero: %+v", stats)
+	}
+}
+
+func TestCalcularTaxasVotoSecretoContaPresenca(t *testing.T) {
+	stats := VotacaoStats{VotosRegistrados: 6, VotosSecretos: 2, AusenciasNaoJustificadas: 2}
+	stats.TotalVotacoes = stats.VotosRegistrados + stats.VotosSecretos + stats.AusenciasNaoJustificadas
+	stats.calcularTaxas()
+
+	if stats.TaxaPresenca != 80 || stats.TaxaPresencaBruta != 80 || stats.TaxaParticipacao != 80 {
+		t.Errorf("votos secretos devem contar como presenca e participacao: %+v", stats)
+	}
+
+	// Presente em todas as sessoes, todas secretas
+	stats = VotacaoStats{VotosSecretos: 5}
+	stats.calcularTaxas()
+	if stats.TaxaPresenca != 100 || stats.TaxaParticipacao != 100 {
+		t.Errorf("presenca em todas as votacoes secretas deveria ser 100: %+v", stats)
+	}
+}
+
+func TestNormalizeVotoAfastamentos(t *testing.T) {
+	casos := map[string]string{"Lsp": VotoLicenca, "LS": VotoLicenca, "LP": VotoLicenca, "MIS": VotoMissao, "NCom": VotoNCom}
+	for entrada, esperado := range casos {
+		if got := normalizeVoto(entrada); got != esperado {
+			t.Errorf("normalizeVoto(%q) = %q, esperado %q", entrada, got, esperado)
+		}
+	}
+}
+
+func TestPlacarPorGrupo(t *testing.T) {
+	votos := []Votacao{
+		{SenadorPartido: "PT", SenadorUF: "BA", Voto: "Sim"},
+		{SenadorPartido: "PT", SenadorUF: "SP", Voto: "Sim"},
+		{SenadorPartido: "PT", SenadorUF: "BA", Voto: VotoNCom},
+		{SenadorPartido: "PL", SenadorUF: "SP", Voto: "Nao"},
+		{SenadorPartido: "PL", SenadorUF: "RJ", Voto: "Obstrucao"},
+		{SenadorPartido: "MDB", SenadorUF: "RJ", Voto: "Abstencao"},
+		{SenadorPartido: "", Voto: VotoLicenca},
+	}
+
+	geral := NovoPlacar(votos)
+	if geral.Total != 7 || geral.Sim != 2 || geral.Nao != 1 || geral.Ausentes != 2 {
+		t.Errorf("placar geral inesperado: %+v", geral)
+	}
+
+	partidos := PlacarPorGrupo(votos, PorPartido)
+	if len(partidos) != 4 {
+		t.Fatalf("partidos = %d; esperado 4", len(partidos))
+	}
+	if partidos[0].Grupo != "PT" || partidos[0].Sim != 2 || partidos[0].Ausentes != 1 {
+		t.Errorf("maior bancada inesperada: %+v", partidos[0])
+	}
+	// Empate em tamanho ordena pelo nome do grupo
+	if partidos[2].Grupo != "MDB" || partidos[3].Grupo != "S/Partido" {
+		t.Errorf("ordem de desempate inesperada: %s, %s", partidos[2].Grupo, partidos[3].Grupo)
+	}
+
+	ufs := PlacarPorGrupo(votos, PorUF)
+	if ufs[0].Grupo != "BA" || ufs[0].Total != 2 || ufs[len(ufs)-1].Grupo != "N/D" {
+		t.Errorf("consolidacao por UF inesperada: %+v", ufs)
+	}
+}
+
+func TestPlacarVotoSecretoNaoContaAusencia(t *testing.T) {
+	placar := NovoPlacar([]Votacao{{Voto: VotoSecreto}, {Voto: VotoSecreto}, {Voto: VotoNCom}})
+	if placar.Total != 3 || placar.Ausentes != 1 {
+		t.Errorf("placar inesperado: %+v", placar)
+	}
+}
+
+func TestResultadoPorPlacar(t *testing.T) {
+	testes := []struct {
+		materia  string
+		sim, nao int
+		esperado string
+	}{
+		{"PL 2338/2023", 40, 30, ResultadoAprovada},
+		{"PL 2338/2023", 30, 30, ResultadoRejeitada},
+		{"PEC 10/2024", 48, 10, ResultadoRejeitada},
+		{"PEC 10/2024", 49, 20, ResultadoAprovada},
+		{"PLP 68/2024", 40, 5, ResultadoRejeitada},
+		{"PLP 68/2024", 41, 30, ResultadoAprovada},
+		{"", 0, 0, ""},
+	}
+	for _, tt := range testes {
+		if got := ResultadoPorPlacar(tt.materia, tt.sim, tt.nao); got != tt.esperado {
+			t.Errorf("%s %d x %d: resultado = %q; esperado %q", tt.materia, tt.sim, tt.nao, got, tt.esperado)
+		}
+	}
+}
+
+func TestResumirSessao(t *testing.T) {
+	votos := []Votacao{
+		{SenadorPartido: "PT", SenadorUF: "BA", Voto: "Sim"},
+		{SenadorPartido: "PL", SenadorUF: "SP", Voto: "Nao"},
+		{SenadorPartido: "PL", SenadorUF: "SP", Voto: "Sim"},
+	}
+
+	casos := []struct {
+		nome      string
+		sessao    Sessao
+		sim, nao  int
+		grupos    bool
+		resultado string
+	}{
+		{"aberta deduz resultado", Sessao{Materia: "PL 1/2025"}, 2, 1, true, ResultadoAprovada},
+		{"aberta com resultado da API", Sessao{Materia: "PL 1/2025", Resultado: ResultadoRejeitada}, 2, 1, true, ResultadoRejeitada},
+		{"secreta usa placar oficial", Sessao{Materia: "PEC 3/2025", Secreta: true, TotalSim: 50, TotalNao: 10}, 50, 10, false, ResultadoAprovada},
+	}
+	for _, c := range casos {
+		r := ResumirSessao(c.sessao, votos)
+		if r.Placar.Sim != c.sim || r.Placar.Nao != c.nao || r.Resultado != c.resultado {
+			t.Errorf("%s: placar %d x %d resultado %q; esperado %d x %d %q", c.nome, r.Placar.Sim, r.Placar.Nao, r.Resultado, c.sim, c.nao, c.resultado)
+		}
+		if temGrupos := len(r.PorPartido) > 0 && len(r.PorUF) > 0; temGrupos != c.grupos || r.PorPartido == nil || r.PorUF == nil {
+			t.Errorf("%s: grupos inesperados: partidos %v UFs %v", c.nome, r.PorPartido, r.PorUF)
+		}
+	}
+}
@@ -2,8 +2,14 @@ package votacao
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
+
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"github.com/Alzarus/to-de-olho/internal/licenca"
+	"github.com/Alzarus/to-de-olho/internal/senador"
 	"github.com/Alzarus/to-de-olho/internal/utils"
 )
 
@@ -22,29 +28,32 @@ func (r *Repository) FindBySenadorID(senadorID int, limit, offset int, votoType
 	var votacoes []Votacao
 	var total int64
 
-	query := r.db.Model(&Votacao{}).Where("senador_id = ?", senadorID)
+	query := r.db.Model(&Votacao{}).Where("votacoes.senador_id = ?", senadorID)
 
 	if ano > 0 {
-		query = query.Where("EXTRACT(YEAR FROM data) = ?", ano)
+		query = query.Where("EXTRACT(YEAR FROM votacoes.data) = ?", ano)
 	}
 
 	// Filtro por tipo de voto
 	if votoType != "" {
 		if votoType == "Outros" {
 			// Outros = tudo que NAO for Sim, Nao, Abstencao, Obstrucao
-			query = query.Where("voto NOT IN (?, ?, ?, ?)", "Sim", "Nao", "Abstencao", "Obstrucao")
+			query = query.Where("votacoes.voto NOT IN (?, ?, ?, ?)", "Sim", "Nao", "Abstencao", "Obstrucao")
 		} else {
-			query = query.Where("voto = ?", votoType)
+			query = query.Where("votacoes.voto = ?", votoType)
 		}
 	}
 
 	// Contar total (considerando filtros)
-	if err := query.Count(&total).Error; err != nil {
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	// Aplicar ordenacao e paginacao
-	result := query.Order("data DESC").
+	// Materia e descricao vem da sessao (colunas listadas para nao trazer as legadas de votacoes)
+	result := query.Select("votacoes.id, votacoes.senador_id, votacoes.sessao_id, votacoes.codigo_sessao, votacoes.data, " +
+		"votacoes.voto, votacoes.created_at, votacoes.updated_at, s.descricao_votacao, s.materia").
+		Joins("LEFT JOIN " + Sessao{}.TableName() + " s ON s.sessao_id = votacoes.sessao_id").
+		Order("votacoes.data DESC").
 		Limit(limit).
 		Offset(offset).
 		Find(&votacoes)
@@ -68,54 +77,51 @@ func (r *Repository) Count() (int64, error) {
 
 // GetStats retorna estatisticas de votacao de um senador restritas ao mandato (2023+)
 func (r *Repository) GetStats(senadorID int) (*VotacaoStats, error) {
-	var stats VotacaoStats
-	stats.SenadorID = senadorID
-
-	var total, registrados, ausencias, obstrucoes int64
-
-	mandatoFilter := fmt.Sprintf("senador_id = ? AND data >= '%d-01-01'", utils.GetInicioLegislaturaAtual())
-
-	// Total de votacoes
-	r.db.Model(&Votacao{}).Where(mandatoFilter, senadorID).Count(&total)
-	stats.TotalVotacoes = int(total)
-
-	// Votos registrados (Sim, Nao, Abstencao)
-	r.db.Model(&Votacao{}).Where(
-		mandatoFilter+" AND voto IN (?, ?, ?)", senadorID, "Sim", "Nao", "Abstencao",
-	).Count(&registrados)
-	stats.VotosRegistrados = int(registrados)
-
-	// Ausencias (NCom)
-	r.db.Model(&Votacao{}).Where(
-		mandatoFilter+" AND voto = ?", senadorID, "NCom",
-	).Count(&ausencias)
-	stats.Ausencias = int(ausencias)
-
-	// Obstrucoes
-	r.db.Model(&Votacao{}).Where(
-		mandatoFilter+" AND voto = ?", senadorID, "Obstrucao",
-	).Count(&obstrucoes)
-	stats.Obstrucoes = int(obstrucoes)
-
-	// Calcular taxas
-	if stats.TotalVotacoes > 0 {
-		// Presenca (calculada em cima dos que de fato ele devia estar: registrados + ausencias + obstrucoes)
-		// Ignorando fatores como Licenca, Missao, Presidencia do Senado (P-OD)
-		baseCalculoPresenca := stats.VotosRegistrados + stats.Ausencias + stats.Obstrucoes
-		
-		if baseCalculoPresenca > 0 {
-			stats.TaxaPresenca = float64(stats.VotosRegistrados+stats.Obstrucoes) / float64(baseCalculoPresenca) * 100
-		} else {
-			stats.TaxaPresenca = 0
-		}
+	return r.calcularStats(senadorID, fmt.Sprintf("%d-01-01", utils.GetInicioLegislaturaAtual()), "")
+}
 
-		// Participacao = Votos efetivos (Sim, Nao, Abstencao) / Total real baseCalculada
-		if baseCalculoPresenca > 0 {
-			stats.TaxaParticipacao = float64(stats.VotosRegistrados) / float64(baseCalculoPresenca) * 100
-		} else {
-			stats.TaxaParticipacao = 0
-		}
+// coberturaLicenca verifica se a data da votacao esta dentro de um afastamento oficial do senador
+var coberturaLicenca = fmt.Sprintf(`EXISTS (SELECT 1 FROM %s l WHERE l.senador_id = votacoes.senador_id
+	AND votacoes.data::date >= l.data_inicio::date
+	AND (l.data_fim IS NULL OR votacoes.data::date <= l.data_fim::date))`, licenca.Licenca{}.TableName())
+
+// calcularStats conta votos por categoria no periodo [inicio, fim) e calcula as taxas.
+// NCom durante licenca ou missao oficial conta como ausencia justificada.
+func (r *Repository) calcularStats(senadorID int, inicio, fim string) (*VotacaoStats, error) {
+	stats := VotacaoStats{SenadorID: senadorID}
+
+	query := r.db.Model(&Votacao{}).Where("senador_id = ? AND data >= ?", senadorID, inicio)
+	if fim != "" {
+		query = query.Where("data < ?", fim)
+	}
+
+	var contagem struct {
+		Total           int
+		Registrados     int
+		Secretos        int
+		Obstrucoes      int
+		Justificadas    int
+		NaoJustificadas int
 	}
+	err := query.Select(`COUNT(*) as total,
+			COUNT(*) FILTER (WHERE voto IN ('Sim', 'Nao', 'Abstencao')) as registrados,
+			COUNT(*) FILTER (WHERE voto = ?) as secretos,
+			COUNT(*) FILTER (WHERE voto = 'Obstrucao') as obstrucoes,
+			COUNT(*) FILTER (WHERE voto IN (?, ?) OR (voto = ? AND `+coberturaLicenca+`)) as justificadas,
+			COUNT(*) FILTER (WHERE voto = ? AND NOT `+coberturaLicenca+`) as nao_justificadas`,
+		VotoSecreto, VotoLicenca, VotoMissao, VotoNCom, VotoNCom).
+		Scan(&contagem).Error
+	if err != nil {
+		return nil, err
+	}
+
+	stats.TotalVotacoes = contagem.Total
+	stats.VotosRegistrados = contagem.Registrados
+	stats.VotosSecretos = contagem.Secretos
+	stats.Obstrucoes = contagem.Obstrucoes
+	stats.AusenciasJustificadas = contagem.Justificadas
+	stats.AusenciasNaoJustificadas = contagem.NaoJustificadas
+	stats.calcularTaxas()
 
 	return &stats, nil
 }
@@ -138,9 +144,34 @@ func (r *Repository) Upsert(votacao *Votacao) error {
 		Assign(*votacao).FirstOrCreate(votacao).Error
 }
 
-// UpdateMetadata atualiza metadados de uma sessao de votacao
-func (r *Repository) UpdateMetadata(sessaoID string, updates map[string]interface{}) error {
-	return r.db.Model(&Votacao{}).Where("sessao_id = ?", sessaoID).Updates(updates).Error
+// UpsertSessoes insere ou atualiza os dados das sessoes de votacao (chave sessao_id)
+func (r *Repository) UpsertSessoes(sessoes []Sessao) error {
+	if len(sessoes) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "sessao_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"codigo_sessao", "data", "descricao_votacao", "materia",
+			"secreta", "total_sim", "total_nao", "resultado", "updated_at"}),
+	}).CreateInBatches(sessoes, 200).Error
+}
+
+// MigrarSessoesLegadas copia materia e descricao, antes repetidas em cada voto, para
+// votacao_sessoes. As colunas antigas de votacoes sao mantidas (somente leitura no modelo)
+// e apenas sessoes ainda ausentes sao copiadas, entao a migracao e idempotente e barata
+// depois da primeira execucao.
+func (r *Repository) MigrarSessoesLegadas() (int64, error) {
+	if !r.db.Migrator().HasColumn(&Votacao{}, "materia") {
+		return 0, nil
+	}
+
+	result := r.db.Exec(`INSERT INTO votacao_sessoes (sessao_id, codigo_sessao, data, descricao_votacao, materia, created_at, updated_at)
+		SELECT DISTINCT ON (v.sessao_id) v.sessao_id, v.codigo_sessao, v.data, COALESCE(v.descricao_votacao, ''), COALESCE(v.materia, ''), NOW(), NOW()
+		FROM votacoes v
+		WHERE NOT EXISTS (SELECT 1 FROM votacao_sessoes s WHERE s.sessao_id = v.sessao_id)
+		ORDER BY v.sessao_id, v.data DESC
+		ON CONFLICT (sessao_id) DO NOTHING`)
+	return result.RowsAffected, result.Error
 }
 
 // UpdateVoteBatch atualiza o tipo de voto em massa (para normalizacao)
@@ -148,129 +179,83 @@ func (r *Repository) UpdateVoteBatch(oldVoto, newVoto string) error {
 	return r.db.Model(&Votacao{}).Where("voto = ?", oldVoto).Update("voto", newVoto).Error
 }
 
-// UpsertBatch insere ou atualiza multiplas votacoes
+// UpsertBatch insere ou atualiza votos usando a chave (senador_id, sessao_id)
 func (r *Repository) UpsertBatch(votacoes []Votacao) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
-		for _, v := range votacoes {
-			if err := tx.Save(&v).Error; err != nil {
-				return err
-			}
-		}
+	if len(votacoes) == 0 {
 		return nil
-	})
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "senador_id"}, {Name: "sessao_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"codigo_sessao", "data", "voto", "updated_at"}),
+	}).CreateInBatches(votacoes, 500).Error
 }
 
 // GetStatsByAno retorna estatisticas de votacao filtradas por ano
 func (r *Repository) GetStatsByAno(senadorID int, ano int) (*VotacaoStats, error) {
-	var stats VotacaoStats
-	stats.SenadorID = senadorID
-
-	var total, registrados, ausencias, obstrucoes int64
-
-	// Filtro de data: inicio do ano e inicio do proximo ano
-	dataInicio := fmt.Sprintf("%d-01-01", ano)
-	dataProximoAno := fmt.Sprintf("%d-01-01", ano+1)
-	dateFilter := "data >= ? AND data < ?"
-
-	// Total de votacoes
-	r.db.Debug().Model(&Votacao{}).Where("senador_id = ? AND "+dateFilter, senadorID, dataInicio, dataProximoAno).Count(&total)
-	stats.TotalVotacoes = int(total)
-
-	// Votos registrados
-	r.db.Model(&Votacao{}).Where(
-		"senador_id = ? AND voto IN (?, ?, ?) AND "+dateFilter,
-		senadorID, "Sim", "Nao", "Abstencao", dataInicio, dataProximoAno,
-	).Count(&registrados)
-	stats.VotosRegistrados = int(registrados)
-
-	// Ausencias
-	r.db.Model(&Votacao{}).Where(
-		"senador_id = ? AND voto = ? AND "+dateFilter,
-		senadorID, "NCom", dataInicio, dataProximoAno,
-	).Count(&ausencias)
-	stats.Ausencias = int(ausencias)
-
-	// Obstrucoes
-	r.db.Model(&Votacao{}).Where(
-		"senador_id = ? AND voto = ? AND "+dateFilter,
-		senadorID, "Obstrucao", dataInicio, dataProximoAno,
-	).Count(&obstrucoes)
-	stats.Obstrucoes = int(obstrucoes)
-
-	// Calcular taxas
-	if stats.TotalVotacoes > 0 {
-		baseCalculoPresenca := stats.VotosRegistrados + stats.Ausencias + stats.Obstrucoes
-
-		if baseCalculoPresenca > 0 {
-			stats.TaxaPresenca = float64(stats.VotosRegistrados+stats.Obstrucoes) / float64(baseCalculoPresenca) * 100
-			stats.TaxaParticipacao = float64(stats.VotosRegistrados) / float64(baseCalculoPresenca) * 100
-		} else {
-			stats.TaxaPresenca = 0
-			stats.TaxaParticipacao = 0
-		}
-	}
-
-	return &stats, nil
+	return r.calcularStats(senadorID, fmt.Sprintf("%d-01-01", ano), fmt.Sprintf("%d-01-01", ano+1))
 }
 
-// FindAll retorna votacoes com paginacao e filtros (ordem: "asc" ou "desc")
-func (r *Repository) FindAll(limit, offset, ano int, materia, ordem string) ([]Votacao, int64, error) {
-	var votacoes []Votacao
+// FindAll retorna sessoes de votacao com paginacao e filtros (ordem: "asc" ou "desc")
+func (r *Repository) FindAll(limit, offset, ano int, materia, ordem string) ([]Sessao, int64, error) {
+	var sessoes []Sessao
 	var total int64
 
-	// Base query com filtros para count e subquery
-	baseQuery := r.db.Model(&Votacao{})
+	query := r.db.Model(&Sessao{})
 
 	if ano > 0 {
-		baseQuery = baseQuery.Where("EXTRACT(YEAR FROM data) = ?", ano)
+		query = query.Where("EXTRACT(YEAR FROM data) = ?", ano)
 	}
 
 	if materia != "" {
 		like := "%" + materia + "%"
-		baseQuery = baseQuery.Where("materia ILIKE ? OR descricao_votacao ILIKE ? OR codigo_sessao ILIKE ?", like, like, like)
+		query = query.Where("materia ILIKE ? OR descricao_votacao ILIKE ? OR codigo_sessao ILIKE ?", like, like, like)
 	}
 
-	// Contar total de sessoes unicas
-	if err := baseQuery.Session(&gorm.Session{}).Select("COUNT(DISTINCT sessao_id)").Count(&total).Error; err != nil {
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	// Subquery para obter sessoes unicas
-	// Nota: DISTINCT ON requer que o ORDER BY comece com a coluna distinct
-	subQuery := baseQuery.Session(&gorm.Session{}).
-		Select("DISTINCT ON (sessao_id) *").
-		Order("sessao_id, data DESC")
-
-	// Query principal ordenando o resultado da subquery pela data
 	sortOrder := "data DESC"
 	if ordem == "asc" {
 		sortOrder = "data ASC"
 	}
 
-	err := r.db.Table("(?) as v", subQuery).
-		Order(sortOrder).
+	err := query.Order(sortOrder).
 		Limit(limit).
 		Offset(offset).
-		Find(&votacoes).Error
+		Find(&sessoes).Error
 
-	return votacoes, total, err
+	return sessoes, total, err
 }
 
-// FindByID retorna uma votacao pelo ID (sessao_id)
-func (r *Repository) FindByID(id string) (*Votacao, error) {
-	var votacao Votacao
-	err := r.db.Where("sessao_id = ?", id).First(&votacao).Error
+// FindByID retorna uma sessao de votacao pelo ID (sessao_id)
+func (r *Repository) FindByID(id string) (*Sessao, error) {
+	var sessao Sessao
+	err := r.db.Where("sessao_id = ?", id).First(&sessao).Error
 	if err != nil {
 		return nil, err
 	}
-	return &votacao, nil
+	return &sessao, nil
+}
+
+// FindSessoesPorMateria retorna as sessoes cuja materia corresponde a "SIGLA NUMERO/ANO".
+// A identificacao gravada pelo sync segue o formato "PEC 10/2024", eventualmente com zeros a esquerda.
+func (r *Repository) FindSessoesPorMateria(sigla, numero string, ano int) ([]Sessao, error) {
+	var sessoes []Sessao
+	padrao := fmt.Sprintf(`^%s\s+0*%s/%d$`, regexp.QuoteMeta(sigla), strings.TrimLeft(numero, "0"), ano)
+	err := r.db.Where("materia ~* ?", padrao).
+		Order("data ASC").
+		Find(&sessoes).Error
+	return sessoes, err
 }
 
 // FindVotosBySessaoID retorna todos os votos de uma sessao especifica
 func (r *Repository) FindVotosBySessaoID(sessaoID string) ([]Votacao, error) {
 	var votacoes []Votacao
-	err := r.db.Debug().Table("votacoes").
-		Select("votacoes.*, senadores.nome as senador_nome, senadores.partido as senador_partido, senadores.uf as senador_uf, senadores.foto_url as senador_foto").
+	err := r.db.Table("votacoes").
+		Select("votacoes.*, senadores.nome as senador_nome, "+
+			senador.PartidoNaDataSQL("votacoes.senador_id", "votacoes.data")+" as senador_partido, "+
+			"senadores.uf as senador_uf, senadores.foto_url as senador_foto").
 		Joins("JOIN senadores ON senadores.id = votacoes.senador_id").
 		Where("votacoes.sessao_id = ?", sessaoID).
 		Order("senadores.nome ASC").
@@ -279,6 +264,74 @@ func (r *Repository) FindVotosBySessaoID(sessaoID string) ([]Votacao, error) {
 	return votacoes, err
 }
 
+// ReplaceOrientacoes substitui as orientacoes de bancada de uma sessao
+func (r *Repository) ReplaceOrientacoes(sessaoID string, orientacoes []Orientacao) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("sessao_id = ?", sessaoID).Delete(&Orientacao{}).Error; err != nil {
+			return err
+		}
+		if len(orientacoes) == 0 {
+			return nil
+		}
+		return tx.Create(&orientacoes).Error
+	})
+}
+
+// FindOrientacoes retorna as orientacoes de bancada de uma sessao
+func (r *Repository) FindOrientacoes(sessaoID string) ([]Orientacao, error) {
+	var orientacoes []Orientacao
+	err := r.db.Where("sessao_id = ?", sessaoID).Order("bancada").Find(&orientacoes).Error
+	return orientacoes, err
+}
+
+// GetAlinhamento calcula fidelidade partidaria e governismo de um senador. Com ano = 0
+// considera a legislatura atual. O partido de referencia e o vigente na data de cada votacao.
+func (r *Repository) GetAlinhamento(senadorID, ano int) (*Alinhamento, error) {
+	inicio := fmt.Sprintf("%d-01-01", utils.GetInicioLegislaturaAtual())
+	if ano > 0 {
+		inicio = fmt.Sprintf("%d-01-01", ano)
+	}
+
+	partido := senador.PartidoNaDataSQL("votacoes.senador_id", "votacoes.data")
+	query := r.db.Table("votacoes").
+		Joins("JOIN senadores ON senadores.id = votacoes.senador_id").
+		Joins("LEFT JOIN "+Orientacao{}.TableName()+" op ON op.sessao_id = votacoes.sessao_id AND op.orientacao IN ('Sim', 'Nao') AND op.bancada = "+partido).
+		Joins("LEFT JOIN "+Orientacao{}.TableName()+" og ON og.sessao_id = votacoes.sessao_id AND og.orientacao IN ('Sim', 'Nao') AND og.bancada = ?", BancadaGoverno).
+		Where("votacoes.senador_id = ? AND votacoes.voto IN ('Sim', 'Nao') AND votacoes.data >= ?", senadorID, inicio)
+	if ano > 0 {
+		query = query.Where("votacoes.data < ?", fmt.Sprintf("%d-01-01", ano+1))
+	}
+	votos := query.Select("votacoes.voto, " +
+		orientacaoSemProprioVotoSQL("op", partido) + " as orientacao_partido, " +
+		orientacaoSemProprioVotoSQL("og", partido) + " as orientacao_governo")
+
+	var contagem struct {
+		ComparadosPartido int
+		ComPartido        int
+		ComparadosGoverno int
+		ComGoverno        int
+	}
+	err := r.db.Table("(?) as v", votos).
+		Select(`COUNT(orientacao_partido) as comparados_partido,
+			COUNT(*) FILTER (WHERE voto = orientacao_partido) as com_partido,
+			COUNT(orientacao_governo) as comparados_governo,
+			COUNT(*) FILTER (WHERE voto = orientacao_governo) as com_governo`).
+		Scan(&contagem).Error
+	if err != nil {
+		return nil, err
+	}
+
+	alinhamento := Alinhamento{
+		SenadorID:              senadorID,
+		VotosComparadosPartido: contagem.ComparadosPartido,
+		VotosComPartido:        contagem.ComPartido,
+		VotosComparadosGoverno: contagem.ComparadosGoverno,
+		VotosComGoverno:        contagem.ComGoverno,
+	}
+	alinhamento.calcularIndices()
+	return &alinhamento, nil
+}
+
 // GetAllSessoesIDs retorna IDs de sessoes distintas para um ano
 func (r *Repository) GetAllSessoesIDs(ano int) ([]string, error) {
 	var ids []string
@@ -28,57 +28,148 @@ func NewSyncService(repo *Repository, senadorRepo *senador.Repository, client *s
 	}
 }
 
-// SyncFromAPI busca votacoes da API para todos os senadores
-func (s *SyncService) SyncFromAPI(ctx context.Context) error {
-	slog.Info("iniciando sync de votacoes")
+// SyncFromAPI importa todas as votacoes nominais de um ano partindo das sessoes:
+// a lista anual ja traz o voto de cada senador, gravado em lote (uma chamada por ano
+// em vez de uma por senador). Sessoes que vierem sem votos sao detalhadas via
+// ObterVotacao, apenas quando ainda nao estiverem no banco.
+func (s *SyncService) SyncFromAPI(ctx context.Context, ano int) error {
+	slog.Info("iniciando sync de votacoes", "ano", ano)
+
+	// Inclui ex-senadores para nao perder votos de quem deixou o cargo
+	senadores, err := s.senadorRepo.FindAll(true)
+	if err != nil {
+		return err
+	}
+	codigoToID := make(map[int]int, len(senadores))
+	for _, sen := range senadores {
+		codigoToID[sen.CodigoParlamentar] = sen.ID
+	}
 
-	// Buscar todos os senadores
-	senadores, err := s.senadorRepo.FindAll(false)
+	sessoesAPI, err := s.client.ListarVotacoesAno(ctx, ano)
 	if err != nil {
 		return err
 	}
+	sessoesAPI = deduplicarSessoes(sessoesAPI)
 
-	var totalVotacoes, totalSenadores int
+	importadas, err := s.repo.GetAllSessoesIDs(ano)
+	if err != nil {
+		return err
+	}
+	jaImportada := make(map[string]bool, len(importadas))
+	for _, id := range importadas {
+		jaImportada[id] = true
+	}
 
-	for _, sen := range senadores {
-		// A API retorna sessoes de votacao
-		sessoes, err := s.client.ListarVotacoesParlamentar(ctx, sen.CodigoParlamentar)
-		if err != nil {
-			slog.Warn("falha ao buscar votacoes", "senador", sen.Nome, "error", err)
-			continue
+	sessoes := make([]Sessao, 0, len(sessoesAPI))
+	var lote []Votacao
+	var totalVotos, detalhadas int
+	for _, sessao := range sessoesAPI {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
 		}
 
-		// Processar cada sessao
-		for _, sessao := range sessoes {
-			// Encontrar o voto do senador nesta sessao
-			var siglaVoto string
-			for _, voto := range sessao.Votos {
-				if voto.CodigoParlamentar == sen.CodigoParlamentar {
-					siglaVoto = voto.SiglaVoto
-					break
-				}
+		if len(sessao.Votos) == 0 && !jaImportada[sessaoID(sessao)] {
+			detalhe, err := s.client.ObterVotacao(ctx, strconv.Itoa(sessao.CodigoSessao))
+			if err != nil {
+				slog.Warn("falha ao detalhar sessao", "sessao", sessaoID(sessao), "error", err)
+			} else {
+				sessao.Votos = detalhe.Votos
+				detalhadas++
 			}
+		}
 
-			if siglaVoto == "" {
-				continue // Senador nao votou nesta sessao
-			}
+		sessoes = append(sessoes, convertSessao(sessao))
+		lote = append(lote, votosDaSessao(sessao, codigoToID)...)
 
-			votacao := s.convertSessaoToVotacao(sessao, sen.ID, siglaVoto)
-			if err := s.repo.Upsert(&votacao); err != nil {
-				slog.Warn("falha ao salvar votacao", "senador", sen.ID, "error", err)
-				continue
+		if len(lote) >= tamanhoLoteVotos {
+			if err := s.repo.UpsertBatch(lote); err != nil {
+				return err
 			}
-			totalVotacoes++
+			totalVotos += len(lote)
+			lote = lote[:0]
 		}
+	}
+
+	if err := s.repo.UpsertBatch(lote); err != nil {
+		return err
+	}
+	totalVotos += len(lote)
 
-		totalSenadores++
-		slog.Debug("votacoes sincronizadas", "senador", sen.Nome, "sessoes", len(sessoes))
+	if err := s.repo.UpsertSessoes(sessoes); err != nil {
+		return err
 	}
 
-	slog.Info("sync de votacoes concluido", "senadores", totalSenadores, "votacoes", totalVotacoes)
+	s.normalizeVotesBatch()
+	s.atualizarOrientacoes(sessoesAPI)
+
+	slog.Info("sync de votacoes concluido", "ano", ano, "sessoes", len(sessoes), "detalhadas", detalhadas, "votos", totalVotos)
 	return nil
 }
 
+// atualizarOrientacoes grava a orientacao de cada bancada nas sessoes nao secretas,
+// usando a lideranca quando a API a informa e a maioria da bancada nos demais casos
+func (s *SyncService) atualizarOrientacoes(sessoesAPI []senadoapi.VotacaoSessaoAPI) {
+	for _, sessao := range sessoesAPI {
+		dados := convertSessao(sessao)
+		if dados.Secreta {
+			continue
+		}
+		id := sessaoID(sessao)
+		votos, err := s.repo.FindVotosBySessaoID(id)
+		if err != nil {
+			slog.Warn("falha ao buscar votos para orientacao", "sessao", id, "error", err)
+			continue
+		}
+		orientacoes := DefinirOrientacoes(id, dados.Data, convertOrientacoes(sessao.OrientacoesLideranca), votos)
+		if err := s.repo.ReplaceOrientacoes(id, orientacoes); err != nil {
+			slog.Warn("falha ao salvar orientacoes", "sessao", id, "error", err)
+		}
+	}
+}
+
+// convertOrientacoes converte as orientacoes de lideranca da API
+func convertOrientacoes(orientacoesAPI []senadoapi.OrientacaoLiderancaAPI) []Orientacao {
+	orientacoes := make([]Orientacao, 0, len(orientacoesAPI))
+	for _, o := range orientacoesAPI {
+		bancada := normalizeBancada(o.Partido)
+		voto := normalizeVoto(o.Voto)
+		if bancada == "" || voto == "" {
+			continue
+		}
+		if voto != "Sim" && voto != "Nao" {
+			voto = OrientacaoLiberado
+		}
+		orientacoes = append(orientacoes, Orientacao{Bancada: bancada, Orientacao: voto})
+	}
+	return orientacoes
+}
+
+// tamanhoLoteVotos limita quantos votos ficam em memoria antes de gravar
+const tamanhoLoteVotos = 5000
+
+// votosDaSessao converte os votos de uma sessao, ignorando parlamentares que nao estao no banco
+func votosDaSessao(sessao senadoapi.VotacaoSessaoAPI, codigoToID map[int]int) []Votacao {
+	votos := make([]Votacao, 0, len(sessao.Votos))
+	vistos := make(map[int]bool, len(sessao.Votos))
+	for _, voto := range sessao.Votos {
+		senadorID, ok := codigoToID[voto.CodigoParlamentar]
+		if !ok || voto.SiglaVoto == "" || vistos[senadorID] {
+			continue
+		}
+		vistos[senadorID] = true
+		votos = append(votos, Votacao{
+			SenadorID:    senadorID,
+			SessaoID:     sessaoID(sessao),
+			CodigoSessao: strconv.Itoa(sessao.CodigoSessao),
+			Data:         parseDataSessao(sessao),
+			Voto:         normalizeVoto(voto.SiglaVoto),
+		})
+	}
+	return votos
+}
+
 // SyncSenador busca votacoes de um senador especifico
 func (s *SyncService) SyncSenador(ctx context.Context, senadorID int) (int, error) {
 	sen, err := s.senadorRepo.FindByID(senadorID)
@@ -94,6 +185,7 @@ func (s *SyncService) SyncSenador(ctx context.Context, senadorID int) (int, erro
 	anoAtual := time.Now().Year()
 
 	var count int
+	sessoesVistas := make(map[string]Sessao)
 	for _, sessao := range sessoes {
 		// [PERFORMANCE] Evitar carregar sessoes antigas no sync diario/atualizacoes
 		if sessao.Ano < anoAtual-2 {
@@ -116,76 +208,15 @@ func (s *SyncService) SyncSenador(ctx context.Context, senadorID int) (int, erro
 		if err := s.repo.Upsert(&votacao); err != nil {
 			continue
 		}
+		sessoesVistas[votacao.SessaoID] = convertSessao(sessao)
 		count++
 	}
 
-	return count, nil
-}
-
-// SyncMetadata busca dados ricos (Datas, Ementas) da lista master e atualiza o banco
-func (s *SyncService) SyncMetadata(ctx context.Context, ano int) error {
-	slog.Info("iniciando sync de metadados (batch)", "ano", ano)
-
-	votacoesMaster, err := s.client.ListarVotacoesAno(ctx, ano)
-	if err != nil {
-		return err
-	}
-	slog.Info("sessoes encontradas na API", "total", len(votacoesMaster))
-
-	count := 0
-	for _, vMaster := range votacoesMaster {
-		// Construir ID da Sessao (ex: "12345_2024") to match existing records
-		sessaoID := strconv.Itoa(vMaster.CodigoSessao) + "_" + strconv.Itoa(vMaster.Ano)
-
-	// Parse Date + Time
-		var dataFinal time.Time
-		
-		// Try ISO 8601 first (API return for 2024 List)
-		if t, err := time.Parse("2006-01-02T15:04:05", vMaster.DataSessao); err == nil {
-			dataFinal = t
-		} else if t, err := time.Parse("2006-01-02", vMaster.DataSessao); err == nil {
-			// Fallback to noon UTC
-			dataFinal = time.Date(t.Year(), t.Month(), t.Day(), 12, 0, 0, 0, time.UTC)
-		}
-
-		// Rich Description: prioritize ementa, fallback to identificacaoMateria
-		materia := ""
-		if vMaster.IdentificacaoMateria != "" {
-			materia = vMaster.IdentificacaoMateria
-		} else if vMaster.Materia.Sigla != "" {
-			materia = fmt.Sprintf("%s %s/%s", vMaster.Materia.Sigla, vMaster.Materia.Numero, vMaster.Materia.Ano)
-		}
-		
-		descricao := vMaster.DescricaoVotacao
-		if vMaster.EmentaLegislativo != "" {
-			descricao = vMaster.EmentaLegislativo
-		}
-
-		// Update all records with this SessaoID
-		updates := map[string]interface{}{}
-		if !dataFinal.IsZero() {
-			updates["data"] = dataFinal
-		}
-		if materia != "" {
-			updates["materia"] = materia
-		}
-		if descricao != "" {
-			 updates["descricao_votacao"] = descricao
-		}
-
-		if len(updates) > 0 {
-			if err := s.repo.UpdateMetadata(sessaoID, updates); err != nil {
-				slog.Warn("falha ao atualizar metadata", "sessao", sessaoID, "err", err)
-			} else {
-				count++
-			}
-		}
+	if err := s.repo.UpsertSessoes(valoresSessoes(sessoesVistas)); err != nil {
+		return count, err
 	}
-	
-	s.normalizeVotesBatch()
 
-	slog.Info("sync metadata concluido", "sessoes_atualizadas", count)
-	return nil
+	return count, nil
 }
 
 func (s *SyncService) normalizeVotesBatch() {
@@ -194,8 +225,11 @@ func (s *SyncService) normalizeVotesBatch() {
 		"Sim":       "Sim", 
 		"Obstrução": "Obstrucao",
 		"P-OD":      "Obstrucao",
-		"MIS":       "Outros",
-		"Lsp":       "Licenca", 
+		"MIS":       VotoMissao,
+		"Outros":    VotoMissao, // versoes anteriores mapeavam MIS para Outros
+		"Lsp":       VotoLicenca,
+		"LS":        VotoLicenca,
+		"LP":        VotoLicenca,
 		"Abstenção": "Abstencao",
 	}
 	
@@ -207,50 +241,115 @@ func (s *SyncService) normalizeVotesBatch() {
 }
 
 
-// convertSessaoToVotacao converte uma sessao de votacao para modelo interno
+// convertSessaoToVotacao converte uma sessao de votacao para o voto de um senador
 func (s *SyncService) convertSessaoToVotacao(sessao senadoapi.VotacaoSessaoAPI, senadorID int, siglaVoto string) Votacao {
-	var data time.Time
-	var parsed bool
-
-	if sessao.DataSessao != "" {
-		// Formato: YYYY-MM-DD ou DD/MM/YYYY
-		if t, err := time.Parse("2006-01-02", sessao.DataSessao); err == nil {
-			// Definir meio-dia para evitar problemas de fuso horario
-			data = time.Date(t.Year(), t.Month(), t.Day(), 12, 0, 0, 0, time.UTC)
-			parsed = true
-		} else if t, err := time.Parse("02/01/2006", sessao.DataSessao); err == nil {
-			data = time.Date(t.Year(), t.Month(), t.Day(), 12, 0, 0, 0, time.UTC)
-			parsed = true
+	return Votacao{
+		SenadorID:    senadorID,
+		SessaoID:     sessaoID(sessao),
+		CodigoSessao: strconv.Itoa(sessao.CodigoSessao),
+		Data:         parseDataSessao(sessao),
+		Voto:         normalizeVoto(siglaVoto),
+	}
+}
+
+// convertSessao extrai os dados da sessao (materia, descricao, placar oficial e resultado)
+func convertSessao(sessao senadoapi.VotacaoSessaoAPI) Sessao {
+	// Identificacao da materia: prioriza o campo pronto, senao monta "SIGLA NUMERO/ANO"
+	materia := sessao.IdentificacaoMateria
+	if materia == "" && sessao.Materia.Sigla != "" {
+		materia = fmt.Sprintf("%s %s/%s", sessao.Materia.Sigla, sessao.Materia.Numero, sessao.Materia.Ano)
+	}
+
+	// Descricao rica: prioriza a ementa
+	descricao := sessao.DescricaoVotacao
+	if sessao.EmentaLegislativo != "" {
+		descricao = sessao.EmentaLegislativo
+	}
+
+	resultado := normalizeResultado(sessao.ResultadoVotacao)
+	if resultado == "" {
+		resultado = ResultadoPorPlacar(materia, sessao.TotalVotosSim, sessao.TotalVotosNao)
+	}
+
+	return Sessao{
+		SessaoID:         sessaoID(sessao),
+		CodigoSessao:     strconv.Itoa(sessao.CodigoSessao),
+		Data:             parseDataSessao(sessao),
+		DescricaoVotacao: descricao,
+		Materia:          materia,
+		Secreta:          strings.EqualFold(sessao.VotacaoSecreta, "S") || strings.EqualFold(sessao.VotacaoSecreta, "Sim"),
+		TotalSim:         sessao.TotalVotosSim,
+		TotalNao:         sessao.TotalVotosNao,
+		Resultado:        resultado,
+	}
+}
+
+// deduplicarSessoes remove sessoes repetidas na lista anual (mesmo codigo de sessao
+// de votacao). Repetidas, elas gerariam votos duplicados no mesmo lote de upsert, o que
+// o ON CONFLICT do Postgres rejeita. Mantem a posicao da primeira ocorrencia, mas fica
+// com a versao que traz votos quando so uma delas os tem.
+func deduplicarSessoes(sessoesAPI []senadoapi.VotacaoSessaoAPI) []senadoapi.VotacaoSessaoAPI {
+	posicao := make(map[string]int, len(sessoesAPI))
+	unicas := make([]senadoapi.VotacaoSessaoAPI, 0, len(sessoesAPI))
+	for _, sessao := range sessoesAPI {
+		id := sessaoID(sessao)
+		i, ok := posicao[id]
+		if !ok {
+			posicao[id] = len(unicas)
+			unicas = append(unicas, sessao)
+			continue
+		}
+		if len(unicas[i].Votos) == 0 && len(sessao.Votos) > 0 {
+			unicas[i] = sessao
 		}
 	}
+	return unicas
+}
 
-	// Fallback: se nao conseguiu parsear a data, usa o campo Ano da API
-	// para criar uma data valida (1 de janeiro do ano)
-	// Isso garante que filtros por ano funcionem corretamente
-	anoFallback := sessao.Ano
-	if anoFallback == 0 {
-		// Extrair ano do sessao_id (formato: "123456_2023")
-		parts := strings.Split(strconv.Itoa(sessao.CodigoSessao)+"_"+strconv.Itoa(sessao.Ano), "_")
-		if len(parts) >= 2 {
-			if parsed, err := strconv.Atoi(parts[len(parts)-1]); err == nil && parsed >= 1988 && parsed <= 2100 {
-				anoFallback = parsed
-			}
+// sessaoID monta a chave da sessao no formato "CODIGO_ANO"
+func sessaoID(sessao senadoapi.VotacaoSessaoAPI) string {
+	return strconv.Itoa(sessao.CodigoSessao) + "_" + strconv.Itoa(sessao.Ano)
+}
+
+// parseDataSessao interpreta a data da sessao (ISO com hora, YYYY-MM-DD ou DD/MM/YYYY).
+// Sem hora, usa meio-dia UTC para evitar deslocamento de fuso. Se a data nao puder ser lida,
+// usa 1 de janeiro do ano da sessao para que os filtros por ano continuem funcionando.
+func parseDataSessao(sessao senadoapi.VotacaoSessaoAPI) time.Time {
+	if t, err := time.Parse("2006-01-02T15:04:05", sessao.DataSessao); err == nil {
+		return t
+	}
+	for _, layout := range []string{"2006-01-02", "02/01/2006"} {
+		if t, err := time.Parse(layout, sessao.DataSessao); err == nil {
+			return time.Date(t.Year(), t.Month(), t.Day(), 12, 0, 0, 0, time.UTC)
 		}
 	}
-	if !parsed && anoFallback > 0 {
-		// Meio-dia para evitar shift de timezone
-		data = time.Date(anoFallback, time.January, 1, 12, 0, 0, 0, time.UTC)
+	if sessao.Ano >= 1988 && sessao.Ano <= 2100 {
+		return time.Date(sessao.Ano, time.January, 1, 12, 0, 0, 0, time.UTC)
 	}
+	return time.Time{}
+}
 
-	return Votacao{
-		SenadorID:        senadorID,
-		SessaoID:         strconv.Itoa(sessao.CodigoSessao) + "_" + strconv.Itoa(sessao.Ano),
-		CodigoSessao:     strconv.Itoa(sessao.CodigoSessao),
-		Data:             data,
-		Voto:             normalizeVoto(siglaVoto),
-		DescricaoVotacao: sessao.DescricaoVotacao,
-		Materia:          "",
+// normalizeResultado converte o resultado informado pela API ("A", "Aprovado", "R", "Rejeitada"...)
+func normalizeResultado(resultado string) string {
+	switch r := strings.ToUpper(strings.TrimSpace(resultado)); {
+	case r == "":
+		return ""
+	case strings.HasPrefix(r, "A"):
+		return ResultadoAprovada
+	case strings.HasPrefix(r, "R"):
+		return ResultadoRejeitada
+	default:
+		return ""
+	}
+}
+
+// valoresSessoes retorna as sessoes de um mapa indexado por sessao_id
+func valoresSessoes(sessoes map[string]Sessao) []Sessao {
+	lista := make([]Sessao, 0, len(sessoes))
+	for _, s := range sessoes {
+		lista = append(lista, s)
 	}
+	return lista
 }
 
 func normalizeVoto(voto string) string {
@@ -263,6 +362,10 @@ func normalizeVoto(voto string) string {
 		return "Obstrucao"
 	case "Abstenção", "Abstencao":
 		return "Abstencao"
+	case "Lsp", "LS", "LP":
+		return VotoLicenca
+	case "MIS":
+		return VotoMissao
 	default:
 		return voto
 	}
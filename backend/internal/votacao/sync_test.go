@@ -0,0 +1,113 @@
+package votacao
+
+import (
+	"testing"
+	"time"
+
+	senadoapi "github.com/Alzarus/to-de-olho/pkg/senado"
+)
+
+func TestConvertSessao(t *testing.T) {
+	api := senadoapi.VotacaoSessaoAPI{
+		Ano:               2024,
+		CodigoSessao:      123,
+		DataSessao:        "2024-07-10",
+		DescricaoVotacao:  "Votacao em primeiro turno",
+		EmentaLegislativo: "Altera a Constituicao",
+		Materia:           senadoapi.MateriaRes{Sigla: "PEC", Numero: "10", Ano: "2024"},
+		VotacaoSecreta:    "N",
+		TotalVotosSim:     52,
+		TotalVotosNao:     18,
+	}
+
+	sessao := convertSessao(api)
+
+	if sessao.SessaoID != "123_2024" || sessao.Materia != "PEC 10/2024" {
+		t.Errorf("identificacao inesperada: %s %q", sessao.SessaoID, sessao.Materia)
+	}
+	if sessao.DescricaoVotacao != "Altera a Constituicao" {
+		t.Errorf("descricao deveria priorizar a ementa: %q", sessao.DescricaoVotacao)
+	}
+	if sessao.Secreta {
+		t.Error("votacao nao deveria ser secreta")
+	}
+	if sessao.Resultado != ResultadoAprovada {
+		t.Errorf("resultado = %q; esperado %q", sessao.Resultado, ResultadoAprovada)
+	}
+	if !sessao.Data.Equal(time.Date(2024, 7, 10, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("data inesperada: %v", sessao.Data)
+	}
+
+	// Resultado informado pela API prevalece sobre o placar
+	api.VotacaoSecreta = "S"
+	api.ResultadoVotacao = "R"
+	sessao = convertSessao(api)
+	if !sessao.Secreta || sessao.Resultado != ResultadoRejeitada {
+		t.Errorf("secreta/resultado inesperados: %v %q", sessao.Secreta, sessao.Resultado)
+	}
+}
+
+func TestParseDataSessaoFallbackAno(t *testing.T) {
+	data := parseDataSessao(senadoapi.VotacaoSessaoAPI{Ano: 2023, DataSessao: "invalida"})
+	if !data.Equal(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("fallback inesperado: %v", data)
+	}
+}
+
+func TestVotosDaSessao(t *testing.T) {
+	sessao := senadoapi.VotacaoSessaoAPI{
+		Ano:          2025,
+		CodigoSessao: 456,
+		DataSessao:   "2025-03-12T15:30:00",
+		Votos: []senadoapi.VotoParlamentar{
+			{CodigoParlamentar: 10, SiglaVoto: "Sim"},
+			{CodigoParlamentar: 20, SiglaVoto: "Não"},
+			{CodigoParlamentar: 30, SiglaVoto: "MIS"},
+			{CodigoParlamentar: 99, SiglaVoto: "Sim"}, // fora do banco
+			{CodigoParlamentar: 10, SiglaVoto: "Sim"}, // duplicado
+			{CodigoParlamentar: 40, SiglaVoto: ""},
+		},
+	}
+	codigoToID := map[int]int{10: 1, 20: 2, 30: 3, 40: 4}
+
+	votos := votosDaSessao(sessao, codigoToID)
+
+	if len(votos) != 3 {
+		t.Fatalf("votos = %d; esperado 3", len(votos))
+	}
+	esperados := map[int]string{1: "Sim", 2: "Nao", 3: VotoMissao}
+	for _, v := range votos {
+		if v.Voto != esperados[v.SenadorID] {
+			t.Errorf("senador %d: voto = %q; esperado %q", v.SenadorID, v.Voto, esperados[v.SenadorID])
+		}
+		if v.SessaoID != "456_2025" || v.Data.Hour() != 15 {
+			t.Errorf("sessao/data inesperadas: %s %v", v.SessaoID, v.Data)
+		}
+	}
+}
+
+func TestDeduplicarSessoes(t *testing.T) {
+	voto := []senadoapi.VotoParlamentar{{CodigoParlamentar: 10, SiglaVoto: "Sim"}}
+	sessoes := []senadoapi.VotacaoSessaoAPI{
+		{Ano: 2025, CodigoSessao: 1},
+		{Ano: 2025, CodigoSessao: 2, Votos: voto},
+		{Ano: 2025, CodigoSessao: 1, Votos: voto}, // repetida, agora com votos
+		{Ano: 2025, CodigoSessao: 2},              // repetida sem votos: descartada
+		{Ano: 2024, CodigoSessao: 1},              // mesmo codigo em outro ano
+	}
+
+	unicas := deduplicarSessoes(sessoes)
+
+	if len(unicas) != 3 {
+		t.Fatalf("sessoes = %d; esperado 3", len(unicas))
+	}
+	esperadas := []string{"1_2025", "2_2025", "1_2024"}
+	for i, sessao := range unicas {
+		if sessaoID(sessao) != esperadas[i] {
+			t.Errorf("posicao %d: sessao %s; esperado %s", i, sessaoID(sessao), esperadas[i])
+		}
+	}
+	if len(unicas[0].Votos) != 1 || len(unicas[1].Votos) != 1 {
+		t.Errorf("versao com votos deveria prevalecer: %+v", unicas[:2])
+	}
+}
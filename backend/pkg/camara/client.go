@@ -0,0 +1,224 @@
+package camara
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	BaseURL = "https://dadosabertos.camara.leg.br/api/v2"
+
+	// ItensPorPagina e o maximo aceito pela API para a maioria dos endpoints
+	ItensPorPagina = 100
+)
+
+// Client consome a API de Dados Abertos da Camara dos Deputados
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient cria um novo client
+func NewClient() *Client {
+	return &Client{
+		baseURL: BaseURL,
+		httpClient: &http.Client{
+			Timeout: 60 * time.Second,
+		},
+	}
+}
+
+// === TIPOS DE RESPOSTA DA API ===
+
+// Link representa um link de paginacao retornado pela API
+type Link struct {
+	Rel  string `json:"rel"`
+	Href string `json:"href"`
+}
+
+// DeputadoAPI representa um deputado retornado por /deputados
+type DeputadoAPI struct {
+	ID            int    `json:"id"`
+	Nome          string `json:"nome"`
+	SiglaPartido  string `json:"siglaPartido"`
+	SiglaUF       string `json:"siglaUf"`
+	IDLegislatura int    `json:"idLegislatura"`
+	URLFoto       string `json:"urlFoto"`
+	Email         string `json:"email"`
+}
+
+// DespesaAPI representa uma despesa da Cota Parlamentar (CEAP) de um deputado
+type DespesaAPI struct {
+	Ano               int     `json:"ano"`
+	Mes               int     `json:"mes"`
+	TipoDespesa       string  `json:"tipoDespesa"`
+	CodDocumento      int     `json:"codDocumento"`
+	TipoDocumento     string  `json:"tipoDocumento"`
+	DataDocumento     string  `json:"dataDocumento"` // YYYY-MM-DD ou YYYY-MM-DDTHH:MM:SS
+	NumDocumento      string  `json:"numDocumento"`
+	ValorDocumento    float64 `json:"valorDocumento"`
+	URLDocumento      string  `json:"urlDocumento"`
+	NomeFornecedor    string  `json:"nomeFornecedor"`
+	CNPJCPFFornecedor string  `json:"cnpjCpfFornecedor"`
+	ValorLiquido      float64 `json:"valorLiquido"`
+	ValorGlosa        float64 `json:"valorGlosa"`
+}
+
+// VotacaoAPI representa uma votacao retornada por /votacoes
+type VotacaoAPI struct {
+	ID               string `json:"id"` // Ex: "2367548-7"
+	Data             string `json:"data"`
+	DataHoraRegistro string `json:"dataHoraRegistro"`
+	SiglaOrgao       string `json:"siglaOrgao"`
+	Descricao        string `json:"descricao"`
+	Aprovacao        *int   `json:"aprovacao"` // 1 aprovada, 0 rejeitada, null quando nao se aplica
+}
+
+// VotoAPI representa o voto de um deputado em uma votacao
+type VotoAPI struct {
+	TipoVoto         string `json:"tipoVoto"` // Sim, Não, Abstenção, Obstrução, Artigo 17
+	DataRegistroVoto string `json:"dataRegistroVoto"`
+	Deputado         struct {
+		ID           int    `json:"id"`
+		Nome         string `json:"nome"`
+		SiglaPartido string `json:"siglaPartido"`
+		SiglaUF      string `json:"siglaUf"`
+	} `json:"deputado_"`
+}
+
+// === METODOS DO CLIENT ===
+
+// ListarDeputadosAtuais retorna os deputados em exercicio, percorrendo todas as paginas
+func (c *Client) ListarDeputadosAtuais(ctx context.Context) ([]DeputadoAPI, error) {
+	var deputados []DeputadoAPI
+
+	for pagina := 1; ; pagina++ {
+		params := url.Values{}
+		params.Set("ordem", "ASC")
+		params.Set("ordenarPor", "nome")
+		params.Set("itens", strconv.Itoa(ItensPorPagina))
+		params.Set("pagina", strconv.Itoa(pagina))
+
+		var result struct {
+			Dados []DeputadoAPI `json:"dados"`
+			Links []Link        `json:"links"`
+		}
+		if err := c.get(ctx, "/deputados", params, &result); err != nil {
+			return nil, err
+		}
+
+		deputados = append(deputados, result.Dados...)
+		if !temProximaPagina(result.Links) {
+			break
+		}
+	}
+
+	return deputados, nil
+}
+
+// ListarDespesasDeputado retorna uma pagina de despesas CEAP de um deputado em um ano
+// Retorna tambem se existe proxima pagina
+func (c *Client) ListarDespesasDeputado(ctx context.Context, idDeputado, ano, pagina int) ([]DespesaAPI, bool, error) {
+	params := url.Values{}
+	params.Set("ano", strconv.Itoa(ano))
+	params.Set("itens", strconv.Itoa(ItensPorPagina))
+	params.Set("pagina", strconv.Itoa(pagina))
+
+	var result struct {
+		Dados []DespesaAPI `json:"dados"`
+		Links []Link       `json:"links"`
+	}
+	if err := c.get(ctx, fmt.Sprintf("/deputados/%d/despesas", idDeputado), params, &result); err != nil {
+		return nil, false, err
+	}
+
+	return result.Dados, temProximaPagina(result.Links), nil
+}
+
+// ListarVotacoes retorna as votacoes registradas entre duas datas
+// NOTA: A API exige que o intervalo esteja dentro do mesmo ano e limita a ~3 meses
+func (c *Client) ListarVotacoes(ctx context.Context, dataInicio, dataFim time.Time) ([]VotacaoAPI, error) {
+	var votacoes []VotacaoAPI
+
+	for pagina := 1; ; pagina++ {
+		params := url.Values{}
+		params.Set("dataInicio", dataInicio.Format("2006-01-02"))
+		params.Set("dataFim", dataFim.Format("2006-01-02"))
+		params.Set("ordem", "ASC")
+		params.Set("ordenarPor", "dataHoraRegistro")
+		params.Set("itens", "200")
+		params.Set("pagina", strconv.Itoa(pagina))
+
+		var result struct {
+			Dados []VotacaoAPI `json:"dados"`
+			Links []Link       `json:"links"`
+		}
+		if err := c.get(ctx, "/votacoes", params, &result); err != nil {
+			return nil, err
+		}
+
+		votacoes = append(votacoes, result.Dados...)
+		if !temProximaPagina(result.Links) {
+			break
+		}
+	}
+
+	return votacoes, nil
+}
+
+// ListarVotosVotacao retorna os votos nominais de uma votacao
+// Votacoes simbolicas retornam lista vazia
+func (c *Client) ListarVotosVotacao(ctx context.Context, idVotacao string) ([]VotoAPI, error) {
+	var result struct {
+		Dados []VotoAPI `json:"dados"`
+	}
+	if err := c.get(ctx, fmt.Sprintf("/votacoes/%s/votos", url.PathEscape(idVotacao)), nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Dados, nil
+}
+
+// get executa um GET na API e decodifica o JSON de resposta em out
+func (c *Client) get(ctx context.Context, path string, params url.Values, out interface{}) error {
+	reqURL := c.baseURL + path
+	if len(params) > 0 {
+		reqURL += "?" + params.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return fmt.Errorf("erro criando request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("erro na requisicao: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status inesperado: %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("erro decodificando JSON: %w", err)
+	}
+
+	return nil
+}
+
+// temProximaPagina verifica se a lista de links da resposta aponta para uma proxima pagina
+func temProximaPagina(links []Link) bool {
+	for _, l := range links {
+		if l.Rel == "next" {
+			return true
+		}
+	}
+	return false
+}
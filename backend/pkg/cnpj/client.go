@@ -0,0 +1,83 @@
+package cnpj
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Alzarus/to-de-olho/pkg/retry"
+)
+
+// BaseURL da BrasilAPI (espelho publico dos dados abertos da Receita Federal)
+const BaseURL = "https://brasilapi.com.br/api/cnpj/v1"
+
+// ErrNaoEncontrado indica que o CNPJ nao existe na base da Receita
+var ErrNaoEncontrado = errors.New("cnpj nao encontrado")
+
+// Client consulta dados cadastrais de CNPJ
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient cria um novo client
+func NewClient() *Client {
+	return &Client{
+		baseURL: BaseURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// EmpresaAPI representa os dados cadastrais de um CNPJ
+type EmpresaAPI struct {
+	CNPJ                string `json:"cnpj"`
+	RazaoSocial         string `json:"razao_social"`
+	NomeFantasia        string `json:"nome_fantasia"`
+	DataInicioAtividade string `json:"data_inicio_atividade"` // YYYY-MM-DD
+	SituacaoCadastral   string `json:"descricao_situacao_cadastral"`
+	UF                  string `json:"uf"`
+	Municipio           string `json:"municipio"`
+}
+
+// Consultar busca os dados cadastrais de um CNPJ (apenas digitos)
+func (c *Client) Consultar(ctx context.Context, cnpj string) (*EmpresaAPI, error) {
+	reqURL := fmt.Sprintf("%s/%s", c.baseURL, cnpj)
+
+	var empresa EmpresaAPI
+	err := retry.WithRetry(ctx, 3, fmt.Sprintf("ConsultarCNPJ(%s)", cnpj), func() error {
+		req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		// 404 e definitivo; nao adianta tentar de novo
+		if resp.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("status inesperado: %d", resp.StatusCode)
+		}
+
+		return json.NewDecoder(resp.Body).Decode(&empresa)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if empresa.CNPJ == "" {
+		return nil, ErrNaoEncontrado
+	}
+
+	return &empresa, nil
+}
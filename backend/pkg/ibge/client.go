@@ -0,0 +1,109 @@
+package ibge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Alzarus/to-de-olho/pkg/retry"
+)
+
+// BaseURL da API de localidades do IBGE (publica, sem autenticacao)
+const BaseURL = "https://servicodados.ibge.gov.br/api/v1/localidades"
+
+// Client consulta a divisao territorial do IBGE
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient cria um novo client
+func NewClient() *Client {
+	return &Client{
+		baseURL: BaseURL,
+		httpClient: &http.Client{
+			Timeout: 60 * time.Second,
+		},
+	}
+}
+
+// UFAPI representa uma unidade da federacao
+type UFAPI struct {
+	ID     int    `json:"id"`
+	Sigla  string `json:"sigla"`
+	Nome   string `json:"nome"`
+	Regiao struct {
+		Sigla string `json:"sigla"`
+		Nome  string `json:"nome"`
+	} `json:"regiao"`
+}
+
+// MunicipioAPI representa um municipio. A UF aparece na microrregiao e na regiao
+// imediata; alguns municipios recentes vem sem microrregiao.
+type MunicipioAPI struct {
+	ID           int    `json:"id"`
+	Nome         string `json:"nome"`
+	Microrregiao *struct {
+		Mesorregiao struct {
+			UF UFAPI `json:"UF"`
+		} `json:"mesorregiao"`
+	} `json:"microrregiao"`
+	RegiaoImediata *struct {
+		RegiaoIntermediaria struct {
+			UF UFAPI `json:"UF"`
+		} `json:"regiao-intermediaria"`
+	} `json:"regiao-imediata"`
+}
+
+// UF retorna a unidade da federacao do municipio
+func (m MunicipioAPI) UF() UFAPI {
+	if m.Microrregiao != nil {
+		return m.Microrregiao.Mesorregiao.UF
+	}
+	if m.RegiaoImediata != nil {
+		return m.RegiaoImediata.RegiaoIntermediaria.UF
+	}
+	return UFAPI{}
+}
+
+// GetUFs lista as unidades da federacao
+// Endpoint: /estados
+func (c *Client) GetUFs(ctx context.Context) ([]UFAPI, error) {
+	var ufs []UFAPI
+	err := c.get(ctx, "/estados", "GetUFs", &ufs)
+	return ufs, err
+}
+
+// GetMunicipios lista todos os municipios do pais
+// Endpoint: /municipios
+func (c *Client) GetMunicipios(ctx context.Context) ([]MunicipioAPI, error) {
+	var municipios []MunicipioAPI
+	err := c.get(ctx, "/municipios", "GetMunicipios", &municipios)
+	return municipios, err
+}
+
+// get executa um GET com retry e decodifica a resposta em out
+func (c *Client) get(ctx context.Context, path, descricao string, out interface{}) error {
+	reqURL := c.baseURL + path
+	return retry.WithRetry(ctx, 3, descricao, func() error {
+		req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("status inesperado: %d", resp.StatusCode)
+		}
+
+		return json.NewDecoder(resp.Body).Decode(out)
+	})
+}
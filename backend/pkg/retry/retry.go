@@ -56,3 +56,16 @@ func WithRetry(ctx context.Context, maxAttempts int, operacao string, fn func()
 
 	return fmt.Errorf("todas as %d tentativas falharam para %s: %w", maxAttempts, operacao, lastErr)
 }
+
+// Sleep aguarda a duracao informada ou o cancelamento do contexto, o que vier primeiro.
+// Usado para espacar requisicoes sem prender a goroutine apos o cancelamento.
+func Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -70,3 +70,18 @@ func TestWithRetry_ContextCancellation(t *testing.T) {
 		t.Fatalf("esperava menos que 5 chamadas (cancelado), obteve %d", calls)
 	}
 }
+
+func TestSleep_ContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	inicio := time.Now()
+	if err := Sleep(ctx, time.Minute); !errors.Is(err, context.Canceled) {
+		t.Fatalf("esperava context.Canceled, obteve %v", err)
+	}
+	if time.Since(inicio) > time.Second {
+		t.Fatal("Sleep deveria retornar imediatamente apos o cancelamento")
+	}
+	if err := Sleep(context.Background(), time.Millisecond); err != nil {
+		t.Fatalf("esperava nil, obteve %v", err)
+	}
+}
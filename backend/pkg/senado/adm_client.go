@@ -92,3 +92,102 @@ func (c *AdmClient) ListarDespesasCEAPS(ctx context.Context, ano int) ([]Despesa
 
 	return result.Despesas, nil
 }
+
+// getJSON executa um GET na API Administrativa e decodifica a resposta em out
+func (c *AdmClient) getJSON(ctx context.Context, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("erro criando request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("erro na requisicao: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status inesperado: %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("erro decodificando JSON: %w", err)
+	}
+
+	return nil
+}
+
+// === SERVIDORES (GABINETES) ===
+
+// ServidorAPI representa um servidor ativo retornado pela API
+type ServidorAPI struct {
+	Sequencial  int    `json:"sequencial"` // Identificador estavel do servidor
+	Nome        string `json:"nome"`
+	Vinculo     string `json:"vinculo"`  // EFETIVO, COMISSIONADO, etc.
+	Situacao    string `json:"situacao"` // ATIVO, CEDIDO, etc.
+	Categoria   string `json:"categoria"`
+	AnoAdmissao int    `json:"ano_admissao"`
+	Cargo       *struct {
+		Nome string `json:"nome"`
+	} `json:"cargo"`
+	Funcao *struct {
+		Nome string `json:"nome"`
+	} `json:"funcao"`
+	Lotacao *struct {
+		Sigla string `json:"sigla"`
+		Nome  string `json:"nome"` // Ex: "Gabinete do Senador Fulano"
+	} `json:"lotacao"`
+}
+
+// RemuneracaoAPI representa a remuneracao mensal de um servidor
+type RemuneracaoAPI struct {
+	Sequencial           int     `json:"sequencial"`
+	Nome                 string  `json:"nome"`
+	Vinculo              string  `json:"vinculo"`
+	TipoFolha            string  `json:"tipo_folha"` // Normal, Suplementar, etc.
+	RemuneracaoBasica    float64 `json:"remuneracao_basica"`
+	VantagensPessoais    float64 `json:"vantagens_pessoais"`
+	FuncaoComissionada   float64 `json:"funcao_comissionada"`
+	GratificacaoNatalina float64 `json:"gratificacao_natalina"`
+	HorasExtras          float64 `json:"horas_extras"`
+	OutrasEventuais      float64 `json:"outras_remuneracoes_eventuais"`
+	AbonoPermanencia     float64 `json:"abono_permanencia"`
+	ReversaoTetoConst    float64 `json:"reversao_teto_constitucional"`
+	ImpostoRenda         float64 `json:"imposto_renda"`
+	Previdencia          float64 `json:"previdencia"`
+	Faltas               float64 `json:"faltas"`
+	RemuneracaoLiquida   float64 `json:"remuneracao_apos_descontos"`
+	Diarias              float64 `json:"diarias"`
+	Auxilios             float64 `json:"auxilios"`
+	VantagensIndenizat   float64 `json:"vantagens_indenizatorias"`
+}
+
+// RemuneracaoBruta soma as parcelas remuneratorias antes dos descontos
+func (r RemuneracaoAPI) RemuneracaoBruta() float64 {
+	return r.RemuneracaoBasica + r.VantagensPessoais + r.FuncaoComissionada +
+		r.GratificacaoNatalina + r.HorasExtras + r.OutrasEventuais + r.AbonoPermanencia +
+		r.ReversaoTetoConst
+}
+
+// ListarServidores busca os servidores ativos com a respectiva lotacao
+func (c *AdmClient) ListarServidores(ctx context.Context) ([]ServidorAPI, error) {
+	url := fmt.Sprintf("%s/api/v1/servidores/servidores/ativos", c.baseURL)
+
+	var servidores []ServidorAPI
+	if err := c.getJSON(ctx, url, &servidores); err != nil {
+		return nil, err
+	}
+	return servidores, nil
+}
+
+// ListarRemuneracoes busca a folha de pagamento de um mes
+func (c *AdmClient) ListarRemuneracoes(ctx context.Context, ano, mes int) ([]RemuneracaoAPI, error) {
+	url := fmt.Sprintf("%s/api/v1/servidores/remuneracoes/%d/%d", c.baseURL, ano, mes)
+
+	var remuneracoes []RemuneracaoAPI
+	if err := c.getJSON(ctx, url, &remuneracoes); err != nil {
+		return nil, err
+	}
+	return remuneracoes, nil
+}
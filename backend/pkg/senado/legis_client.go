@@ -156,10 +156,21 @@ type VotacaoSessaoAPI struct {
 	EmentaLegislativo  string            `json:"ementaLegislativo"`
 	IdentificacaoMateria string          `json:"identificacaoMateria"` // Ex: "PEC 10/2024"
 	Materia            MateriaRes        `json:"materia"`
-	VotacaoSecreta     string            `json:"votacaoSecreta"`
+	VotacaoSecreta     string            `json:"votacaoSecreta"` // "S" ou "N"
+	ResultadoVotacao   string            `json:"resultadoVotacao"`
 	TotalVotosSim      int               `json:"totalVotosSim"`
 	TotalVotosNao      int               `json:"totalVotosNao"`
 	Votos              []VotoParlamentar `json:"votos"`
+	// Orientacoes das liderancas (partidos, governo); ausente em boa parte das sessoes
+	OrientacoesLideranca []OrientacaoLiderancaAPI `json:"orientacoesLideranca"`
+}
+
+// OrientacaoLiderancaAPI representa a orientacao de voto de uma lideranca
+type OrientacaoLiderancaAPI struct {
+	CodigoParlamentar int    `json:"codigoParlamentar"`
+	NomeParlamentar   string `json:"nomeParlamentar"`
+	Partido           string `json:"partido"` // Sigla da bancada ou "Governo"
+	Voto              string `json:"voto"`    // Sim, Nao, Liberado...
 }
 
 // VotoParlamentar representa o voto de um parlamentar
@@ -396,3 +407,455 @@ func (c *LegisClient) ObterVotacao(ctx context.Context, codigoSessao string) (*V
 	
 	return &result[0], nil
 }
+
+// getJSON executa um GET na API Legislativa e decodifica a resposta em out
+func (c *LegisClient) getJSON(ctx context.Context, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("erro criando request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("erro na requisicao: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status inesperado: %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("erro decodificando JSON: %w", err)
+	}
+
+	return nil
+}
+
+// === DISCURSOS ===
+
+// DiscursosResponse representa a resposta de /senador/{codigo}/discursos
+type DiscursosResponse struct {
+	DiscursosParlamentar struct {
+		Parlamentar struct {
+			Pronunciamentos struct {
+				Pronunciamento Lista[PronunciamentoAPI] `json:"Pronunciamento"`
+			} `json:"Pronunciamentos"`
+		} `json:"Parlamentar"`
+	} `json:"DiscursosParlamentar"`
+}
+
+// PronunciamentoAPI representa um discurso (pronunciamento) de um parlamentar
+type PronunciamentoAPI struct {
+	CodigoPronunciamento    string `json:"CodigoPronunciamento"`
+	DataPronunciamento      string `json:"DataPronunciamento"` // YYYY-MM-DD
+	SiglaCasaPronunciamento string `json:"SiglaCasaPronunciamento"`
+	TextoResumo             string `json:"TextoResumo"`
+	Indexacao               string `json:"Indexacao"`
+	UrlTexto                string `json:"UrlTexto"`
+	TipoUsoPalavra          struct {
+		Codigo    string `json:"Codigo"`
+		Sigla     string `json:"Sigla"`
+		Descricao string `json:"Descricao"` // Discurso, Aparte, Encaminhamento, etc.
+	} `json:"TipoUsoPalavra"`
+	SessaoPlenaria struct {
+		CodigoSessao    string `json:"CodigoSessao"`
+		DataSessao      string `json:"DataSessao"`
+		SiglaCasaSessao string `json:"SiglaCasaSessao"`
+		SiglaTipoSessao string `json:"SiglaTipoSessao"` // DNO, NDE, SDO, etc.
+		NumeroSessao    string `json:"NumeroSessao"`
+	} `json:"SessaoPlenaria"`
+}
+
+// ListarDiscursosParlamentar busca discursos de um parlamentar em um intervalo de datas
+// Endpoint: /senador/{codigo}/discursos?dataInicio=YYYYMMDD&dataFim=YYYYMMDD
+func (c *LegisClient) ListarDiscursosParlamentar(ctx context.Context, codigoParlamentar int, dataInicio, dataFim time.Time) ([]PronunciamentoAPI, error) {
+	url := fmt.Sprintf("%s/senador/%d/discursos?dataInicio=%s&dataFim=%s",
+		c.baseURL, codigoParlamentar, dataInicio.Format("20060102"), dataFim.Format("20060102"))
+
+	var result DiscursosResponse
+	if err := c.getJSON(ctx, url, &result); err != nil {
+		return nil, err
+	}
+
+	return result.DiscursosParlamentar.Parlamentar.Pronunciamentos.Pronunciamento, nil
+}
+
+// === AGENDA ===
+
+// AgendaPlenarioResponse representa a resposta de /plenario/agenda/mes/{data}
+type AgendaPlenarioResponse struct {
+	AgendaPlenario struct {
+		Sessoes struct {
+			Sessao Lista[SessaoAgendaAPI] `json:"Sessao"`
+		} `json:"Sessoes"`
+	} `json:"AgendaPlenario"`
+}
+
+// SessaoAgendaAPI representa uma sessao prevista na agenda do plenario
+type SessaoAgendaAPI struct {
+	CodigoSessao     string `json:"CodigoSessao"`
+	SiglaCasa        string `json:"SiglaCasa"`
+	DataSessao       string `json:"DataSessao"`       // YYYY-MM-DD
+	HoraInicioSessao string `json:"HoraInicioSessao"` // HH:MM
+	TipoSessao       string `json:"TipoSessao"`
+	NumeroSessao     string `json:"NumeroSessao"`
+	SituacaoSessao   string `json:"SituacaoSessao"`
+	Evento           struct {
+		DescricaoTipoEvento string `json:"DescricaoTipoEvento"`
+	} `json:"Evento"`
+	Materias struct {
+		Materia Lista[MateriaAgendaAPI] `json:"Materia"`
+	} `json:"Materias"`
+}
+
+// MateriaAgendaAPI representa uma materia na ordem do dia
+type MateriaAgendaAPI struct {
+	SequenciaOrdem string `json:"SequenciaOrdem"`
+	CodigoMateria  string `json:"CodigoMateria"`
+	SiglaMateria   string `json:"SiglaMateria"`
+	NumeroMateria  string `json:"NumeroMateria"`
+	AnoMateria     string `json:"AnoMateria"`
+	Ementa         string `json:"Ementa"`
+	NomeRelator    string `json:"NomeRelator"`
+}
+
+// AgendaComissoesResponse representa a resposta de /comissao/agenda/{inicio}/{fim}
+type AgendaComissoesResponse struct {
+	AgendaReuniao struct {
+		Reunioes struct {
+			Reuniao Lista[ReuniaoAgendaAPI] `json:"Reuniao"`
+		} `json:"Reunioes"`
+	} `json:"AgendaReuniao"`
+}
+
+// ReuniaoAgendaAPI representa uma reuniao de comissao agendada
+type ReuniaoAgendaAPI struct {
+	Codigo   string `json:"Codigo"`
+	Data     string `json:"Data"` // YYYY-MM-DD
+	Hora     string `json:"Hora"` // HH:MM
+	Tipo     string `json:"Tipo"` // Deliberativa, Audiencia Publica, etc.
+	Situacao string `json:"Situacao"`
+	Titulo   string `json:"Titulo"`
+	Local    string `json:"Local"`
+	Comissao struct {
+		Codigo    string `json:"Codigo"`
+		Sigla     string `json:"Sigla"`
+		Nome      string `json:"Nome"`
+		SiglaCasa string `json:"SiglaCasa"`
+	} `json:"Comissao"`
+	Partes struct {
+		Parte Lista[ParteReuniaoAPI] `json:"Parte"`
+	} `json:"Partes"`
+}
+
+// ParteReuniaoAPI representa uma parte (bloco) da pauta de uma reuniao
+type ParteReuniaoAPI struct {
+	Descricao string `json:"Descricao"`
+	Itens     struct {
+		Item Lista[ItemPautaAPI] `json:"Item"`
+	} `json:"Itens"`
+}
+
+// ItemPautaAPI representa um item da pauta de uma reuniao de comissao
+type ItemPautaAPI struct {
+	Sequencial string `json:"Sequencial"`
+	Relator    string `json:"Relator"`
+	Materia    struct {
+		Codigo string `json:"Codigo"`
+		Sigla  string `json:"Sigla"`
+		Numero string `json:"Numero"`
+		Ano    string `json:"Ano"`
+		Ementa string `json:"Ementa"`
+	} `json:"Materia"`
+}
+
+// ListarAgendaPlenario retorna as sessoes do plenario previstas no mes da data informada
+// Endpoint: /plenario/agenda/mes/{YYYYMMDD}
+func (c *LegisClient) ListarAgendaPlenario(ctx context.Context, mes time.Time) ([]SessaoAgendaAPI, error) {
+	url := fmt.Sprintf("%s/plenario/agenda/mes/%s", c.baseURL, mes.Format("20060102"))
+
+	var result AgendaPlenarioResponse
+	if err := c.getJSON(ctx, url, &result); err != nil {
+		return nil, err
+	}
+
+	return result.AgendaPlenario.Sessoes.Sessao, nil
+}
+
+// ListarAgendaComissoes retorna as reunioes de comissoes agendadas em um intervalo de datas
+// Endpoint: /comissao/agenda/{YYYYMMDD}/{YYYYMMDD}
+func (c *LegisClient) ListarAgendaComissoes(ctx context.Context, dataInicio, dataFim time.Time) ([]ReuniaoAgendaAPI, error) {
+	url := fmt.Sprintf("%s/comissao/agenda/%s/%s", c.baseURL, dataInicio.Format("20060102"), dataFim.Format("20060102"))
+
+	var result AgendaComissoesResponse
+	if err := c.getJSON(ctx, url, &result); err != nil {
+		return nil, err
+	}
+
+	return result.AgendaReuniao.Reunioes.Reuniao, nil
+}
+
+// ReuniaoComissaoResponse representa a resposta de /comissao/reuniao/{codigo}
+type ReuniaoComissaoResponse struct {
+	Reuniao ReuniaoComissaoAPI `json:"Reuniao"`
+}
+
+// ReuniaoComissaoAPI representa os detalhes de uma reuniao de comissao, com a lista de presenca
+type ReuniaoComissaoAPI struct {
+	Codigo   string `json:"Codigo"`
+	Data     string `json:"Data"` // YYYY-MM-DD
+	Hora     string `json:"Hora"` // HH:MM
+	Tipo     string `json:"Tipo"`
+	Situacao string `json:"Situacao"`
+	Comissao struct {
+		Codigo    string `json:"Codigo"`
+		Sigla     string `json:"Sigla"`
+		Nome      string `json:"Nome"`
+		SiglaCasa string `json:"SiglaCasa"`
+	} `json:"Comissao"`
+	Presencas struct {
+		Parlamentar Lista[PresencaReuniaoAPI] `json:"Parlamentar"`
+	} `json:"Presencas"`
+}
+
+// PresencaReuniaoAPI representa um parlamentar registrado na lista de presenca da reuniao
+type PresencaReuniaoAPI struct {
+	CodigoParlamentar string `json:"CodigoParlamentar"`
+	NomeParlamentar   string `json:"NomeParlamentar"`
+	SiglaCasa         string `json:"SiglaCasa"` // SF ou CD (comissoes mistas)
+}
+
+// ObterReuniaoComissao busca os detalhes e a lista de presenca de uma reuniao de comissao
+// Endpoint: /comissao/reuniao/{codigo}
+func (c *LegisClient) ObterReuniaoComissao(ctx context.Context, codigoReuniao string) (*ReuniaoComissaoAPI, error) {
+	url := fmt.Sprintf("%s/comissao/reuniao/%s", c.baseURL, codigoReuniao)
+
+	var result ReuniaoComissaoResponse
+	if err := c.getJSON(ctx, url, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Reuniao, nil
+}
+
+// === RELATORIAS ===
+
+// RelatoriasResponse representa a resposta de /senador/{codigo}/relatorias
+type RelatoriasResponse struct {
+	RelatoriaParlamentar struct {
+		Parlamentar struct {
+			Relatorias struct {
+				Relatoria Lista[RelatoriaAPI] `json:"Relatoria"`
+			} `json:"Relatorias"`
+		} `json:"Parlamentar"`
+	} `json:"RelatoriaParlamentar"`
+}
+
+// RelatoriaAPI representa a designacao de um parlamentar como relator de uma materia
+type RelatoriaAPI struct {
+	IdentificacaoMateria struct {
+		CodigoMateria                 string `json:"CodigoMateria"`
+		SiglaSubtipoMateria           string `json:"SiglaSubtipoMateria"`
+		NumeroMateria                 string `json:"NumeroMateria"`
+		AnoMateria                    string `json:"AnoMateria"`
+		DescricaoIdentificacaoMateria string `json:"DescricaoIdentificacaoMateria"` // Ex: "PL 2338/2023"
+	} `json:"IdentificacaoMateria"`
+	EmentaMateria         string `json:"EmentaMateria"`
+	IdentificacaoComissao struct {
+		CodigoComissao    string `json:"CodigoComissao"`
+		SiglaComissao     string `json:"SiglaComissao"`
+		NomeComissao      string `json:"NomeComissao"`
+		SiglaCasaComissao string `json:"SiglaCasaComissao"`
+	} `json:"IdentificacaoComissao"`
+	DescricaoTipoRelator       string `json:"DescricaoTipoRelator"` // Relator, Relator ad hoc, Relator revisor
+	DataDesignacao             string `json:"DataDesignacao"`       // YYYY-MM-DD
+	DataDestituicao            string `json:"DataDestituicao,omitempty"`
+	DescricaoMotivoDestituicao string `json:"DescricaoMotivoDestituicao,omitempty"`
+	DataApresentacaoRelatorio  string `json:"DataApresentacaoRelatorio,omitempty"`
+}
+
+// ListarRelatoriasParlamentar busca as materias em que o parlamentar foi designado relator
+// Endpoint: /senador/{codigo}/relatorias
+func (c *LegisClient) ListarRelatoriasParlamentar(ctx context.Context, codigoParlamentar int) ([]RelatoriaAPI, error) {
+	url := fmt.Sprintf("%s/senador/%d/relatorias", c.baseURL, codigoParlamentar)
+
+	var result RelatoriasResponse
+	if err := c.getJSON(ctx, url, &result); err != nil {
+		return nil, err
+	}
+
+	return result.RelatoriaParlamentar.Parlamentar.Relatorias.Relatoria, nil
+}
+
+// LicencasResponse representa a resposta de /senador/{codigo}/licencas
+type LicencasResponse struct {
+	LicencaParlamentar struct {
+		Parlamentar struct {
+			Licencas struct {
+				Licenca Lista[LicencaAPI] `json:"Licenca"`
+			} `json:"Licencas"`
+		} `json:"Parlamentar"`
+	} `json:"LicencaParlamentar"`
+}
+
+// LicencaAPI representa um afastamento oficial (licenca ou missao) de um parlamentar
+type LicencaAPI struct {
+	Codigo                   string `json:"Codigo"`
+	DataInicio               string `json:"DataInicio"` // YYYY-MM-DD
+	DataFim                  string `json:"DataFim,omitempty"`
+	SiglaTipoAfastamento     string `json:"SiglaTipoAfastamento"` // Ex: LS, LP, MIS
+	DescricaoTipoAfastamento string `json:"DescricaoTipoAfastamento"`
+}
+
+// ListarLicencasParlamentar busca licencas e afastamentos oficiais de um parlamentar
+// a partir da data informada
+// Endpoint: /senador/{codigo}/licencas?dataInicio=YYYYMMDD
+func (c *LegisClient) ListarLicencasParlamentar(ctx context.Context, codigoParlamentar int, desde time.Time) ([]LicencaAPI, error) {
+	url := fmt.Sprintf("%s/senador/%d/licencas?dataInicio=%s", c.baseURL, codigoParlamentar, desde.Format("20060102"))
+
+	var result LicencasResponse
+	if err := c.getJSON(ctx, url, &result); err != nil {
+		return nil, err
+	}
+
+	return result.LicencaParlamentar.Parlamentar.Licencas.Licenca, nil
+}
+
+// MandatosResponse representa a resposta de /senador/{codigo}/mandatos
+type MandatosResponse struct {
+	MandatoParlamentar struct {
+		Parlamentar struct {
+			Mandatos struct {
+				Mandato Lista[MandatoAPI] `json:"Mandato"`
+			} `json:"Mandatos"`
+		} `json:"Parlamentar"`
+	} `json:"MandatoParlamentar"`
+}
+
+// LegislaturaMandatoAPI representa uma das legislaturas cobertas por um mandato
+type LegislaturaMandatoAPI struct {
+	NumeroLegislatura string `json:"NumeroLegislatura"`
+	DataInicio        string `json:"DataInicio"` // YYYY-MM-DD
+	DataFim           string `json:"DataFim"`
+}
+
+// ExercicioAPI representa um periodo de exercicio efetivo dentro de um mandato
+type ExercicioAPI struct {
+	CodigoExercicio           string `json:"CodigoExercicio"`
+	DataInicio                string `json:"DataInicio"`
+	DataFim                   string `json:"DataFim,omitempty"`
+	SiglaCausaAfastamento     string `json:"SiglaCausaAfastamento,omitempty"`
+	DescricaoCausaAfastamento string `json:"DescricaoCausaAfastamento,omitempty"`
+}
+
+// MandatoAPI representa um mandato de senador (duas legislaturas)
+type MandatoAPI struct {
+	CodigoMandato                string                `json:"CodigoMandato"`
+	UfParlamentar                string                `json:"UfParlamentar"`
+	PrimeiraLegislaturaDoMandato LegislaturaMandatoAPI `json:"PrimeiraLegislaturaDoMandato"`
+	SegundaLegislaturaDoMandato  LegislaturaMandatoAPI `json:"SegundaLegislaturaDoMandato"`
+	DescricaoParticipacao        string                `json:"DescricaoParticipacao"` // Titular, 1º Suplente, 2º Suplente
+	Exercicios                   struct {
+		Exercicio Lista[ExercicioAPI] `json:"Exercicio"`
+	} `json:"Exercicios"`
+}
+
+// ListarMandatosParlamentar busca o historico de mandatos e exercicios de um parlamentar
+// Endpoint: /senador/{codigo}/mandatos
+func (c *LegisClient) ListarMandatosParlamentar(ctx context.Context, codigoParlamentar int) ([]MandatoAPI, error) {
+	url := fmt.Sprintf("%s/senador/%d/mandatos", c.baseURL, codigoParlamentar)
+
+	var result MandatosResponse
+	if err := c.getJSON(ctx, url, &result); err != nil {
+		return nil, err
+	}
+
+	return result.MandatoParlamentar.Parlamentar.Mandatos.Mandato, nil
+}
+
+// FiliacoesResponse representa a resposta de /senador/{codigo}/filiacoes
+type FiliacoesResponse struct {
+	FiliacaoParlamentar struct {
+		Parlamentar struct {
+			Filiacoes struct {
+				Filiacao Lista[FiliacaoAPI] `json:"Filiacao"`
+			} `json:"Filiacoes"`
+		} `json:"Parlamentar"`
+	} `json:"FiliacaoParlamentar"`
+}
+
+// FiliacaoAPI representa um periodo de filiacao partidaria de um parlamentar
+type FiliacaoAPI struct {
+	Partido struct {
+		CodigoPartido string `json:"CodigoPartido"`
+		SiglaPartido  string `json:"SiglaPartido"`
+		NomePartido   string `json:"NomePartido"`
+	} `json:"Partido"`
+	DataFiliacao    string `json:"DataFiliacao"` // YYYY-MM-DD
+	DataDesfiliacao string `json:"DataDesfiliacao,omitempty"`
+}
+
+// ListarFiliacoesParlamentar busca o historico de filiacoes partidarias de um parlamentar
+// Endpoint: /senador/{codigo}/filiacoes
+func (c *LegisClient) ListarFiliacoesParlamentar(ctx context.Context, codigoParlamentar int) ([]FiliacaoAPI, error) {
+	url := fmt.Sprintf("%s/senador/%d/filiacoes", c.baseURL, codigoParlamentar)
+
+	var result FiliacoesResponse
+	if err := c.getJSON(ctx, url, &result); err != nil {
+		return nil, err
+	}
+
+	return result.FiliacaoParlamentar.Parlamentar.Filiacoes.Filiacao, nil
+}
+
+// MovimentacaoMateriaResponse representa a resposta de /materia/movimentacoes/{codigo}
+type MovimentacaoMateriaResponse struct {
+	MovimentacaoMateria struct {
+		Materia struct {
+			Tramitacoes struct {
+				Tramitacao Lista[TramitacaoAPI] `json:"Tramitacao"`
+			} `json:"Tramitacoes"`
+		} `json:"Materia"`
+	} `json:"MovimentacaoMateria"`
+}
+
+// LocalTramitacaoAPI representa o orgao (comissao, plenario, secretaria) de uma tramitacao
+type LocalTramitacaoAPI struct {
+	Local struct {
+		SiglaLocal     string `json:"SiglaLocal"`
+		NomeLocal      string `json:"NomeLocal"`
+		SiglaCasaLocal string `json:"SiglaCasaLocal"`
+	} `json:"Local"`
+}
+
+// TramitacaoAPI representa um passo processual de uma materia
+type TramitacaoAPI struct {
+	IdentificacaoTramitacao struct {
+		CodigoTramitacao      string             `json:"CodigoTramitacao"`
+		NumeroOrdemTramitacao string             `json:"NumeroOrdemTramitacao"`
+		DataTramitacao        string             `json:"DataTramitacao"` // YYYY-MM-DD
+		TextoTramitacao       string             `json:"TextoTramitacao"`
+		OrigemTramitacao      LocalTramitacaoAPI `json:"OrigemTramitacao"`
+		DestinoTramitacao     LocalTramitacaoAPI `json:"DestinoTramitacao"`
+		Situacao              struct {
+			CodigoSituacao    string `json:"CodigoSituacao"`
+			SiglaSituacao     string `json:"SiglaSituacao"`
+			DescricaoSituacao string `json:"DescricaoSituacao"`
+		} `json:"Situacao"`
+	} `json:"IdentificacaoTramitacao"`
+}
+
+// ListarTramitacaoMateria busca o historico de tramitacao de uma materia
+// Endpoint: /materia/movimentacoes/{codigo}
+func (c *LegisClient) ListarTramitacaoMateria(ctx context.Context, codigoMateria int) ([]TramitacaoAPI, error) {
+	url := fmt.Sprintf("%s/materia/movimentacoes/%d", c.baseURL, codigoMateria)
+
+	var result MovimentacaoMateriaResponse
+	if err := c.getJSON(ctx, url, &result); err != nil {
+		return nil, err
+	}
+
+	return result.MovimentacaoMateria.Materia.Tramitacoes.Tramitacao, nil
+}
@@ -0,0 +1,36 @@
+package senado
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Lista representa uma colecao da API Legislativa.
+// Quando ha um unico item, a API devolve um objeto em vez de um array;
+// Lista aceita os dois formatos (e null) de forma transparente.
+type Lista[T any] []T
+
+// UnmarshalJSON decodifica array, objeto unico ou null
+func (l *Lista[T]) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		*l = nil
+		return nil
+	}
+
+	if data[0] == '[' {
+		var itens []T
+		if err := json.Unmarshal(data, &itens); err != nil {
+			return err
+		}
+		*l = itens
+		return nil
+	}
+
+	var item T
+	if err := json.Unmarshal(data, &item); err != nil {
+		return err
+	}
+	*l = Lista[T]{item}
+	return nil
+}
@@ -0,0 +1,35 @@
+package senado
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestLista_ObjetoOuArray garante que colecoes com um unico item (objeto) sao aceitas
+func TestLista_ObjetoOuArray(t *testing.T) {
+	type item struct {
+		Codigo string `json:"Codigo"`
+	}
+
+	casos := []struct {
+		entrada  string
+		esperado int
+	}{
+		{`{"Itens": [{"Codigo": "1"}, {"Codigo": "2"}]}`, 2},
+		{`{"Itens": {"Codigo": "1"}}`, 1},
+		{`{"Itens": null}`, 0},
+		{`{}`, 0},
+	}
+
+	for _, caso := range casos {
+		var resp struct {
+			Itens Lista[item] `json:"Itens"`
+		}
+		if err := json.Unmarshal([]byte(caso.entrada), &resp); err != nil {
+			t.Fatalf("erro decodificando %s: %v", caso.entrada, err)
+		}
+		if len(resp.Itens) != caso.esperado {
+			t.Errorf("%s: esperado %d itens, obtido %d", caso.entrada, caso.esperado, len(resp.Itens))
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package transferegov
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Alzarus/to-de-olho/pkg/retry"
+)
+
+// BaseURL da API de transferencias especiais do Transferegov (PostgREST, sem autenticacao)
+const BaseURL = "https://api.transferegov.gestao.gov.br/transferenciasespeciais"
+
+// TamanhoPagina e o numero de registros pedido por pagina
+const TamanhoPagina = 500
+
+// Client consulta os planos de acao das transferencias especiais ("emendas PIX")
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient cria um novo client
+func NewClient() *Client {
+	return &Client{
+		baseURL: BaseURL,
+		httpClient: &http.Client{
+			Timeout: 60 * time.Second,
+		},
+	}
+}
+
+// PlanoAcaoAPI representa o plano de acao de uma transferencia especial: o ente
+// beneficiario (municipio ou estado), a emenda de origem e os valores previstos
+type PlanoAcaoAPI struct {
+	IDPlanoAcao             int64   `json:"id_plano_acao"`
+	CodigoPlanoAcao         string  `json:"codigo_plano_acao"`
+	AnoPlanoAcao            int     `json:"ano_plano_acao"`
+	Situacao                string  `json:"situacao_plano_acao"`
+	CNPJBeneficiario        string  `json:"cnpj_beneficiario_plano_acao"`
+	NomeBeneficiario        string  `json:"nome_beneficiario_plano_acao"`
+	UFBeneficiario          string  `json:"uf_beneficiario_plano_acao"`
+	NomeParlamentar         string  `json:"nome_parlamentar_emenda_plano_acao"`
+	AnoEmenda               int     `json:"ano_emenda_parlamentar_plano_acao"`
+	CodigoEmendaFormatado   string  `json:"codigo_emenda_parlamentar_formatado_plano_acao"`
+	AreasPoliticasPublicas  string  `json:"codigo_descricao_areas_politicas_publicas_plano_acao"`
+	ProgramacaoOrcamentaria string  `json:"descricao_programacao_orcamentaria_plano_acao"`
+	ValorCusteio            float64 `json:"valor_custeio_plano_acao"`
+	ValorInvestimento       float64 `json:"valor_investimento_plano_acao"`
+}
+
+// GetPlanosAcao lista os planos de acao das emendas de um ano, paginando por offset
+// Endpoint: /plano_acao_especial?ano_emenda_parlamentar_plano_acao=eq.ANO&limit=N&offset=M
+func (c *Client) GetPlanosAcao(ctx context.Context, anoEmenda, offset int) ([]PlanoAcaoAPI, error) {
+	params := url.Values{}
+	params.Add("ano_emenda_parlamentar_plano_acao", fmt.Sprintf("eq.%d", anoEmenda))
+	params.Add("order", "id_plano_acao")
+	params.Add("limit", fmt.Sprintf("%d", TamanhoPagina))
+	params.Add("offset", fmt.Sprintf("%d", offset))
+	reqURL := c.baseURL + "/plano_acao_especial?" + params.Encode()
+
+	var planos []PlanoAcaoAPI
+	err := retry.WithRetry(ctx, 3, fmt.Sprintf("GetPlanosAcao(ano=%d, offset=%d)", anoEmenda, offset), func() error {
+		req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("status inesperado: %d", resp.StatusCode)
+		}
+
+		planos = nil
+		return json.NewDecoder(resp.Body).Decode(&planos)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return planos, nil
+}
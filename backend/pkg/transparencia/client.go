@@ -72,11 +72,115 @@ func (c *Client) GetEmendasWithCtx(ctx context.Context, ano int, nomeAutor strin
 		params.Add("nomeAutor", nomeAutor)
 	}
 
-	reqURL := fmt.Sprintf("%s/emendas?%s", BaseURL, params.Encode())
-
 	var emendas []EmendaDTO
+	err := c.getJSON(ctx, "/emendas", params, fmt.Sprintf("GetEmendas(ano=%d, autor=%s, pag=%d)", ano, nomeAutor, pagina), &emendas)
+	return emendas, err
+}
 
-	err := retry.WithRetry(ctx, 3, fmt.Sprintf("GetEmendas(ano=%d, autor=%s, pag=%d)", ano, nomeAutor, pagina), func() error {
+// Valor aceita valores monetarios enviados como numero (1234.56) ou como texto
+// no formato brasileiro ("1.234,56")
+type Valor float64
+
+func (v *Valor) UnmarshalJSON(data []byte) error {
+	texto := string(data)
+	if texto == "null" {
+		*v = 0
+		return nil
+	}
+	if strings.HasPrefix(texto, `"`) {
+		*v = Valor(ParseMoney(strings.Trim(texto, `"`)))
+		return nil
+	}
+	f, err := strconv.ParseFloat(texto, 64)
+	if err != nil {
+		return fmt.Errorf("valor invalido: %s", texto)
+	}
+	*v = Valor(f)
+	return nil
+}
+
+// DocumentoEmendaDTO representa um documento de execucao (empenho, liquidacao ou pagamento)
+// vinculado a uma emenda
+type DocumentoEmendaDTO struct {
+	Data                    string `json:"data"` // DD/MM/AAAA
+	Fase                    string `json:"fase"` // Empenho, Liquidação, Pagamento
+	CodigoDocumento         string `json:"codigoDocumento"`
+	CodigoDocumentoResumido string `json:"codigoDocumentoResumido"`
+	EspecieTipo             string `json:"especieTipo"`
+}
+
+// DespesaDocumentoDTO detalha um documento de despesa, com o favorecido direto
+type DespesaDocumentoDTO struct {
+	Data              string `json:"data"`
+	Documento         string `json:"documento"`
+	DocumentoResumido string `json:"documentoResumido"`
+	Fase              string `json:"fase"`
+	Especie           string `json:"especie"`
+	Valor             Valor  `json:"valor"`
+	CodigoFavorecido  string `json:"codigoFavorecido"` // CPF/CNPJ ou codigo SIAFI
+	NomeFavorecido    string `json:"nomeFavorecido"`
+	UFFavorecido      string `json:"ufFavorecido"`
+	Ug                string `json:"ug"`
+	Orgao             string `json:"orgao"`
+	Observacao        string `json:"observacao"`
+}
+
+// FavorecidoFinalDTO representa quem recebeu o recurso ao final de um pagamento
+// (ex.: fornecedor contratado pelo municipio com a transferencia)
+type FavorecidoFinalDTO struct {
+	CodigoFavorecido string `json:"codigoFavorecido"`
+	NomeFavorecido   string `json:"nomeFavorecido"`
+	UF               string `json:"uf"`
+	Municipio        string `json:"municipio"`
+	Valor            Valor  `json:"valor"`
+}
+
+// GetDocumentosEmenda lista os documentos de execucao de uma emenda
+// Endpoint: /emendas/documentos/{codigo}?pagina=N
+func (c *Client) GetDocumentosEmenda(ctx context.Context, codigoEmenda string, pagina int) ([]DocumentoEmendaDTO, error) {
+	params := url.Values{}
+	params.Add("pagina", fmt.Sprintf("%d", pagina))
+
+	var documentos []DocumentoEmendaDTO
+	err := c.getJSON(ctx, "/emendas/documentos/"+url.PathEscape(codigoEmenda), params,
+		fmt.Sprintf("GetDocumentosEmenda(emenda=%s, pag=%d)", codigoEmenda, pagina), &documentos)
+	return documentos, err
+}
+
+// GetDocumentoDespesa busca o detalhe de um documento de despesa
+// Endpoint: /despesas/documentos/{codigo}
+func (c *Client) GetDocumentoDespesa(ctx context.Context, codigoDocumento string) (*DespesaDocumentoDTO, error) {
+	var documento DespesaDocumentoDTO
+	err := c.getJSON(ctx, "/despesas/documentos/"+url.PathEscape(codigoDocumento), nil,
+		fmt.Sprintf("GetDocumentoDespesa(documento=%s)", codigoDocumento), &documento)
+	if err != nil {
+		return nil, err
+	}
+	return &documento, nil
+}
+
+// GetFavorecidosFinais lista os favorecidos finais de um documento de pagamento
+// Endpoint: /despesas/favorecidos-finais-por-documento?codigoDocumento=X&pagina=N
+func (c *Client) GetFavorecidosFinais(ctx context.Context, codigoDocumento string, pagina int) ([]FavorecidoFinalDTO, error) {
+	params := url.Values{}
+	params.Add("codigoDocumento", codigoDocumento)
+	params.Add("pagina", fmt.Sprintf("%d", pagina))
+
+	var favorecidos []FavorecidoFinalDTO
+	err := c.getJSON(ctx, "/despesas/favorecidos-finais-por-documento", params,
+		fmt.Sprintf("GetFavorecidosFinais(documento=%s, pag=%d)", codigoDocumento, pagina), &favorecidos)
+	return favorecidos, err
+}
+
+// getJSON executa um GET autenticado na API com retry e decodifica a resposta em out.
+// Respostas com corpo vazio mantem out inalterado.
+func (c *Client) getJSON(ctx context.Context, path string, params url.Values, descricao string, out interface{}) error {
+	reqURL := BaseURL + path
+	if len(params) > 0 {
+		reqURL += "?" + params.Encode()
+	}
+
+	return retry.WithRetry(ctx, 3, descricao, func() error {
 		req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
 		if err != nil {
 			return err
@@ -108,17 +212,13 @@ func (c *Client) GetEmendasWithCtx(ctx context.Context, ano int, nomeAutor strin
 
 		// Verificar se body esta vazio
 		if resp.ContentLength == 0 {
-			emendas = []EmendaDTO{}
 			return nil
 		}
 
-		if err := json.NewDecoder(resp.Body).Decode(&emendas); err != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
 			return fmt.Errorf("erro decode: %w", err)
 		}
 
 		return nil
 	})
-
-	return emendas, err
 }
-
@@ -0,0 +1,31 @@
+package transparencia
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestValorUnmarshalJSON(t *testing.T) {
+	casos := map[string]float64{
+		`1234.56`:        1234.56,
+		`"1.234.567,89"`: 1234567.89,
+		`"R$ 10,00"`:     10,
+		`""`:             0,
+		`null`:           0,
+	}
+	for entrada, esperado := range casos {
+		var v Valor
+		if err := json.Unmarshal([]byte(entrada), &v); err != nil {
+			t.Errorf("Unmarshal(%s) retornou erro: %v", entrada, err)
+			continue
+		}
+		if float64(v) != esperado {
+			t.Errorf("Unmarshal(%s) = %v, esperado %v", entrada, v, esperado)
+		}
+	}
+
+	var v Valor
+	if err := json.Unmarshal([]byte(`true`), &v); err == nil {
+		t.Error("esperado erro para valor nao numerico")
+	}
+}
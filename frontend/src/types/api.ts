@@ -10,7 +10,8 @@ export interface ScoreDetalhes {
   // Presenca
   total_votacoes: number;
   votacoes_participadas: number;
-  taxa_presenca_bruta: number;
+  taxa_presenca: number; // desconsidera ausencias justificadas (usada no score)
+  taxa_presenca_bruta: number; // conta todas as ausencias
 
   // Economia CEAPS
   gasto_ceaps: number;